@@ -2,9 +2,12 @@ package main
 
 import (
 	"github.com/abhishekkkk-15/devcon/agent/internal/app"
+	"github.com/abhishekkkk-15/devcon/agent/internal/config"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/detect"
 	"github.com/abhishekkkk-15/devcon/agent/internal/core/service"
 	"github.com/abhishekkkk-15/devcon/agent/internal/core/util"
 	"github.com/abhishekkkk-15/devcon/agent/internal/infra/docker"
+	"github.com/abhishekkkk-15/devcon/agent/internal/infra/store"
 	"github.com/abhishekkkk-15/devcon/agent/internal/infra/system"
 	"github.com/abhishekkkk-15/devcon/agent/internal/transport/cli"
 	"github.com/abhishekkkk-15/devcon/agent/internal/transport/cli/commands"
@@ -19,13 +22,67 @@ func main() {
 		panic(err)
 	}
 	systemRepo := system.NewSystemRepo()
+	stackStore, err := store.NewStackStore(config.DataDir())
+	if err != nil {
+		panic(err)
+	}
+	backupStore, err := store.NewBackupStore(config.BackupsDir(), config.DataDir())
+	if err != nil {
+		panic(err)
+	}
+	desiredStateStore, err := store.NewDesiredStateStore(config.DataDir())
+	if err != nil {
+		panic(err)
+	}
+	lifecycleStore, err := store.NewLifecycleStore(config.DataDir())
+	if err != nil {
+		panic(err)
+	}
+	scheduleStore, err := store.NewScheduleStore(config.DataDir())
+	if err != nil {
+		panic(err)
+	}
+	gcStore, err := store.NewGCStore(config.DataDir())
+	if err != nil {
+		panic(err)
+	}
+	trashStore, err := store.NewTrashStore(config.DataDir())
+	if err != nil {
+		panic(err)
+	}
 
 	// --- Core Services ---
 	containerService := service.NewContainerService(dockerDaemon)
 	systemService := service.NewSystemService(systemRepo)
+	stackService := service.NewStackService(stackStore)
+	templateService := service.NewTemplateService(store.NewTemplateStore(config.TemplatesDir()))
+	backupService := service.NewBackupService(backupStore)
+	reconcileService := service.NewReconcileService(desiredStateStore)
+	lifecycleService := service.NewLifecycleService(lifecycleStore)
+	scheduleService := service.NewScheduleService(scheduleStore)
+	gcService := service.NewGCService(gcStore)
+	trashService := service.NewTrashService(trashStore)
+
+	detector := detect.NewDefaultRegistry()
+	rules, err := detect.LoadRules(config.DetectRulesFile())
+	if err != nil {
+		panic(err)
+	}
+	detector.Prepend(detect.RuleDetectors(rules)...)
 
 	// --- Application Layer ---
-	containerApp := app.NewContainerApp(*containerService)
+	containerApp := app.NewContainerApp(app.ContainerServices{
+		Container: *containerService,
+		Stack:     stackService,
+		Template:  templateService,
+		Detector:  detector,
+		Backup:    backupService,
+		Reconcile: reconcileService,
+		Lifecycle: lifecycleService,
+		Schedule:  scheduleService,
+		GC:        gcService,
+		Trash:     trashService,
+	})
 	systemApp := app.NewSystemApp(systemService)
 
 	// --- CLI Transport ---
@@ -33,6 +90,24 @@ func main() {
 	rootCmd.AddCommand(commands.NewListCmd(containerApp))
 	rootCmd.AddCommand(commands.NewDevconCommand(containerApp))
 	rootCmd.AddCommand(commands.NewStartServer(containerApp, systemApp))
+	rootCmd.AddCommand(commands.NewStackCmd(containerApp))
+	rootCmd.AddCommand(commands.NewServiceCmd(containerApp))
+	rootCmd.AddCommand(commands.NewUpCmd(containerApp))
+	rootCmd.AddCommand(commands.NewTemplateCmd(containerApp))
+	rootCmd.AddCommand(commands.NewURLCmd(containerApp))
+	rootCmd.AddCommand(commands.NewPostgresCmd(containerApp))
+	rootCmd.AddCommand(commands.NewRedisCmd(containerApp))
+	rootCmd.AddCommand(commands.NewBackupCmd(containerApp))
+	rootCmd.AddCommand(commands.NewCloneCmd(containerApp))
+	rootCmd.AddCommand(commands.NewPlanCmd(containerApp))
+	rootCmd.AddCommand(commands.NewApplyCmd(containerApp))
+	rootCmd.AddCommand(commands.NewDriftCmd(containerApp))
+	rootCmd.AddCommand(commands.NewLifecycleCmd(containerApp))
+	rootCmd.AddCommand(commands.NewScheduleCmd(containerApp))
+	rootCmd.AddCommand(commands.NewGCCmd(containerApp))
+	rootCmd.AddCommand(commands.NewBulkCmd(containerApp))
+	rootCmd.AddCommand(commands.NewTrashCmd(containerApp))
+	rootCmd.AddCommand(commands.NewGroupCmd(containerApp))
 
 	if err := rootCmd.Execute(); err != nil {
 		panic(err)
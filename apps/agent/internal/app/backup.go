@@ -0,0 +1,387 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/backup"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/connection"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/redis"
+)
+
+func (a *ContainerApp) CreateBackup(ctx context.Context, identifier string, req domain.BackupRequest) (*domain.Backup, error) {
+	created, err := a.createBackup(ctx, identifier, req.Database, backup.TriggerManual)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := a.backupService.GetPolicy(ctx, created.Resource)
+	if err != nil {
+		return nil, err
+	}
+	if policy != nil {
+		if _, err := a.backupService.ApplyRetention(ctx, created.Resource, policy.Retention); err != nil {
+			return nil, err
+		}
+	}
+	return created, nil
+}
+
+func (a *ContainerApp) ListBackups(ctx context.Context, resource string) ([]domain.Backup, error) {
+	return a.backupService.ListBackups(ctx, resource)
+}
+
+func (a *ContainerApp) GetBackup(ctx context.Context, id string) (*domain.Backup, error) {
+	b, err := a.backupService.GetBackup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if b == nil {
+		return nil, fmt.Errorf("backup %s not found", id)
+	}
+	return b, nil
+}
+
+func (a *ContainerApp) OpenBackup(ctx context.Context, id string) (*domain.Backup, io.ReadCloser, error) {
+	b, err := a.GetBackup(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	content, err := a.backupService.OpenBackup(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	return b, content, nil
+}
+
+func (a *ContainerApp) DeleteBackup(ctx context.Context, id string) error {
+	if _, err := a.GetBackup(ctx, id); err != nil {
+		return err
+	}
+	return a.backupService.DeleteBackup(ctx, id)
+}
+
+// RestoreBackup loads a dump into a running resource of the same type. SQL
+// dumps are replayed through the database client; redis resources are
+// restarted on the restored RDB file.
+func (a *ContainerApp) RestoreBackup(ctx context.Context, id string, req domain.RestoreRequest) (*domain.RestoreResult, error) {
+	b, err := a.GetBackup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	target := req.Target
+	if target == "" {
+		target = b.Resource
+	}
+	res, err := a.resolveRunning(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+	if backup.FormatFor(res.resourceType) != b.Format {
+		return nil, fmt.Errorf("cannot restore a %s backup into %s resource %s", b.Format, res.resourceType, res.name)
+	}
+
+	content, err := a.backupService.OpenBackup(ctx, b.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer content.Close()
+
+	if b.Format == backup.FormatRDB {
+		if err := a.restoreRedis(ctx, res, content); err != nil {
+			return nil, err
+		}
+		return &domain.RestoreResult{Backup: b.ID, Target: res.name}, nil
+	}
+
+	plan, err := backup.RestorePlan(*b, res.env, req.Database)
+	if err != nil {
+		return nil, err
+	}
+	result, err := a.containerService.ExecContainer(ctx, res.id, domain.ExecSpec{
+		Cmd:   plan.Cmd,
+		Env:   plan.Env,
+		Stdin: io.MultiReader(strings.NewReader(plan.Prefix), content),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result.ExitCode != 0 {
+		return nil, execError("restore", result)
+	}
+	return &domain.RestoreResult{Backup: b.ID, Target: res.name, Database: plan.Database}, nil
+}
+
+func (a *ContainerApp) ListBackupPolicies(ctx context.Context) ([]domain.BackupPolicy, error) {
+	return a.backupService.ListPolicies(ctx)
+}
+
+// SetBackupPolicy schedules backups of a resource, replacing any previous
+// policy. The resource does not have to be running.
+func (a *ContainerApp) SetBackupPolicy(ctx context.Context, identifier string, policy domain.BackupPolicy) (*domain.BackupPolicy, error) {
+	summary, err := a.containerService.FindContainer(ctx, identifier)
+	if err != nil {
+		return nil, err
+	}
+	if summary.ID == "" {
+		return nil, fmt.Errorf("resource %s not found", identifier)
+	}
+	if resourceType := a.detectType(summary); backup.FormatFor(resourceType) == "" {
+		return nil, fmt.Errorf("backups are not supported for resource type %s", resourceType)
+	}
+	if err := backup.ValidateSchedule(policy.Schedule); err != nil {
+		return nil, err
+	}
+	if policy.Retention.KeepLast < 0 || policy.Retention.MaxAgeDays < 0 {
+		return nil, fmt.Errorf("retention values cannot be negative")
+	}
+
+	policy.Resource = firstContainerName(summary.Names)
+	if existing, err := a.backupService.GetPolicy(ctx, policy.Resource); err != nil {
+		return nil, err
+	} else if existing != nil {
+		policy.LastRunAt, policy.LastBackup, policy.LastError = existing.LastRunAt, existing.LastBackup, existing.LastError
+	}
+	if err := a.backupService.SavePolicy(ctx, &policy); err != nil {
+		return nil, err
+	}
+	if err := a.syncBackupSchedules(ctx); err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+func (a *ContainerApp) DeleteBackupPolicy(ctx context.Context, resource string) error {
+	if err := a.backupService.DeletePolicy(ctx, resource); err != nil {
+		return err
+	}
+	return a.syncBackupSchedules(ctx)
+}
+
+// StartBackupScheduler runs backup policies on their schedules. Policies are
+// re-read every minute so changes made from another process are picked up.
+func (a *ContainerApp) StartBackupScheduler() error {
+	a.backupScheduler = newCronSync("backup scheduler")
+	return a.backupScheduler.start(func() error { return a.syncBackupSchedules(context.Background()) })
+}
+
+func (a *ContainerApp) syncBackupSchedules(ctx context.Context) error {
+	if a.backupScheduler == nil {
+		return nil
+	}
+	policies, err := a.backupService.ListPolicies(ctx)
+	if err != nil {
+		return err
+	}
+	wanted := make(map[string]string, len(policies))
+	for _, policy := range policies {
+		if !policy.Disabled {
+			wanted[policy.Resource] = policy.Schedule
+		}
+	}
+	a.backupScheduler.sync(wanted, a.runScheduledBackup)
+	return nil
+}
+
+func (a *ContainerApp) runScheduledBackup(resource string) {
+	ctx := context.Background()
+	policy, err := a.backupService.GetPolicy(ctx, resource)
+	if err != nil || policy == nil || policy.Disabled {
+		return
+	}
+
+	created, err := a.createBackup(ctx, resource, policy.Database, backup.TriggerSchedule)
+	if err == nil {
+		_, err = a.backupService.ApplyRetention(ctx, resource, policy.Retention)
+	}
+
+	// Re-read so edits made while the dump ran are kept.
+	latest, getErr := a.backupService.GetPolicy(ctx, resource)
+	if getErr != nil || latest == nil {
+		return
+	}
+	latest.LastRunAt = time.Now().Unix()
+	latest.LastError = ""
+	if created != nil {
+		latest.LastBackup = created.ID
+	}
+	if err != nil {
+		latest.LastError = err.Error()
+		log.Printf("backup of %s failed: %v", resource, err)
+	}
+	if err := a.backupService.SavePolicy(ctx, latest); err != nil {
+		log.Printf("backup of %s: saving policy: %v", resource, err)
+	}
+}
+
+// createBackup streams a dump from the container straight into the backup
+// store.
+func (a *ContainerApp) createBackup(ctx context.Context, identifier, database, trigger string) (*domain.Backup, error) {
+	res, err := a.resolveRunning(ctx, identifier)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := backup.DumpPlan(res.resourceType, res.env, database)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	id := backup.NewID(res.name, now)
+	created := &domain.Backup{
+		ID:           id,
+		Resource:     res.name,
+		ResourceType: res.resourceType,
+		Format:       plan.Format,
+		Database:     plan.Database,
+		File:         id + plan.Extension,
+		Trigger:      trigger,
+		CreatedAt:    now.Unix(),
+	}
+
+	reader, writer := io.Pipe()
+	go func() {
+		result, err := a.containerService.ExecContainer(ctx, res.id, domain.ExecSpec{Cmd: plan.Cmd, Env: plan.Env, Stdout: writer})
+		if err == nil && result.ExitCode != 0 {
+			err = execError(plan.Format, result)
+		}
+		writer.CloseWithError(err)
+	}()
+	if err := a.backupService.CreateBackup(ctx, created, reader); err != nil {
+		reader.CloseWithError(err)
+		return nil, err
+	}
+	return created, nil
+}
+
+// redisAOFRestoreScript replaces the append only files with the staged dump,
+// which redis loads as an AOF base with an RDB preamble. Redis 7 keeps them
+// in a directory with a manifest, older versions in a single file. The files
+// get the owner of the data directory since the server may not run as root.
+const redisAOFRestoreScript = `set -e
+cd "$1"
+owner=$(stat -c %u:%g .)
+if [ -n "$4" ]; then
+  rm -rf "$4.devcon-restore"
+  mkdir "$4.devcon-restore"
+  cp "$2.devcon-restore" "$4.devcon-restore/$3.1.base.rdb"
+  printf 'file %s seq 1 type b\n' "$3.1.base.rdb" > "$4.devcon-restore/$3.manifest"
+  chown -R "$owner" "$4.devcon-restore"
+  rm -rf "$4"
+  mv "$4.devcon-restore" "$4"
+else
+  cp "$2.devcon-restore" "$3"
+  chown "$owner" "$3"
+fi`
+
+// restoreRedis swaps the RDB file under a stopped server: the dump is staged
+// next to the data file, moved into place, and redis is shut down without
+// saving so the container restarts on the restored data. With appendonly on,
+// redis would load its AOF instead, so AOF is switched off while the files
+// are replaced by the dump and comes back on with the restart.
+func (a *ContainerApp) restoreRedis(ctx context.Context, res *runningResource, content io.Reader) error {
+	var env []string
+	if creds, _ := connection.CredentialsFor("redis", res.env); creds.Password != "" {
+		env = []string{"REDISCLI_AUTH=" + creds.Password}
+	}
+
+	result, err := a.containerService.ExecContainer(ctx, res.id, domain.ExecSpec{
+		Cmd: []string{"sh", "-c", "redis-cli CONFIG GET appendonly && redis-cli CONFIG GET dir && redis-cli CONFIG GET dbfilename && redis-cli CONFIG GET appendfilename && redis-cli CONFIG GET appenddirname"},
+		Env: env,
+	})
+	if err != nil {
+		return err
+	}
+	if result.ExitCode != 0 {
+		return execError("redis-cli", result)
+	}
+	config, err := redis.ParseConfig(result.Stdout)
+	if err != nil {
+		return err
+	}
+	if config["dir"] == "" || config["dbfilename"] == "" {
+		return fmt.Errorf("could not determine the data file of %s", res.name)
+	}
+	dataFile := strings.TrimSuffix(config["dir"], "/") + "/" + config["dbfilename"]
+	appendOnly := config["appendonly"] == "yes"
+	if appendOnly && config["appendfilename"] == "" {
+		return fmt.Errorf("could not determine the append only file of %s", res.name)
+	}
+
+	before, err := a.containerService.InsepectContainer(ctx, res.id)
+	if err != nil {
+		return err
+	}
+
+	result, err = a.containerService.ExecContainer(ctx, res.id, domain.ExecSpec{
+		Cmd:   []string{"sh", "-c", `cat > "$1.devcon-restore" && chmod 644 "$1.devcon-restore"`, "sh", dataFile},
+		Stdin: content,
+	})
+	if err != nil {
+		return err
+	}
+	if result.ExitCode != 0 {
+		return execError("restore", result)
+	}
+
+	if appendOnly {
+		setAppendOnly := func(value string) error {
+			out, err := a.runRedisCli(ctx, res.id, redis.Command(0, "CONFIG", "SET", "appendonly", value))
+			if err != nil {
+				return err
+			}
+			return redis.ParseOK(out)
+		}
+		if err := setAppendOnly("no"); err != nil {
+			return err
+		}
+		result, err = a.containerService.ExecContainer(ctx, res.id, domain.ExecSpec{
+			Cmd: []string{"sh", "-c", redisAOFRestoreScript, "sh", config["dir"], config["dbfilename"], config["appendfilename"], config["appenddirname"]},
+		})
+		if err == nil && result.ExitCode != 0 {
+			err = execError("restore", result)
+		}
+		if err != nil {
+			_ = setAppendOnly("yes")
+			return err
+		}
+	}
+
+	// The exec ends with the server, so its error is expected and ignored.
+	_, _ = a.containerService.ExecContainer(ctx, res.id, domain.ExecSpec{
+		Cmd: []string{"sh", "-c", `mv "$1.devcon-restore" "$1" && redis-cli SHUTDOWN NOSAVE`, "sh", dataFile},
+		Env: env,
+	})
+
+	deadline := time.Now().Add(30 * time.Second)
+	for {
+		inspect, err := a.containerService.InsepectContainer(ctx, res.id)
+		if err != nil {
+			return err
+		}
+		if !inspect.Container.State.Running {
+			break
+		}
+		// A restart policy may already have brought it back up.
+		if inspect.Container.State.StartedAt != before.Container.State.StartedAt {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("resource %s did not stop after the restore", res.name)
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+	return a.containerService.StartContainer(ctx, res.id)
+}
+
+func execError(name string, result *domain.ExecResult) error {
+	message := strings.TrimSpace(string(result.Stderr))
+	if message == "" {
+		message = strings.TrimSpace(string(result.Stdout))
+	}
+	return fmt.Errorf("%s exited with code %d: %s", name, result.ExitCode, message)
+}
@@ -0,0 +1,148 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/bulk"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+)
+
+// shortIDLength is the shortest ID prefix a bulk request may use, the length
+// docker prints.
+const shortIDLength = 12
+
+// Bulk runs an action on every selected resource, at most req.Concurrency at
+// a time. A failure is recorded in that resource's result and does not stop
+// the others; the returned error is only about the request itself.
+func (a *ContainerApp) Bulk(ctx context.Context, action string, req domain.BulkRequest) (*domain.BulkReport, error) {
+	if err := bulk.Validate(action, &req); err != nil {
+		return nil, err
+	}
+	results, err := a.selectResources(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.BulkReport{Action: action, DryRun: req.DryRun, Results: results}
+	if !req.DryRun {
+		var wg sync.WaitGroup
+		slots := make(chan struct{}, req.Concurrency)
+		for i := range results {
+			if results[i].Error != "" {
+				continue
+			}
+			wg.Add(1)
+			slots <- struct{}{}
+			go func(result *domain.BulkResult) {
+				defer func() {
+					<-slots
+					wg.Done()
+				}()
+				begin := time.Now()
+				if err := a.bulkAction(ctx, action, result.ID); err != nil {
+					result.Error = err.Error()
+				}
+				result.DurationMs = time.Since(begin).Milliseconds()
+			}(&report.Results[i])
+		}
+		wg.Wait()
+	}
+
+	for i := range report.Results {
+		result := &report.Results[i]
+		result.OK = result.Error == ""
+		if result.OK {
+			report.Succeeded++
+		} else {
+			report.Failed++
+		}
+	}
+	return report, nil
+}
+
+// selectResources resolves the request to one result per resource, in the
+// order given or by name. IDs that match nothing get a failed result.
+func (a *ContainerApp) selectResources(ctx context.Context, req domain.BulkRequest) ([]domain.BulkResult, error) {
+	selector, err := bulk.ParseSelector(req.Selector)
+	if err != nil {
+		return nil, err
+	}
+	containers, err := a.containerService.ListContainers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(req.IDs))
+	wanted := make(map[string]int, len(req.IDs))
+	for _, id := range req.IDs {
+		id = strings.TrimSpace(id)
+		if _, ok := wanted[id]; ok || id == "" {
+			continue
+		}
+		wanted[id] = len(ids)
+		ids = append(ids, id)
+	}
+	results := make([]domain.BulkResult, 0)
+	found := make(map[int]bool, len(ids))
+	picked := make(map[int]domain.BulkResult, len(ids))
+	for _, c := range containers.Items {
+		name := firstContainerName(c.Names)
+		index := -1
+		if len(ids) > 0 {
+			for id, i := range wanted {
+				if id == name || id == c.ID || (len(id) >= shortIDLength && strings.HasPrefix(c.ID, id)) {
+					index = i
+				}
+			}
+			if index < 0 {
+				continue
+			}
+			found[index] = true
+		}
+		if req.Type != "" && !strings.EqualFold(a.detectType(c), req.Type) {
+			continue
+		}
+		if !selector.Matches(c.Labels) {
+			continue
+		}
+		result := domain.BulkResult{ID: c.ID, Name: name}
+		if index >= 0 {
+			picked[index] = result
+		} else {
+			results = append(results, result)
+		}
+	}
+
+	if len(ids) == 0 {
+		sort.Slice(results, func(i, j int) bool { return results[i].Name < results[j].Name })
+		return results, nil
+	}
+	for i, id := range ids {
+		switch {
+		case !found[i]:
+			results = append(results, domain.BulkResult{Name: id, Error: fmt.Sprintf("resource %s not found", id)})
+		case picked[i].ID != "":
+			results = append(results, picked[i])
+		}
+	}
+	return results, nil
+}
+
+func (a *ContainerApp) bulkAction(ctx context.Context, action, id string) error {
+	switch action {
+	case domain.BulkStart:
+		return a.Start(ctx, id)
+	case domain.BulkStop:
+		return a.Stop(ctx, id)
+	case domain.BulkRestart:
+		return a.Restart(ctx, id)
+	case domain.BulkDelete:
+		return a.Delete(ctx, id)
+	}
+	return fmt.Errorf("unknown action %q", action)
+}
@@ -0,0 +1,230 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+	"github.com/moby/moby/api/types/mount"
+	dockerclient "github.com/moby/moby/client"
+)
+
+const (
+	cloneOfLabel       = "devcon.clone_of"
+	cloneSourceIDLabel = "devcon.clone_source_id"
+	clonedAtLabel      = "devcon.cloned_at"
+	cloneDataLabel     = "devcon.clone_data"
+)
+
+const (
+	cloneDataNone    = "none"
+	cloneDataVolumes = "volumes"
+	cloneDataCommit  = "commit"
+)
+
+var resourceNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+// CloneResource creates a copy of a resource under a new name. Host ports are
+// left for docker to assign, named volumes are replaced by fresh ones and
+// compose labels are dropped so the clone is not adopted by the source's
+// stack.
+func (a *ContainerApp) CloneResource(ctx context.Context, identifier string, req domain.CloneRequest) (*domain.CloneResult, error) {
+	name := strings.TrimSpace(req.Name)
+	if !resourceNameRegexp.MatchString(name) {
+		return nil, fmt.Errorf("invalid resource name %q", name)
+	}
+	if existing, err := a.containerService.FindContainer(ctx, name); err != nil {
+		return nil, err
+	} else if existing.ID != "" {
+		return nil, fmt.Errorf("resource %s already exists", name)
+	}
+
+	summary, err := a.containerService.FindContainer(ctx, identifier)
+	if err != nil {
+		return nil, err
+	}
+	if summary.ID == "" {
+		return nil, fmt.Errorf("resource %s not found", identifier)
+	}
+	source, err := a.containerService.InsepectContainer(ctx, summary.ID)
+	if err != nil {
+		return nil, err
+	}
+	src := source.Container
+	sourceName := strings.TrimPrefix(src.Name, "/")
+
+	result := &domain.CloneResult{
+		Source:  sourceName,
+		Data:    cloneDataNone,
+		Ports:   make(map[string]string),
+		Volumes: make(map[string]string),
+	}
+	overrides := &domain.ContainerOverrides{
+		Name: name,
+		Labels: map[string]string{
+			cloneOfLabel:       sourceName,
+			cloneSourceIDLabel: src.ID,
+			clonedAtLabel:      strconv.FormatInt(time.Now().Unix(), 10),
+		},
+		RemoveLabels: []string{composeProjectLabel, composeServiceLabel, composeNumberLabel, composeConfigHashLabel},
+		HostPorts:    make(map[string]string),
+		Volumes:      make(map[string]string),
+		ResetAliases: true,
+	}
+	if _, ok := src.Config.Labels[resourceNameLabel]; ok {
+		overrides.Labels[resourceNameLabel] = name
+	}
+	for port := range src.HostConfig.PortBindings {
+		overrides.HostPorts[port.String()] = ""
+	}
+
+	named := namedVolumes(source)
+	volumeTargets := make([]string, 0)
+	for _, m := range src.Mounts {
+		switch m.Type {
+		case mount.TypeVolume:
+			volumeTargets = append(volumeTargets, m.Destination)
+		case mount.TypeBind:
+			result.Warnings = append(result.Warnings, fmt.Sprintf("bind mount %s is shared with %s", m.Source, sourceName))
+		}
+	}
+
+	if req.CopyData {
+		if len(volumeTargets) > 0 {
+			result.Data = cloneDataVolumes
+		} else {
+			result.Data = cloneDataCommit
+			reference := "devcon/clone-" + composeProjectName(name) + ":" + strconv.FormatInt(time.Now().Unix(), 10)
+			if _, err := a.containerService.CommitContainer(ctx, src.ID, reference); err != nil {
+				return nil, fmt.Errorf("failed to commit %s: %w", sourceName, err)
+			}
+			overrides.Image = reference
+			result.Image = reference
+		}
+	}
+	overrides.Labels[cloneDataLabel] = result.Data
+
+	// cleanup removes what a failed clone left behind, the committed image
+	// last since the container uses it.
+	createdVolumes := make([]string, 0, len(named))
+	cleanup := func(containerID string) {
+		if containerID != "" {
+			_ = a.containerService.DeleteContainerWithVolumes(ctx, containerID)
+		}
+		for _, volume := range createdVolumes {
+			_ = a.containerService.RemoveVolume(ctx, volume)
+		}
+		if result.Image != "" {
+			_ = a.containerService.RemoveImage(ctx, result.Image)
+		}
+	}
+	for _, volume := range named {
+		cloned := cloneVolumeName(volume, sourceName, name)
+		if err := a.containerService.CreateVolume(ctx, &domain.VolumeSpec{Name: cloned, Labels: map[string]string{cloneOfLabel: volume}}); err != nil {
+			cleanup("")
+			return nil, fmt.Errorf("failed to create volume %s: %w", cloned, err)
+		}
+		createdVolumes = append(createdVolumes, cloned)
+		overrides.Volumes[volume] = cloned
+	}
+
+	created, err := a.containerService.CreateContainerFrom(ctx, source, overrides)
+	if err != nil {
+		cleanup("")
+		return nil, err
+	}
+
+	if result.Data == cloneDataVolumes {
+		if err := a.copyVolumes(ctx, source, created.ID, volumeTargets); err != nil {
+			cleanup(created.ID)
+			return nil, err
+		}
+	}
+
+	if !req.NoStart {
+		if err := a.containerService.StartContainer(ctx, created.ID); err != nil {
+			cleanup(created.ID)
+			return nil, err
+		}
+	}
+	inspect, err := a.containerService.InsepectContainer(ctx, created.ID)
+	if err != nil {
+		return nil, err
+	}
+	state := domain.DesiredRunning
+	if req.NoStart {
+		state = domain.DesiredStopped
+	}
+	if err := a.trackResource(ctx, inspect, state, true); err != nil {
+		return nil, err
+	}
+	result.Resource = buildDevconStatus(inspect, false)
+	for port, bindings := range inspect.Container.NetworkSettings.Ports {
+		if len(bindings) > 0 {
+			result.Ports[port.String()] = bindings[0].HostPort
+		}
+	}
+	for _, m := range inspect.Container.Mounts {
+		if m.Type == mount.TypeVolume {
+			result.Volumes[m.Destination] = m.Name
+		}
+	}
+	return result, nil
+}
+
+// copyVolumes copies each volume path from the source into the clone. A
+// running source is paused meanwhile so the copy is consistent.
+func (a *ContainerApp) copyVolumes(ctx context.Context, source dockerclient.ContainerInspectResult, targetID string, targets []string) error {
+	state := source.Container.State
+	if state != nil && state.Running && !state.Paused {
+		if err := a.containerService.PauseContainer(ctx, source.Container.ID); err != nil {
+			return err
+		}
+		defer a.containerService.UnpauseContainer(ctx, source.Container.ID)
+	}
+	for _, target := range targets {
+		if err := a.containerService.CopyContainerPath(ctx, source.Container.ID, targetID, target); err != nil {
+			return fmt.Errorf("failed to copy %s: %w", target, err)
+		}
+	}
+	return nil
+}
+
+// namedVolumes lists the named volumes a container mounts; anonymous ones
+// are recreated by docker for the clone.
+func namedVolumes(inspect dockerclient.ContainerInspectResult) []string {
+	hostConfig := inspect.Container.HostConfig
+	seen := make(map[string]bool)
+	names := make([]string, 0)
+	add := func(name string) {
+		if name != "" && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	for _, bind := range hostConfig.Binds {
+		source, _, _ := strings.Cut(bind, ":")
+		if !strings.HasPrefix(source, "/") && !strings.HasPrefix(source, ".") {
+			add(source)
+		}
+	}
+	for _, m := range hostConfig.Mounts {
+		if m.Type == mount.TypeVolume {
+			add(m.Source)
+		}
+	}
+	return names
+}
+
+// cloneVolumeName keeps the suffix of volumes named after their resource, so
+// "pg-data" cloned to "pg-copy" becomes "pg-copy-data".
+func cloneVolumeName(volume, sourceName, cloneName string) string {
+	if suffix, ok := strings.CutPrefix(volume, sourceName+"-"); ok {
+		return cloneName + "-" + suffix
+	}
+	return cloneName + "-" + volume
+}
@@ -0,0 +1,68 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/api/types/mount"
+	dockerclient "github.com/moby/moby/client"
+)
+
+func TestCloneVolumeName(t *testing.T) {
+	tests := []struct {
+		volume, source, clone string
+		want                  string
+	}{
+		{"pg-data", "pg", "pg-copy", "pg-copy-data"},
+		{"pg-data-wal", "pg", "pg-copy", "pg-copy-data-wal"},
+		{"shared", "pg", "pg-copy", "pg-copy-shared"},
+		{"pgdata", "pg", "pg-copy", "pg-copy-pgdata"},
+		{"pg", "pg", "pg-copy", "pg-copy-pg"},
+	}
+	for _, tt := range tests {
+		if got := cloneVolumeName(tt.volume, tt.source, tt.clone); got != tt.want {
+			t.Errorf("cloneVolumeName(%q, %q, %q) = %q, want %q", tt.volume, tt.source, tt.clone, got, tt.want)
+		}
+	}
+}
+
+func TestNamedVolumes(t *testing.T) {
+	tests := []struct {
+		name       string
+		hostConfig *container.HostConfig
+		want       []string
+	}{
+		{"none", &container.HostConfig{}, []string{}},
+		{
+			name: "binds skip host paths",
+			hostConfig: &container.HostConfig{Binds: []string{
+				"pg-data:/var/lib/postgresql/data",
+				"/srv/conf:/etc/conf:ro",
+				"./init:/docker-entrypoint-initdb.d",
+			}},
+			want: []string{"pg-data"},
+		},
+		{
+			name: "mounts skip binds and tmpfs",
+			hostConfig: &container.HostConfig{
+				Binds: []string{"pg-data:/data"},
+				Mounts: []mount.Mount{
+					{Type: mount.TypeVolume, Source: "pg-data", Target: "/data"},
+					{Type: mount.TypeVolume, Source: "pg-wal", Target: "/wal"},
+					{Type: mount.TypeBind, Source: "/tmp", Target: "/tmp"},
+					{Type: mount.TypeTmpfs, Target: "/run"},
+				},
+			},
+			want: []string{"pg-data", "pg-wal"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inspect := dockerclient.ContainerInspectResult{Container: container.InspectResponse{HostConfig: tt.hostConfig}}
+			if got := namedVolumes(inspect); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("namedVolumes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,528 @@
+package app
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/compose"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+	"github.com/moby/moby/api/types/container"
+)
+
+const (
+	composeProjectLabel = "com.docker.compose.project"
+	composeServiceLabel = "com.docker.compose.service"
+	composeNumberLabel  = "com.docker.compose.container-number"
+	composeOneoffLabel  = "com.docker.compose.oneoff"
+	composeNetworkLabel = "com.docker.compose.network"
+	composeVolumeLabel  = "com.docker.compose.volume"
+
+	composeConfigHashLabel = "com.docker.compose.config-hash"
+)
+
+func (a *ContainerApp) PreviewCompose(ctx context.Context, cfg *domain.ContainerCfg) (*domain.ComposePreview, error) {
+	project, _, err := parseComposeStack(cfg.Name, composeSource(cfg))
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := compose.ServiceOrder(project)
+	if err != nil {
+		return nil, err
+	}
+	services := make(map[string]domain.ComposeService, len(project.Services))
+	for _, service := range project.Services {
+		services[service.Name] = service
+	}
+
+	preview := &domain.ComposePreview{
+		Project:  project.Name,
+		Services: make([]domain.ComposeServicePreview, 0, len(order)),
+		Networks: make([]string, 0, len(project.Networks)),
+		Volumes:  make([]string, 0, len(project.Volumes)),
+		Warnings: project.Warnings,
+	}
+	for _, name := range order {
+		service := services[name]
+		entry := domain.ComposeServicePreview{
+			Name:          service.Name,
+			ContainerName: composeContainerName(project.Name, service, 1),
+			Image:         service.Image,
+			Ports:         make([]string, 0, len(service.Ports)),
+			DependsOn:     service.DependsOn,
+		}
+		for _, port := range service.Ports {
+			entry.Ports = append(entry.Ports, formatPortSpec(port))
+		}
+		preview.Services = append(preview.Services, entry)
+	}
+	for _, network := range project.Networks {
+		preview.Networks = append(preview.Networks, network.Name)
+	}
+	for _, volume := range project.Volumes {
+		preview.Volumes = append(preview.Volumes, volume.Name)
+	}
+	sort.Strings(preview.Networks)
+	sort.Strings(preview.Volumes)
+	return preview, nil
+}
+
+func (a *ContainerApp) ResolveCompose(ctx context.Context, cfg *domain.ContainerCfg) (*domain.ResolvedStack, error) {
+	project, resolved, err := parseComposeStack(cfg.Name, composeSource(cfg))
+	if err != nil {
+		return nil, err
+	}
+	return &domain.ResolvedStack{Project: project, Config: string(resolved), Warnings: project.Warnings}, nil
+}
+
+// CheckStack parses source as ApplyStack would, without touching Docker, so an
+// invalid definition can be rejected before the apply is queued.
+func (a *ContainerApp) CheckStack(name string, source domain.StackSource) error {
+	_, _, err := parseComposeStack(strings.TrimSpace(name), source)
+	return err
+}
+
+func (a *ContainerApp) ApplyStack(ctx context.Context, name string, source domain.StackSource) (*domain.StackApplyResult, error) {
+	if strings.TrimSpace(name) == "" {
+		return nil, fmt.Errorf("stack name cannot be empty")
+	}
+	if err := a.containerService.PingDaemon(ctx); err != nil {
+		return nil, err
+	}
+	return a.applyStack(ctx, strings.TrimSpace(name), source, "")
+}
+
+func (a *ContainerApp) startComposeStack(ctx context.Context, cfg *domain.ContainerCfg) (*domain.DevconStatus, error) {
+	project := composeProjectName(cfg.Name)
+	existing, err := a.containerService.FindContainersByComposeProject(ctx, project)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := a.applyStack(ctx, cfg.Name, composeSource(cfg), ""); err != nil {
+		return nil, err
+	}
+
+	containers, err := a.containerService.FindContainersByComposeProject(ctx, project)
+	if err != nil {
+		return nil, err
+	}
+	if len(containers) == 0 {
+		return &domain.DevconStatus{ID: project, Name: cfg.Name, Image: "docker-compose stack", State: "running", AlreadyExisted: len(existing) > 0}, nil
+	}
+	inspect, err := a.containerService.InsepectContainer(ctx, containers[0].ID)
+	if err != nil {
+		return nil, err
+	}
+	return buildDevconStatus(inspect, len(existing) > 0), nil
+}
+
+// applyStack converges the stack to source and records it as a new version,
+// unless it resolves the same as the latest one. The env_file variables read
+// while parsing are stored with the version so a rollback reuses them.
+func (a *ContainerApp) applyStack(ctx context.Context, name string, source domain.StackSource, note string) (*domain.StackApplyResult, error) {
+	project, _, err := parseComposeStack(name, source)
+	if err != nil {
+		return nil, err
+	}
+	source.ServiceEnv = project.ServiceEnv
+	result, err := a.convergeComposeProject(ctx, project)
+	if err != nil {
+		return nil, err
+	}
+
+	stack, err := a.stackService.GetStack(ctx, project.Name)
+	if err != nil {
+		return nil, err
+	}
+	if stack != nil {
+		if current := stack.Current(); current != nil && current.StackSource.Equal(source) {
+			result.Version = current.Version
+			return result, nil
+		}
+	}
+	version, err := a.stackService.RecordVersion(ctx, project.Name, name, source, note)
+	if err != nil {
+		return nil, err
+	}
+	result.Version = version.Version
+	return result, nil
+}
+
+// convergeComposeProject brings the running stack in line with project. Services
+// whose config hash label still matches are left running untouched. Replaced
+// containers are set aside until every service is up, so a failure leaves
+// the stack as it was.
+func (a *ContainerApp) convergeComposeProject(ctx context.Context, project *domain.ComposeProject) (*domain.StackApplyResult, error) {
+	order, err := compose.ServiceOrder(project)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.ensureComposeNetworks(ctx, project); err != nil {
+		return nil, err
+	}
+	if err := a.ensureComposeVolumes(ctx, project); err != nil {
+		return nil, err
+	}
+	if err := a.buildComposeImages(ctx, project); err != nil {
+		return nil, err
+	}
+	if err := a.pullComposeImages(ctx, project); err != nil {
+		return nil, err
+	}
+
+	existing, err := a.containerService.FindContainersByComposeProject(ctx, project.Name)
+	if err != nil {
+		return nil, err
+	}
+	byService := make(map[string][]container.Summary)
+	for _, c := range existing {
+		service := c.Labels[composeServiceLabel]
+		byService[service] = append(byService[service], c)
+	}
+
+	services := make(map[string]domain.ComposeService, len(project.Services))
+	for _, service := range project.Services {
+		services[service.Name] = service
+	}
+
+	result := &domain.StackApplyResult{
+		Project:   project.Name,
+		Created:   make([]string, 0),
+		Recreated: make([]string, 0),
+		Removed:   make([]string, 0),
+		Unchanged: make([]string, 0),
+	}
+	created := make([]string, 0)
+	replaced := make([]asideContainer, 0)
+	fail := func(err error) (*domain.StackApplyResult, error) {
+		a.rollbackConvergence(ctx, created, replaced)
+		return nil, err
+	}
+	for _, name := range order {
+		service := services[name]
+		hash := composeConfigHash(project, service)
+		current := byService[name]
+		sort.Slice(current, func(i, j int) bool { return containerNumber(current[i]) < containerNumber(current[j]) })
+
+		switch {
+		case len(current) == 0:
+			id, err := a.createComposeReplica(ctx, project, service, 1)
+			if id != "" {
+				created = append(created, id)
+			}
+			if err != nil {
+				return fail(err)
+			}
+			result.Created = append(result.Created, name)
+		case !replicasMatch(current, hash):
+			for _, c := range current {
+				aside, err := a.setAside(ctx, c.ID, firstContainerName(c.Names), c.State == "running")
+				if err != nil {
+					return fail(fmt.Errorf("failed to replace %s: %w", firstContainerName(c.Names), err))
+				}
+				replaced = append(replaced, aside)
+				number := containerNumber(c)
+				if number == 0 {
+					number = 1
+				}
+				id, err := a.createComposeReplica(ctx, project, service, number)
+				if id != "" {
+					created = append(created, id)
+				}
+				if err != nil {
+					return fail(err)
+				}
+			}
+			result.Recreated = append(result.Recreated, name)
+		default:
+			for _, c := range current {
+				if c.State == "running" {
+					continue
+				}
+				if err := a.containerService.StartContainer(ctx, c.ID); err != nil {
+					return fail(err)
+				}
+			}
+			result.Unchanged = append(result.Unchanged, name)
+		}
+	}
+	for _, aside := range replaced {
+		if err := a.containerService.DeleteContainer(ctx, aside.id); err != nil {
+			return nil, fmt.Errorf("failed to remove %s: %w", aside.name, err)
+		}
+	}
+
+	for name, containers := range byService {
+		if _, ok := services[name]; ok {
+			continue
+		}
+		for _, c := range containers {
+			if err := a.containerService.DeleteContainer(ctx, c.ID); err != nil {
+				return nil, fmt.Errorf("failed to remove %s: %w", firstContainerName(c.Names), err)
+			}
+		}
+		result.Removed = append(result.Removed, name)
+	}
+	sort.Strings(result.Removed)
+	return result, nil
+}
+
+func (a *ContainerApp) buildComposeImages(ctx context.Context, project *domain.ComposeProject) error {
+	for i := range project.Services {
+		service := &project.Services[i]
+		if service.Build == nil {
+			continue
+		}
+		id, err := a.containerService.BuildImage(ctx, *service.Build, service.Image, map[string]string{
+			composeProjectLabel: project.Name,
+			composeServiceLabel: service.Name,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to build service %s: %w", service.Name, err)
+		}
+		service.Build.ImageID = id
+	}
+	return nil
+}
+
+// pullComposeImages fetches the images of services that are not built up
+// front, so a slow pull is reported before any container is touched.
+func (a *ContainerApp) pullComposeImages(ctx context.Context, project *domain.ComposeProject) error {
+	for _, service := range project.Services {
+		if service.Build != nil || service.Image == "" {
+			continue
+		}
+		a.operationPhase(ctx, domain.PhasePulling, "pulling %s for service %s", service.Image, service.Name)
+		if err := a.containerService.EnsureImage(ctx, service.Image); err != nil {
+			return fmt.Errorf("failed to pull image for service %s: %w", service.Name, err)
+		}
+	}
+	return nil
+}
+
+// createComposeReplica creates and starts one container of a service. The
+// container ID is returned even when starting it fails, so it can be rolled
+// back.
+func (a *ContainerApp) createComposeReplica(ctx context.Context, project *domain.ComposeProject, service domain.ComposeService, number int) (string, error) {
+	spec := composeContainerSpec(project, service, number)
+	a.operationPhase(ctx, domain.PhaseCreating, "creating service %s", service.Name)
+	res, err := a.containerService.CreateContainerFromSpec(ctx, spec)
+	if err != nil {
+		return "", fmt.Errorf("failed to create service %s: %w", service.Name, err)
+	}
+	a.operationPhase(ctx, domain.PhaseStarting, "starting service %s", service.Name)
+	if err := a.containerService.StartContainer(ctx, res.ID); err != nil {
+		return res.ID, fmt.Errorf("failed to start service %s: %w", service.Name, err)
+	}
+	return res.ID, nil
+}
+
+// rollbackConvergence removes the containers a failed convergence created and
+// puts back the ones it replaced.
+func (a *ContainerApp) rollbackConvergence(ctx context.Context, created []string, replaced []asideContainer) {
+	for _, id := range created {
+		_ = a.containerService.DeleteContainer(ctx, id)
+	}
+	for i := len(replaced) - 1; i >= 0; i-- {
+		a.putBack(ctx, replaced[i])
+	}
+}
+
+func replicasMatch(containers []container.Summary, hash string) bool {
+	for _, c := range containers {
+		if c.Labels[composeConfigHashLabel] != hash {
+			return false
+		}
+	}
+	return true
+}
+
+func (a *ContainerApp) ensureComposeNetworks(ctx context.Context, project *domain.ComposeProject) error {
+	for key, network := range project.Networks {
+		exists, err := a.containerService.NetworkExists(ctx, network.Name)
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+		if network.External {
+			return fmt.Errorf("external network %s does not exist", network.Name)
+		}
+		labels := map[string]string{
+			composeProjectLabel: project.Name,
+			composeNetworkLabel: key,
+		}
+		for k, v := range network.Labels {
+			labels[k] = v
+		}
+		if _, err := a.containerService.CreateNetwork(ctx, &domain.NetworkSpec{
+			Name:     network.Name,
+			Driver:   network.Driver,
+			Internal: network.Internal,
+			Labels:   labels,
+		}); err != nil {
+			return fmt.Errorf("failed to create network %s: %w", network.Name, err)
+		}
+	}
+	return nil
+}
+
+func (a *ContainerApp) ensureComposeVolumes(ctx context.Context, project *domain.ComposeProject) error {
+	for key, volume := range project.Volumes {
+		exists, err := a.containerService.VolumeExists(ctx, volume.Name)
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+		if volume.External {
+			return fmt.Errorf("external volume %s does not exist", volume.Name)
+		}
+		labels := map[string]string{
+			composeProjectLabel: project.Name,
+			composeVolumeLabel:  key,
+		}
+		for k, v := range volume.Labels {
+			labels[k] = v
+		}
+		if err := a.containerService.CreateVolume(ctx, &domain.VolumeSpec{
+			Name:   volume.Name,
+			Driver: volume.Driver,
+			Labels: labels,
+		}); err != nil {
+			return fmt.Errorf("failed to create volume %s: %w", volume.Name, err)
+		}
+	}
+	return nil
+}
+
+func composeSource(cfg *domain.ContainerCfg) domain.StackSource {
+	return domain.StackSource{
+		Compose:   cfg.Compose,
+		Overrides: cfg.ComposeOverrides,
+		Profiles:  cfg.Profiles,
+		EnvFile:   cfg.EnvFile,
+	}
+}
+
+func parseComposeStack(name string, source domain.StackSource) (*domain.ComposeProject, []byte, error) {
+	if strings.TrimSpace(source.Compose) == "" {
+		return nil, nil, fmt.Errorf("compose payload cannot be empty")
+	}
+	env, err := compose.ParseEnvFile([]byte(source.EnvFile))
+	if err != nil {
+		return nil, nil, err
+	}
+	documents := [][]byte{[]byte(source.Compose)}
+	for _, override := range source.Overrides {
+		documents = append(documents, []byte(override))
+	}
+	return compose.Load(documents, compose.Options{
+		Project:    composeProjectName(name),
+		Profiles:   source.Profiles,
+		Env:        env,
+		WorkingDir: source.Dir,
+		ServiceEnv: source.ServiceEnv,
+	})
+}
+
+func composeConfigHash(project *domain.ComposeProject, service domain.ComposeService) string {
+	networks := make([]string, 0, len(service.Networks))
+	for _, attachment := range service.Networks {
+		networks = append(networks, project.Networks[attachment.Name].Name)
+	}
+	volumes := make([]string, 0, len(service.Volumes))
+	for _, m := range service.Volumes {
+		if m.Type == "volume" && m.Source != "" {
+			volumes = append(volumes, project.Volumes[m.Source].Name)
+		}
+	}
+	data, _ := json.Marshal(struct {
+		Service  domain.ComposeService `json:"service"`
+		Networks []string              `json:"networks"`
+		Volumes  []string              `json:"volumes"`
+	}{service, networks, volumes})
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func composeContainerSpec(project *domain.ComposeProject, service domain.ComposeService, number int) *domain.ContainerSpec {
+	labels := map[string]string{
+		composeProjectLabel:    project.Name,
+		composeServiceLabel:    service.Name,
+		composeNumberLabel:     strconv.Itoa(number),
+		composeOneoffLabel:     "False",
+		composeConfigHashLabel: composeConfigHash(project, service),
+	}
+	for k, v := range service.Labels {
+		labels[k] = v
+	}
+
+	spec := &domain.ContainerSpec{
+		Name:          composeContainerName(project.Name, service, number),
+		Image:         service.Image,
+		Command:       service.Command,
+		Entrypoint:    service.Entrypoint,
+		Env:           service.Environment,
+		Labels:        labels,
+		Ports:         service.Ports,
+		Expose:        service.Expose,
+		RestartPolicy: service.Restart,
+		WorkingDir:    service.WorkingDir,
+		User:          service.User,
+		Hostname:      service.Hostname,
+		StopSignal:    service.StopSignal,
+		Privileged:    service.Privileged,
+		Tty:           service.Tty,
+		OpenStdin:     service.StdinOpen,
+		CapAdd:        service.CapAdd,
+		ExtraHosts:    service.ExtraHosts,
+		Tmpfs:         service.Tmpfs,
+		Healthcheck:   service.Healthcheck,
+	}
+
+	for _, m := range service.Volumes {
+		if m.Type == "volume" && m.Source != "" {
+			m.Source = project.Volumes[m.Source].Name
+		}
+		spec.Mounts = append(spec.Mounts, m)
+	}
+	for _, attachment := range service.Networks {
+		spec.Networks = append(spec.Networks, domain.NetworkAttachment{
+			Name:    project.Networks[attachment.Name].Name,
+			Aliases: append([]string{service.Name}, attachment.Aliases...),
+		})
+	}
+	return spec
+}
+
+func composeContainerName(project string, service domain.ComposeService, number int) string {
+	if service.ContainerName != "" {
+		return service.ContainerName
+	}
+	return composeReplicaName(project, service.Name, number)
+}
+
+func formatPortSpec(port domain.PortSpec) string {
+	var b strings.Builder
+	if port.HostIP != "" {
+		b.WriteString(port.HostIP + ":")
+	}
+	if port.HostPort != "" {
+		b.WriteString(port.HostPort + ":")
+	}
+	b.WriteString(port.ContainerPort)
+	if port.Protocol != "" && port.Protocol != "tcp" {
+		b.WriteString("/" + port.Protocol)
+	}
+	return b.String()
+}
@@ -0,0 +1,77 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+)
+
+func TestComposeContainerName(t *testing.T) {
+	tests := []struct {
+		service domain.ComposeService
+		number  int
+		want    string
+	}{
+		{domain.ComposeService{Name: "web"}, 1, "shop-web-1"},
+		{domain.ComposeService{Name: "web"}, 2, "shop-web-2"},
+		{domain.ComposeService{Name: "db", ContainerName: "shop-postgres"}, 1, "shop-postgres"},
+	}
+	for _, tt := range tests {
+		if got := composeContainerName("shop", tt.service, tt.number); got != tt.want {
+			t.Errorf("composeContainerName(%+v, %d) = %q, want %q", tt.service, tt.number, got, tt.want)
+		}
+	}
+}
+
+func TestFormatPortSpec(t *testing.T) {
+	tests := []struct {
+		port domain.PortSpec
+		want string
+	}{
+		{domain.PortSpec{ContainerPort: "80", Protocol: "tcp"}, "80"},
+		{domain.PortSpec{HostPort: "8080", ContainerPort: "80", Protocol: "tcp"}, "8080:80"},
+		{domain.PortSpec{HostIP: "127.0.0.1", HostPort: "8080", ContainerPort: "80"}, "127.0.0.1:8080:80"},
+		{domain.PortSpec{HostPort: "53", ContainerPort: "53", Protocol: "udp"}, "53:53/udp"},
+	}
+	for _, tt := range tests {
+		if got := formatPortSpec(tt.port); got != tt.want {
+			t.Errorf("formatPortSpec(%+v) = %q, want %q", tt.port, got, tt.want)
+		}
+	}
+}
+
+func TestComposeConfigHash(t *testing.T) {
+	project := &domain.ComposeProject{
+		Name:     "shop",
+		Networks: map[string]domain.ComposeNetwork{"default": {Name: "shop_default"}},
+		Volumes:  map[string]domain.ComposeVolume{"data": {Name: "shop_data"}},
+	}
+	service := domain.ComposeService{
+		Name:     "db",
+		Image:    "postgres:16",
+		Networks: []domain.NetworkAttachment{{Name: "default"}},
+		Volumes:  []domain.MountSpec{{Type: "volume", Source: "data", Target: "/var/lib/postgresql/data"}},
+	}
+	base := composeConfigHash(project, service)
+	if again := composeConfigHash(project, service); again != base {
+		t.Fatalf("composeConfigHash is not stable: %s != %s", again, base)
+	}
+
+	changedImage := service
+	changedImage.Image = "postgres:17"
+	renamed := *project
+	renamed.Volumes = map[string]domain.ComposeVolume{"data": {Name: "other_data"}}
+	tests := []struct {
+		name    string
+		project *domain.ComposeProject
+		service domain.ComposeService
+	}{
+		{"image", project, changedImage},
+		{"volume name", &renamed, service},
+	}
+	for _, tt := range tests {
+		if got := composeConfigHash(tt.project, tt.service); got == base {
+			t.Errorf("changing the %s keeps hash %s", tt.name, got)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/connection"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+)
+
+// GetConnectionInfo builds connection URLs for a resource, both from the host
+// through published ports and from containers sharing one of its networks.
+func (a *ContainerApp) GetConnectionInfo(ctx context.Context, identifier string, reveal bool) (*domain.ConnectionInfo, error) {
+	if identifier == "" {
+		return nil, fmt.Errorf("container id cannot be empty")
+	}
+	summary, err := a.containerService.FindContainer(ctx, identifier)
+	if err != nil {
+		return nil, err
+	}
+	if summary.ID == "" {
+		return nil, fmt.Errorf("resource %s not found", identifier)
+	}
+	resourceType := a.detectType(summary)
+	if !connection.Supported(resourceType) {
+		return nil, fmt.Errorf("resource %s has type %s, which has no connection info", identifier, resourceType)
+	}
+
+	inspect, err := a.containerService.InsepectContainer(ctx, summary.ID)
+	if err != nil {
+		return nil, err
+	}
+	c := inspect.Container
+	name := strings.TrimPrefix(c.Name, "/")
+
+	in := connection.Input{
+		ID:       c.ID,
+		Name:     name,
+		Type:     resourceType,
+		Env:      envMap(c.Config.Env),
+		Ports:    make(map[string]string),
+		Networks: make(map[string]string),
+	}
+	for port, bindings := range c.NetworkSettings.Ports {
+		for _, binding := range bindings {
+			if binding.HostPort != "" {
+				in.Ports[port.String()] = binding.HostPort
+				break
+			}
+		}
+	}
+	for network, endpoint := range c.NetworkSettings.Networks {
+		if endpoint == nil {
+			continue
+		}
+		// The default bridge network has no DNS, so peers need the IP there.
+		switch network {
+		case "bridge":
+			if endpoint.IPAddress.IsValid() {
+				in.Networks[network] = endpoint.IPAddress.String()
+			}
+		case "host", "none":
+		default:
+			in.Networks[network] = name
+		}
+	}
+	return connection.Build(in, reveal)
+}
+
+func envMap(env []string) map[string]string {
+	values := make(map[string]string, len(env))
+	for _, kv := range env {
+		if key, value, ok := strings.Cut(kv, "="); ok {
+			values[key] = value
+		}
+	}
+	return values
+}
@@ -3,53 +3,117 @@ package app
 import (
 	"context"
 	"fmt"
-	"os"
-	"os/exec"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/deps"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/detect"
 	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
 	"github.com/abhishekkkk-15/devcon/agent/internal/core/service"
+	"github.com/moby/moby/api/types/container"
 	dockerclient "github.com/moby/moby/client"
 )
 
+const (
+	resourceTypeLabel = "devcon.resource_type"
+	resourceNameLabel = "devcon.resource_name"
+	templateLabel     = "devcon.template"
+)
+
 type ContainerApp struct {
 	containerService service.ContainerService
+	stackService     *service.StackService
+	templateService  *service.TemplateService
+	detector         *detect.Registry
+	backupService    *service.BackupService
+	backupScheduler  *cronSync
+	reconcileService *service.ReconcileService
+	reconciler       reconciler
+	lifecycleService *service.LifecycleService
+	lifecycle        lifecycleMonitor
+	scheduleService  *service.ScheduleService
+	scheduler        *cronSync
+	gcService        *service.GCService
+	gcScheduler      *cronSync
+	trashService     *service.TrashService
+	events           eventLog
+	operations       operationTable
 }
 
-func NewContainerApp(c service.ContainerService) *ContainerApp {
-	return &ContainerApp{containerService: c}
+// ContainerServices is what a ContainerApp is built from. New services are
+// added here rather than to NewContainerApp's parameters.
+type ContainerServices struct {
+	Container service.ContainerService
+	Stack     *service.StackService
+	Template  *service.TemplateService
+	Detector  *detect.Registry
+	Backup    *service.BackupService
+	Reconcile *service.ReconcileService
+	Lifecycle *service.LifecycleService
+	Schedule  *service.ScheduleService
+	GC        *service.GCService
+	Trash     *service.TrashService
+}
+
+func NewContainerApp(s ContainerServices) *ContainerApp {
+	return &ContainerApp{
+		containerService: s.Container,
+		stackService:     s.Stack,
+		templateService:  s.Template,
+		detector:         s.Detector,
+		backupService:    s.Backup,
+		reconcileService: s.Reconcile,
+		lifecycleService: s.Lifecycle,
+		scheduleService:  s.Schedule,
+		gcService:        s.GC,
+		trashService:     s.Trash,
+	}
 }
 
 func (a *ContainerApp) List(ctx context.Context) (dockerclient.ContainerListResult, error) {
 	return a.containerService.ListContainers(ctx)
 }
 
-func (a *ContainerApp) ListResources(ctx context.Context) ([]domain.Resource, error) {
+// ListResources leaves out resources in the trash unless includeTrashed is
+// set.
+func (a *ContainerApp) ListResources(ctx context.Context, groupStacks, includeTrashed bool) ([]domain.Resource, error) {
 	containers, err := a.containerService.ListContainers(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	lifecycles, err := a.lifecycleService.ListLifecycles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	timers := make(map[string]*domain.ResourceTimers, len(lifecycles))
+	for _, lifecycle := range lifecycles {
+		timers[lifecycle.Resource] = lifecycleTimers(lifecycle)
+	}
+	trashed, err := a.trashedNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	resources := make([]domain.Resource, 0, len(containers.Items))
 	for _, container := range containers.Items {
-		resourceType := inferResourceType(container.Image)
-		if container.Labels != nil {
-			if val, ok := container.Labels["devcon.resource_type"]; ok && val != "" {
-				resourceType = val
-			} else if _, ok := container.Labels["com.docker.compose.project"]; ok {
-				resourceType = "custom"
-			}
-		}
-
 		resource := domain.Resource{
 			ID:        container.ID,
 			Name:      firstContainerName(container.Names),
 			Image:     container.Image,
-			Type:      resourceType,
+			Type:      a.detectType(container),
 			Status:    strings.ToUpper(string(container.State)),
 			CreatedAt: container.Created,
+			Stack:     container.Labels[composeProjectLabel],
+			Service:   container.Labels[composeServiceLabel],
+			Group:     container.Labels[groupLabel],
+		}
+		resource.Timers = timers[resource.Name]
+		resource.Trashed = trashed[resource.Name]
+		if resource.Trashed && !includeTrashed {
+			continue
 		}
 
 		for _, port := range container.Ports {
@@ -64,6 +128,9 @@ func (a *ContainerApp) ListResources(ctx context.Context) ([]domain.Resource, er
 		resources = append(resources, resource)
 	}
 
+	if groupStacks {
+		return groupStackResources(resources), nil
+	}
 	return resources, nil
 }
 
@@ -71,56 +138,29 @@ func (a *ContainerApp) Start(ctx context.Context, id string) error {
 	if id == "" {
 		return fmt.Errorf("container id cannot be empty")
 	}
-	project, err := a.composeProjectForResource(ctx, id)
-	if err != nil {
-		return err
-	}
-	if project != "" {
-		return a.containerService.StartComposeProject(ctx, project)
-	}
-	return a.containerService.StartContainer(ctx, id)
+	return a.startWithDependencies(ctx, id, false)
 }
 
 func (a *ContainerApp) Restart(ctx context.Context, id string) error {
 	if id == "" {
 		return fmt.Errorf("container id cannot be empty")
 	}
-	project, err := a.composeProjectForResource(ctx, id)
-	if err != nil {
-		return err
-	}
-	if project != "" {
-		return a.containerService.RestartComposeProject(ctx, project)
-	}
-	return a.containerService.RestartContainer(ctx, id)
+	return a.startWithDependencies(ctx, id, true)
 }
 
 func (a *ContainerApp) Stop(ctx context.Context, id string) error {
 	if id == "" {
 		return fmt.Errorf("container id cannot be empty")
 	}
-	project, err := a.composeProjectForResource(ctx, id)
-	if err != nil {
-		return err
-	}
-	if project != "" {
-		return a.containerService.StopComposeProject(ctx, project)
-	}
-	return a.containerService.StopContainer(ctx, id)
+	return a.stopWithDependents(ctx, id)
 }
 
 func (a *ContainerApp) Delete(ctx context.Context, id string) error {
 	if id == "" {
 		return fmt.Errorf("container id cannot be empty")
 	}
-	project, err := a.composeProjectForResource(ctx, id)
-	if err != nil {
-		return err
-	}
-	if project != "" {
-		return a.containerService.DeleteComposeProject(ctx, project)
-	}
-	return a.containerService.DeleteContainer(ctx, id)
+	_, err := a.DeleteResource(ctx, id, domain.DeleteRequest{})
+	return err
 }
 
 func (a *ContainerApp) GetResourceDetails(ctx context.Context, id string) (*domain.ResourceDetails, error) {
@@ -132,14 +172,12 @@ func (a *ContainerApp) GetResourceDetails(ctx context.Context, id string) (*doma
 		return nil, err
 	}
 
-	resourceType := inferResourceType(inspect.Container.Config.Image)
-	if inspect.Container.Config.Labels != nil {
-		if val, ok := inspect.Container.Config.Labels["devcon.resource_type"]; ok && val != "" {
-			resourceType = val
-		} else if _, ok := inspect.Container.Config.Labels["com.docker.compose.project"]; ok {
-			resourceType = "custom"
-		}
+	// Detect from the list entry, as ListResources does, so both agree.
+	summary, err := a.containerService.FindContainer(ctx, inspect.Container.ID)
+	if err != nil {
+		return nil, err
 	}
+	resourceType := a.detectType(summary)
 
 	details := &domain.ResourceDetails{
 		ID:             inspect.Container.ID,
@@ -178,6 +216,38 @@ func (a *ContainerApp) GetResourceDetails(ctx context.Context, id string) (*doma
 		details.CreatedAt = createdAt.Unix()
 	}
 
+	if project := inspect.Container.Config.Labels[composeProjectLabel]; project != "" {
+		replica, _ := strconv.Atoi(inspect.Container.Config.Labels[composeNumberLabel])
+		details.Stack = &domain.StackRef{
+			Project: project,
+			Service: inspect.Container.Config.Labels[composeServiceLabel],
+			Replica: replica,
+		}
+	}
+	details.ClonedFrom = inspect.Container.Config.Labels[cloneOfLabel]
+	details.Group = inspect.Container.Config.Labels[groupLabel]
+	details.DependsOn = resourceDependencies(inspect)
+	details.Readiness = resourceReadiness(inspect)
+	details.Drift, err = a.resourceDrift(ctx, inspect)
+	if err != nil {
+		return nil, err
+	}
+	lifecycle, err := a.lifecycleService.GetLifecycle(ctx, firstContainerName([]string{inspect.Container.Name}))
+	if err != nil {
+		return nil, err
+	}
+	if lifecycle != nil {
+		details.Timers = lifecycleTimers(*lifecycle)
+	}
+	details.Protected, err = a.resourceProtected(ctx, details.Name, details.Labels)
+	if err != nil {
+		return nil, err
+	}
+	details.Trash, err = a.trashService.GetTrash(ctx, details.Name)
+	if err != nil {
+		return nil, err
+	}
+
 	return details, nil
 }
 
@@ -221,6 +291,9 @@ func (a *ContainerApp) StartDevconWeb(ctx context.Context, cfg *domain.Container
 		return buildDevconStatus(inspect, true), nil
 	}
 
+	if err := a.containerService.EnsureImage(ctx, cfg.Image); err != nil {
+		return nil, err
+	}
 	created, err := a.containerService.CreateContainer(ctx, cfg)
 	if err != nil {
 		return nil, err
@@ -232,6 +305,9 @@ func (a *ContainerApp) StartDevconWeb(ctx context.Context, cfg *domain.Container
 	if err != nil {
 		return nil, err
 	}
+	if err := a.trackResource(ctx, inspect, domain.DesiredRunning, true); err != nil {
+		return nil, err
+	}
 	return buildDevconStatus(inspect, false), nil
 }
 
@@ -244,6 +320,7 @@ func (a *ContainerApp) CreateResource(ctx context.Context, cfg *domain.Container
 	cfg.Image = strings.TrimSpace(cfg.Image)
 	cfg.Type = strings.TrimSpace(cfg.Type)
 	cfg.Compose = strings.TrimSpace(cfg.Compose)
+	cfg.Group = strings.TrimSpace(cfg.Group)
 
 	if cfg.Name == "" {
 		return nil, fmt.Errorf("resource name cannot be empty")
@@ -261,7 +338,10 @@ func (a *ContainerApp) CreateResource(ctx context.Context, cfg *domain.Container
 		return nil, fmt.Errorf("host port cannot be empty")
 	}
 	if cfg.Type == "" {
-		cfg.Type = inferResourceType(cfg.Image)
+		cfg.Type = a.detector.Detect(detect.Subject{Image: cfg.Image, Ports: []string{cfg.ContainerPort + "/tcp"}})
+	}
+	if cfg.Group != "" && !resourceNameRegexp.MatchString(cfg.Group) {
+		return nil, fmt.Errorf("invalid group name %q", cfg.Group)
 	}
 
 	container, err := a.containerService.FindContainer(ctx, cfg.Name)
@@ -269,76 +349,55 @@ func (a *ContainerApp) CreateResource(ctx context.Context, cfg *domain.Container
 		return nil, err
 	}
 	if container.ID != "" {
+		if entry, err := a.trashService.GetTrash(ctx, cfg.Name); err == nil && entry != nil {
+			return nil, fmt.Errorf("resource %s is in the trash; restore or purge it first", cfg.Name)
+		}
 		return nil, fmt.Errorf("resource %s already exists", cfg.Name)
 	}
-
-	created, err := a.containerService.CreateContainer(ctx, cfg)
-	if err != nil {
+	cfg.Labels = make(map[string]string)
+	if err := readinessLabels(cfg.Readiness, cfg.Labels); err != nil {
 		return nil, err
 	}
-	if err := a.containerService.StartContainer(ctx, created.ID); err != nil {
-		return nil, err
-	}
-	inspect, err := a.containerService.InsepectContainer(ctx, created.ID)
+	timers, err := newLifecycleRequest(cfg.TTL, cfg.TTLAction, cfg.IdleTimeout)
 	if err != nil {
 		return nil, err
 	}
-	return buildDevconStatus(inspect, false), nil
-}
-
-func (a *ContainerApp) startComposeStack(ctx context.Context, cfg *domain.ContainerCfg) (*domain.DevconStatus, error) {
-	composeContent := strings.TrimSpace(cfg.Compose)
-	if composeContent == "" {
-		return nil, fmt.Errorf("compose payload cannot be empty")
-	}
-
-	project := composeProjectName(cfg.Name)
-	existing, err := a.containerService.FindContainersByComposeProject(ctx, project)
+	dependsOn, err := a.checkDependencies(ctx, cfg.Name, cfg.DependsOn, true)
 	if err != nil {
 		return nil, err
 	}
-	if len(existing) > 0 {
-		if err := a.containerService.StartComposeProject(ctx, project); err != nil {
-			return nil, err
-		}
-		inspect, err := a.containerService.InsepectContainer(ctx, existing[0].ID)
-		if err != nil {
-			return nil, err
-		}
-		return buildDevconStatus(inspect, true), nil
+	if len(dependsOn) > 0 {
+		cfg.Labels[dependsOnLabel] = deps.Encode(dependsOn)
 	}
-
-	tmpFile, err := os.CreateTemp("", fmt.Sprintf("devcon-compose-%s-*.yml", project))
-	if err != nil {
-		return nil, err
+	if cfg.Group != "" {
+		cfg.Labels[groupLabel] = cfg.Group
 	}
-	defer os.Remove(tmpFile.Name())
 
-	if _, err := tmpFile.WriteString(composeContent); err != nil {
-		tmpFile.Close()
+	a.operationPhase(ctx, domain.PhasePulling, "pulling %s", cfg.Image)
+	if err := a.containerService.EnsureImage(ctx, cfg.Image); err != nil {
 		return nil, err
 	}
-	if err := tmpFile.Close(); err != nil {
+	a.operationPhase(ctx, domain.PhaseCreating, "creating %s", cfg.Name)
+	created, err := a.containerService.CreateContainer(ctx, cfg)
+	if err != nil {
 		return nil, err
 	}
-
-	cmd := exec.CommandContext(ctx, "docker", "compose", "-f", tmpFile.Name(), "-p", project, "up", "-d")
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return nil, fmt.Errorf("failed to start compose stack: %w - %s", err, string(output))
+	a.operationPhase(ctx, domain.PhaseStarting, "starting %s", cfg.Name)
+	if err := a.startWithDependencies(ctx, created.ID, false); err != nil {
+		return nil, err
 	}
-
-	created, err := a.containerService.FindContainersByComposeProject(ctx, project)
+	inspect, err := a.containerService.InsepectContainer(ctx, created.ID)
 	if err != nil {
 		return nil, err
 	}
-	if len(created) == 0 {
-		return &domain.DevconStatus{ID: project, Name: cfg.Name, Image: "docker-compose stack", State: "running", AlreadyExisted: false}, nil
-	}
-	inspect, err := a.containerService.InsepectContainer(ctx, created[0].ID)
-	if err != nil {
+	if err := a.trackResource(ctx, inspect, domain.DesiredRunning, true); err != nil {
 		return nil, err
 	}
+	if timers != nil {
+		if _, err := a.SetLifecycle(ctx, created.ID, *timers); err != nil {
+			return nil, err
+		}
+	}
 	return buildDevconStatus(inspect, false), nil
 }
 
@@ -377,17 +436,6 @@ func firstContainerName(names []string) string {
 
 var composeProjectRegexp = regexp.MustCompile(`[^a-z0-9]+`)
 
-func (a *ContainerApp) composeProjectForResource(ctx context.Context, identifier string) (string, error) {
-	container, err := a.containerService.FindContainer(ctx, identifier)
-	if err != nil {
-		return "", err
-	}
-	if container.ID == "" || container.Labels == nil {
-		return "", nil
-	}
-	return strings.TrimSpace(container.Labels["com.docker.compose.project"]), nil
-}
-
 func composeProjectName(name string) string {
 	base := strings.ToLower(strings.TrimSpace(name))
 	if base == "" {
@@ -401,14 +449,17 @@ func composeProjectName(name string) string {
 	return sanitized
 }
 
-func inferResourceType(image string) string {
-	normalized := strings.ToLower(image)
-	switch {
-	case strings.Contains(normalized, "postgres"):
-		return "postgres"
-	case strings.Contains(normalized, "redis"):
-		return "redis"
-	default:
-		return "compute"
+func (a *ContainerApp) detectType(c container.Summary) string {
+	subject := detect.Subject{
+		Image:   c.Image,
+		Labels:  c.Labels,
+		Ports:   make([]string, 0, len(c.Ports)),
+		Command: c.Command,
+	}
+	for _, port := range c.Ports {
+		if port.PrivatePort != 0 {
+			subject.Ports = append(subject.Ports, fmt.Sprintf("%d/%s", port.PrivatePort, port.Type))
+		}
 	}
+	return a.detector.Detect(subject)
 }
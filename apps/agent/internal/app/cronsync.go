@@ -0,0 +1,78 @@
+package app
+
+import (
+	"log"
+	"sync"
+
+	"github.com/robfig/cron/v3"
+)
+
+// cronSync keeps one cron entry per key in step with stored schedules. The
+// schedulers for actions, backups and garbage collection differ only in where
+// their specs come from and what runs when one fires.
+type cronSync struct {
+	mu      sync.Mutex
+	name    string
+	cron    *cron.Cron
+	entries map[string]cronEntry
+}
+
+type cronEntry struct {
+	id   cron.EntryID
+	spec string
+}
+
+func newCronSync(name string) *cronSync {
+	return &cronSync{
+		name:    name,
+		cron:    cron.New(cron.WithChain(cron.SkipIfStillRunning(cron.DiscardLogger))),
+		entries: make(map[string]cronEntry),
+	}
+}
+
+// start calls resync once, then every minute so changes made from another
+// process, such as the CLI, are picked up, and starts the cron.
+func (s *cronSync) start(resync func() error) error {
+	if err := resync(); err != nil {
+		return err
+	}
+	if _, err := s.cron.AddFunc("@every 1m", func() {
+		if err := resync(); err != nil {
+			log.Printf("%s: %v", s.name, err)
+		}
+	}); err != nil {
+		return err
+	}
+	s.cron.Start()
+	return nil
+}
+
+// sync schedules run for every key in wanted, keyed to its cron spec. Entries
+// whose spec is unchanged keep running; the rest are replaced or removed. A
+// spec the cron parser rejects is logged and left unscheduled.
+func (s *cronSync) sync(wanted map[string]string, run func(key string)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, spec := range wanted {
+		if entry, ok := s.entries[key]; ok {
+			if entry.spec == spec {
+				continue
+			}
+			s.cron.Remove(entry.id)
+			delete(s.entries, key)
+		}
+		id, err := s.cron.AddFunc(spec, func() { run(key) })
+		if err != nil {
+			log.Printf("%s: %s: %v", s.name, key, err)
+			continue
+		}
+		s.entries[key] = cronEntry{id: id, spec: spec}
+	}
+	for key, entry := range s.entries {
+		if _, ok := wanted[key]; !ok {
+			s.cron.Remove(entry.id)
+			delete(s.entries, key)
+		}
+	}
+}
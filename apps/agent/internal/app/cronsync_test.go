@@ -0,0 +1,69 @@
+package app
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+func TestCronSync(t *testing.T) {
+	s := newCronSync("test")
+	run := func(string) {}
+
+	steps := []struct {
+		name        string
+		wanted      map[string]string
+		wantSpecs   map[string]string
+		wantSameIDs []string
+	}{
+		{
+			name:      "add",
+			wanted:    map[string]string{"a": "@every 1h", "b": "0 2 * * *"},
+			wantSpecs: map[string]string{"a": "@every 1h", "b": "0 2 * * *"},
+		},
+		{
+			name:        "unchanged keeps entries",
+			wanted:      map[string]string{"a": "@every 1h", "b": "0 2 * * *"},
+			wantSpecs:   map[string]string{"a": "@every 1h", "b": "0 2 * * *"},
+			wantSameIDs: []string{"a", "b"},
+		},
+		{
+			name:        "changed spec replaces entry",
+			wanted:      map[string]string{"a": "@every 1h", "b": "0 3 * * *"},
+			wantSpecs:   map[string]string{"a": "@every 1h", "b": "0 3 * * *"},
+			wantSameIDs: []string{"a"},
+		},
+		{
+			name:        "invalid spec is dropped",
+			wanted:      map[string]string{"a": "@every 1h", "b": "not a spec"},
+			wantSpecs:   map[string]string{"a": "@every 1h"},
+			wantSameIDs: []string{"a"},
+		},
+		{
+			name:      "missing key is removed",
+			wanted:    map[string]string{},
+			wantSpecs: map[string]string{},
+		},
+	}
+	for _, step := range steps {
+		before := maps.Clone(s.entries)
+		s.sync(step.wanted, run)
+
+		specs := make(map[string]string, len(s.entries))
+		for key, entry := range s.entries {
+			specs[key] = entry.spec
+		}
+		if !maps.Equal(specs, step.wantSpecs) {
+			t.Errorf("%s: specs = %v, want %v", step.name, specs, step.wantSpecs)
+		}
+		for key, entry := range s.entries {
+			same := before[key].id == entry.id
+			if same != slices.Contains(step.wantSameIDs, key) {
+				t.Errorf("%s: %s kept entry = %v", step.name, key, same)
+			}
+		}
+		if got := len(s.cron.Entries()); got != len(step.wantSpecs) {
+			t.Errorf("%s: cron has %d entries, want %d", step.name, got, len(step.wantSpecs))
+		}
+	}
+}
@@ -0,0 +1,325 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/deps"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+	"github.com/moby/moby/api/types/container"
+	dockerclient "github.com/moby/moby/client"
+)
+
+const dependsOnLabel = "devcon.depends_on"
+
+const dependencyPollInterval = 500 * time.Millisecond
+
+// resourceGraph is every container by name together with the dependencies
+// declared in its labels.
+type resourceGraph struct {
+	containers map[string]container.Summary
+	deps       map[string][]domain.ResourceDependency
+}
+
+func (a *ContainerApp) resourceGraph(ctx context.Context) (*resourceGraph, error) {
+	list, err := a.containerService.ListContainers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	graph := &resourceGraph{
+		containers: make(map[string]container.Summary, len(list.Items)),
+		deps:       make(map[string][]domain.ResourceDependency),
+	}
+	for _, c := range list.Items {
+		name := firstContainerName(c.Names)
+		graph.containers[name] = c
+		// A label that does not parse is treated as no dependencies rather
+		// than making the resource impossible to start.
+		if declared, err := deps.Decode(c.Labels[dependsOnLabel]); err == nil && len(declared) > 0 {
+			graph.deps[name] = declared
+		}
+	}
+	return graph, nil
+}
+
+func (g *resourceGraph) edges() map[string][]string {
+	edges := make(map[string][]string, len(g.containers))
+	for name := range g.containers {
+		edges[name] = nil
+	}
+	for name, declared := range g.deps {
+		for _, dep := range declared {
+			edges[name] = append(edges[name], dep.Resource)
+		}
+	}
+	return edges
+}
+
+// checkDependencies validates the dependencies a new resource declares and
+// rejects any that would close a cycle with existing resources.
+func (a *ContainerApp) checkDependencies(ctx context.Context, name string, declared []domain.ResourceDependency, requireExisting bool) ([]domain.ResourceDependency, error) {
+	if len(declared) == 0 {
+		return nil, nil
+	}
+	graph, err := a.resourceGraph(ctx)
+	if err != nil {
+		return nil, err
+	}
+	normalized := make([]domain.ResourceDependency, 0, len(declared))
+	seen := make(map[string]bool, len(declared))
+	for _, dep := range declared {
+		dep, err := deps.Normalize(dep)
+		if err != nil {
+			return nil, err
+		}
+		if dep.Resource == name {
+			return nil, fmt.Errorf("resource %s cannot depend on itself", name)
+		}
+		if seen[dep.Resource] {
+			return nil, fmt.Errorf("dependency %s is listed twice", dep.Resource)
+		}
+		seen[dep.Resource] = true
+		if _, ok := graph.containers[dep.Resource]; requireExisting && !ok {
+			return nil, fmt.Errorf("dependency %s not found", dep.Resource)
+		}
+		normalized = append(normalized, dep)
+	}
+
+	graph.deps[name] = normalized
+	edges := graph.edges()
+	if _, ok := edges[name]; !ok {
+		edges[name] = nil
+	}
+	for _, dep := range normalized {
+		if _, ok := edges[dep.Resource]; !ok {
+			edges[dep.Resource] = nil
+		}
+	}
+	if _, err := deps.Order(edges); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
+
+// startWithDependencies brings the resource's dependencies up first, waiting
+// for each declared condition, and then starts or restarts the resource.
+func (a *ContainerApp) startWithDependencies(ctx context.Context, identifier string, restart bool) error {
+	inspect, err := a.containerService.InsepectContainer(ctx, identifier)
+	if err != nil {
+		return err
+	}
+	name := firstContainerName([]string{inspect.Container.Name})
+	graph, err := a.resourceGraph(ctx)
+	if err != nil {
+		return err
+	}
+	order, err := deps.Order(deps.Closure(graph.edges(), name))
+	if err != nil {
+		return err
+	}
+	trashed, err := a.trashedNames(ctx)
+	if err != nil {
+		return err
+	}
+	for _, node := range order {
+		if trashed[node] {
+			return fmt.Errorf("resource %s is in the trash; restore it first", node)
+		}
+	}
+
+	for _, node := range order {
+		for _, dep := range graph.deps[node] {
+			target, ok := graph.containers[dep.Resource]
+			if !ok {
+				return fmt.Errorf("dependency %s of %s not found", dep.Resource, node)
+			}
+			if err := a.waitForDependency(ctx, dep, target.ID); err != nil {
+				return err
+			}
+		}
+
+		if node == name {
+			break
+		}
+		c := graph.containers[node]
+		if c.State == "running" {
+			continue
+		}
+		if err := a.setDesiredState(ctx, c.ID, domain.DesiredRunning); err != nil {
+			return err
+		}
+		if err := a.containerService.StartContainer(ctx, c.ID); err != nil {
+			return fmt.Errorf("failed to start dependency %s: %w", node, err)
+		}
+	}
+
+	if err := a.setDesiredState(ctx, inspect.Container.ID, domain.DesiredRunning); err != nil {
+		return err
+	}
+	if restart {
+		return a.containerService.RestartContainer(ctx, inspect.Container.ID)
+	}
+	return a.containerService.StartContainer(ctx, inspect.Container.ID)
+}
+
+// stopWithDependents stops everything that depends on the resource first,
+// the reverse of the start order, and then the resource itself.
+func (a *ContainerApp) stopWithDependents(ctx context.Context, identifier string) error {
+	inspect, err := a.containerService.InsepectContainer(ctx, identifier)
+	if err != nil {
+		return err
+	}
+	name := firstContainerName([]string{inspect.Container.Name})
+	graph, err := a.resourceGraph(ctx)
+	if err != nil {
+		return err
+	}
+	order, err := deps.Order(deps.Closure(deps.Reverse(graph.edges()), name))
+	if err != nil {
+		return err
+	}
+
+	for _, node := range order {
+		c, ok := graph.containers[node]
+		if !ok || (node != name && c.State != "running") {
+			continue
+		}
+		if err := a.setDesiredState(ctx, c.ID, domain.DesiredStopped); err != nil {
+			return err
+		}
+		if err := a.containerService.StopContainer(ctx, c.ID); err != nil {
+			if node != name {
+				return fmt.Errorf("failed to stop dependent %s: %w", node, err)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *ContainerApp) waitForDependency(ctx context.Context, dep domain.ResourceDependency, id string) error {
+	timeout := deps.Timeout(dep)
+	deadline := time.Now().Add(timeout)
+	for {
+		inspect, err := a.containerService.InsepectContainer(ctx, id)
+		if err != nil {
+			return err
+		}
+		ready, err := conditionMet(inspect, dep.Condition, dep.Port)
+		if err != nil {
+			return fmt.Errorf("dependency %s: %w", dep.Resource, err)
+		}
+		if ready {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out after %s waiting for dependency %s to be %s", timeout, dep.Resource, dep.Condition)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(dependencyPollInterval):
+		}
+	}
+}
+
+// conditionMet reports whether a container satisfies a start condition. A
+// container that exited, or a healthy condition on a container without a
+// healthcheck, can never be met and is an error.
+func conditionMet(inspect dockerclient.ContainerInspectResult, condition, port string) (bool, error) {
+	state := inspect.Container.State
+	if state == nil {
+		return false, nil
+	}
+	if !state.Running {
+		if state.Status == container.StateExited || state.Status == container.StateDead {
+			return false, fmt.Errorf("exited with code %d", state.ExitCode)
+		}
+		return false, nil
+	}
+	switch condition {
+	case deps.ConditionHealthy:
+		if state.Health == nil || state.Health.Status == container.NoHealthcheck {
+			return false, fmt.Errorf("has no healthcheck")
+		}
+		return state.Health.Status == container.Healthy, nil
+	case deps.ConditionPortOpen:
+		address, err := dialAddress(inspect, port)
+		if err != nil {
+			return false, err
+		}
+		conn, err := net.DialTimeout("tcp", address, time.Second)
+		if err != nil {
+			return false, nil
+		}
+		conn.Close()
+		return true, nil
+	default:
+		return true, nil
+	}
+}
+
+// dialAddress finds where the agent can reach a container port: its
+// published host port, or the container's address when it is not published.
+// Without a port the lowest exposed TCP port is used.
+func dialAddress(inspect dockerclient.ContainerInspectResult, port string) (string, error) {
+	settings := inspect.Container.NetworkSettings
+	if settings == nil {
+		return "", fmt.Errorf("has no network settings")
+	}
+	port = strings.TrimSuffix(port, "/tcp")
+
+	if port == "" {
+		lowest := 0
+		for p := range settings.Ports {
+			if p.Proto() == "tcp" && (lowest == 0 || int(p.Num()) < lowest) {
+				lowest = int(p.Num())
+			}
+		}
+		if lowest == 0 {
+			return "", fmt.Errorf("exposes no tcp port")
+		}
+		port = strconv.Itoa(lowest)
+	}
+
+	for p, bindings := range settings.Ports {
+		if p.Proto() != "tcp" || strconv.Itoa(int(p.Num())) != port {
+			continue
+		}
+		for _, binding := range bindings {
+			if binding.HostPort == "" {
+				continue
+			}
+			host := "127.0.0.1"
+			if binding.HostIP.IsValid() && !binding.HostIP.IsUnspecified() {
+				host = binding.HostIP.String()
+			}
+			return net.JoinHostPort(host, binding.HostPort), nil
+		}
+	}
+	networks := make([]string, 0, len(settings.Networks))
+	for network := range settings.Networks {
+		networks = append(networks, network)
+	}
+	sort.Strings(networks)
+	for _, network := range networks {
+		if endpoint := settings.Networks[network]; endpoint != nil && endpoint.IPAddress.IsValid() {
+			return net.JoinHostPort(endpoint.IPAddress.String(), port), nil
+		}
+	}
+	return "", fmt.Errorf("port %s is not reachable", port)
+}
+
+// resourceDependencies reads the dependencies a container declares.
+func resourceDependencies(inspect dockerclient.ContainerInspectResult) []domain.ResourceDependency {
+	if inspect.Container.Config == nil {
+		return nil
+	}
+	declared, _ := deps.Decode(inspect.Container.Config.Labels[dependsOnLabel])
+	return declared
+}
@@ -0,0 +1,74 @@
+package app
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/deps"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+	"github.com/moby/moby/api/types/container"
+	dockerclient "github.com/moby/moby/client"
+)
+
+func TestConditionMet(t *testing.T) {
+	running := func(health *container.Health) *container.State {
+		return &container.State{Running: true, Status: container.StateRunning, Health: health}
+	}
+	tests := []struct {
+		name      string
+		state     *container.State
+		condition string
+		want      bool
+		wantErr   string
+	}{
+		{"no state", nil, deps.ConditionStarted, false, ""},
+		{"created", &container.State{Status: container.StateCreated}, deps.ConditionStarted, false, ""},
+		{"exited", &container.State{Status: container.StateExited, ExitCode: 3}, deps.ConditionStarted, false, "exited with code 3"},
+		{"started", running(nil), deps.ConditionStarted, true, ""},
+		{"healthy without healthcheck", running(nil), deps.ConditionHealthy, false, "has no healthcheck"},
+		{"starting", running(&container.Health{Status: container.Starting}), deps.ConditionHealthy, false, ""},
+		{"healthy", running(&container.Health{Status: container.Healthy}), deps.ConditionHealthy, true, ""},
+		{"port without network settings", running(nil), deps.ConditionPortOpen, false, "has no network settings"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inspect := dockerclient.ContainerInspectResult{Container: container.InspectResponse{State: tt.state}}
+			got, err := conditionMet(inspect, tt.condition, "")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("conditionMet() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("conditionMet() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("conditionMet() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResourceDependencies(t *testing.T) {
+	declared := []domain.ResourceDependency{{Resource: "db", Condition: deps.ConditionHealthy}}
+	tests := []struct {
+		name   string
+		config *container.Config
+		want   []domain.ResourceDependency
+	}{
+		{"no config", nil, nil},
+		{"no label", &container.Config{}, nil},
+		{"declared", &container.Config{Labels: map[string]string{dependsOnLabel: deps.Encode(declared)}}, declared},
+		{"unreadable label", &container.Config{Labels: map[string]string{dependsOnLabel: "{"}}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inspect := dockerclient.ContainerInspectResult{Container: container.InspectResponse{Config: tt.config}}
+			if got := resourceDependencies(inspect); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("resourceDependencies() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
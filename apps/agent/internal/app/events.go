@@ -0,0 +1,82 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+)
+
+// eventHistory is how many recent events are kept for polling clients.
+const eventHistory = 500
+
+type eventLog struct {
+	mu          sync.Mutex
+	seq         int64
+	events      []domain.ResourceEvent
+	subscribers map[chan domain.ResourceEvent]struct{}
+}
+
+func (a *ContainerApp) publishEvent(kind, resource, format string, args ...any) {
+	event := domain.ResourceEvent{
+		Time:     time.Now().Unix(),
+		Kind:     kind,
+		Resource: resource,
+		Message:  fmt.Sprintf(format, args...),
+	}
+	log.Printf("%s %s: %s", kind, resource, event.Message)
+
+	a.events.mu.Lock()
+	defer a.events.mu.Unlock()
+	a.events.seq++
+	event.Seq = a.events.seq
+	a.events.events = append(a.events.events, event)
+	if len(a.events.events) > eventHistory {
+		a.events.events = a.events.events[len(a.events.events)-eventHistory:]
+	}
+	for ch := range a.events.subscribers {
+		// A subscriber that cannot keep up misses events rather than
+		// blocking the publisher.
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// Events returns the kept events newer than since.
+func (a *ContainerApp) Events(since int64) []domain.ResourceEvent {
+	a.events.mu.Lock()
+	defer a.events.mu.Unlock()
+	events := make([]domain.ResourceEvent, 0)
+	for _, event := range a.events.events {
+		if event.Seq > since {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// SubscribeEvents delivers events as they are published until ctx is done,
+// then closes the channel.
+func (a *ContainerApp) SubscribeEvents(ctx context.Context) <-chan domain.ResourceEvent {
+	ch := make(chan domain.ResourceEvent, 64)
+	a.events.mu.Lock()
+	if a.events.subscribers == nil {
+		a.events.subscribers = make(map[chan domain.ResourceEvent]struct{})
+	}
+	a.events.subscribers[ch] = struct{}{}
+	a.events.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		a.events.mu.Lock()
+		delete(a.events.subscribers, ch)
+		a.events.mu.Unlock()
+		close(ch)
+	}()
+	return ch
+}
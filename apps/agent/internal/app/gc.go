@@ -0,0 +1,520 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/config"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/backup"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+	"github.com/moby/moby/api/types/container"
+)
+
+const (
+	protectedLabel       = "devcon.protected"
+	anonymousVolumeLabel = "com.docker.volume.anonymous"
+	eventGC              = "gc"
+)
+
+func (a *ContainerApp) GetGCConfig(ctx context.Context) (*domain.GCConfig, error) {
+	cfg, err := a.gcService.GetConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.MaxAge == "" {
+		cfg.MaxAge = config.GCMaxAge().String()
+	}
+	if cfg.Protected == nil {
+		cfg.Protected = []string{}
+	}
+	return cfg, nil
+}
+
+func (a *ContainerApp) UpdateGCConfig(ctx context.Context, req domain.GCConfigRequest) (*domain.GCConfig, error) {
+	cfg, err := a.gcService.GetConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.MaxAge != "" {
+		if _, err := gcMaxAge(req.MaxAge); err != nil {
+			return nil, err
+		}
+		cfg.MaxAge = req.MaxAge
+	}
+	switch req.Schedule {
+	case "":
+	case "off":
+		cfg.Schedule = ""
+	default:
+		if err := backup.ValidateSchedule(req.Schedule); err != nil {
+			return nil, err
+		}
+		cfg.Schedule = req.Schedule
+	}
+	if req.Volumes != nil {
+		cfg.Volumes = *req.Volumes
+	}
+	if err := a.gcService.SaveConfig(ctx, cfg); err != nil {
+		return nil, err
+	}
+	if err := a.syncGCSchedule(ctx); err != nil {
+		return nil, err
+	}
+	return a.GetGCConfig(ctx)
+}
+
+// ProtectArtifact keeps garbage collection away from everything carrying
+// name: a resource, a stack or workspace and its members, or a volume,
+// network or image.
+func (a *ContainerApp) ProtectArtifact(ctx context.Context, name string) (*domain.GCConfig, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("name cannot be empty")
+	}
+	cfg, err := a.gcService.GetConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(cfg.Protected, name) {
+		cfg.Protected = append(cfg.Protected, name)
+		sort.Strings(cfg.Protected)
+		if err := a.gcService.SaveConfig(ctx, cfg); err != nil {
+			return nil, err
+		}
+	}
+	return a.GetGCConfig(ctx)
+}
+
+func (a *ContainerApp) UnprotectArtifact(ctx context.Context, name string) (*domain.GCConfig, error) {
+	cfg, err := a.gcService.GetConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	i := slices.Index(cfg.Protected, name)
+	if i < 0 {
+		return nil, fmt.Errorf("%s is not protected", name)
+	}
+	cfg.Protected = slices.Delete(cfg.Protected, i, i+1)
+	if err := a.gcService.SaveConfig(ctx, cfg); err != nil {
+		return nil, err
+	}
+	return a.GetGCConfig(ctx)
+}
+
+// RunGC collects devcon artifacts that have been unused for longer than the
+// max age. A dry run only reports what would be removed.
+func (a *ContainerApp) RunGC(ctx context.Context, req domain.GCRequest) (*domain.GCReport, error) {
+	return a.collectGarbage(ctx, req, backup.TriggerManual)
+}
+
+// StartGCScheduler runs garbage collection on the configured schedule. Every
+// minute it also records which artifacts devcon containers use, so an image
+// or volume is aged from when it was last used rather than when it was made.
+func (a *ContainerApp) StartGCScheduler() error {
+	a.gcScheduler = newCronSync("gc scheduler")
+	return a.gcScheduler.start(func() error {
+		ctx := context.Background()
+		if err := a.observeArtifactUsage(ctx, nil); err != nil {
+			log.Println("gc scheduler:", err)
+		}
+		return a.syncGCSchedule(ctx)
+	})
+}
+
+func (a *ContainerApp) syncGCSchedule(ctx context.Context) error {
+	if a.gcScheduler == nil {
+		return nil
+	}
+	cfg, err := a.gcService.GetConfig(ctx)
+	if err != nil {
+		return err
+	}
+	wanted := make(map[string]string, 1)
+	if cfg.Schedule != "" {
+		wanted[eventGC] = cfg.Schedule
+	}
+	a.gcScheduler.sync(wanted, func(string) { a.runScheduledGC() })
+	return nil
+}
+
+func (a *ContainerApp) runScheduledGC() {
+	report, err := a.collectGarbage(context.Background(), domain.GCRequest{}, backup.TriggerSchedule)
+	if err != nil {
+		log.Println("gc scheduler:", err)
+		return
+	}
+	if report.Errors > 0 {
+		log.Printf("gc scheduler: %d artifacts could not be removed", report.Errors)
+	}
+}
+
+func (a *ContainerApp) collectGarbage(ctx context.Context, req domain.GCRequest, trigger string) (*domain.GCReport, error) {
+	cfg, err := a.GetGCConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.MaxAge == "" {
+		req.MaxAge = cfg.MaxAge
+	}
+	maxAge, err := gcMaxAge(req.MaxAge)
+	if err != nil {
+		return nil, err
+	}
+	volumes := cfg.Volumes
+	if req.Volumes != nil {
+		volumes = *req.Volumes
+	}
+
+	report := &domain.GCReport{
+		DryRun:    req.DryRun,
+		MaxAge:    req.MaxAge,
+		Trigger:   trigger,
+		StartedAt: time.Now().Unix(),
+	}
+	report.Candidates, err = a.planGC(ctx, cfg.Protected, time.Now().Add(-maxAge).Unix(), volumes)
+	if err != nil {
+		return nil, err
+	}
+	for _, candidate := range report.Candidates {
+		report.ReclaimableBytes += candidate.SizeBytes
+	}
+	if req.DryRun {
+		report.FinishedAt = time.Now().Unix()
+		return report, nil
+	}
+
+	for i := range report.Candidates {
+		candidate := &report.Candidates[i]
+		if err := a.removeCandidate(ctx, *candidate); err != nil {
+			candidate.Error = err.Error()
+			report.Errors++
+			continue
+		}
+		candidate.Removed = true
+		report.ReclaimedBytes += candidate.SizeBytes
+	}
+	report.FinishedAt = time.Now().Unix()
+	if err := a.forgetArtifactUsage(ctx, report.Candidates); err != nil {
+		return nil, err
+	}
+
+	a.publishEvent(eventGC, "", "removed %d of %d unused artifacts, reclaimed %d bytes", len(report.Candidates)-report.Errors, len(report.Candidates), report.ReclaimedBytes)
+	// Re-read so settings changed while collecting are kept.
+	latest, err := a.gcService.GetConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	latest.LastRun = report
+	if err := a.gcService.SaveConfig(ctx, latest); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// planGC lists what collection removes, in removal order: containers first
+// so the images, networks and volumes they held are free to go after them.
+func (a *ContainerApp) planGC(ctx context.Context, protected []string, cutoff int64, volumes bool) ([]domain.GCCandidate, error) {
+	usage, err := a.containerService.DiskUsage(ctx)
+	if err != nil {
+		return nil, err
+	}
+	networks, err := a.containerService.ListNetworks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.observeArtifactUsage(ctx, usage.Containers.Items); err != nil {
+		return nil, err
+	}
+	lastUsed, err := a.gcService.GetUsage(ctx)
+	if err != nil {
+		return nil, err
+	}
+	guard, err := a.protectedNames(ctx, protected)
+	if err != nil {
+		return nil, err
+	}
+
+	volumeSizes := make(map[string]int64, len(usage.Volumes.Items))
+	anonymous := make(map[string]bool)
+	for _, v := range usage.Volumes.Items {
+		if v.UsageData != nil && v.UsageData.Size > 0 {
+			volumeSizes[v.Name] = v.UsageData.Size
+		}
+		if _, ok := v.Labels[anonymousVolumeLabel]; ok {
+			anonymous[v.Name] = true
+		}
+	}
+
+	candidates := make([]domain.GCCandidate, 0)
+	// Whatever a container that stays holds on to is in use.
+	usedImages := make(map[string]bool)
+	usedNetworks := make(map[string]bool)
+	usedVolumes := make(map[string]bool)
+	for _, c := range usage.Containers.Items {
+		candidate, err := a.containerCandidate(ctx, c, guard, cutoff)
+		if err != nil {
+			return nil, err
+		}
+		if candidate == nil {
+			usedImages[c.ImageID] = true
+			if c.NetworkSettings != nil {
+				for _, n := range c.NetworkSettings.Networks {
+					usedNetworks[n.NetworkID] = true
+				}
+			}
+			for _, m := range c.Mounts {
+				usedVolumes[m.Name] = true
+			}
+			continue
+		}
+		candidate.SizeBytes = c.SizeRw
+		for _, m := range c.Mounts {
+			if m.Name != "" && anonymous[m.Name] {
+				candidate.Volumes = append(candidate.Volumes, m.Name)
+				candidate.SizeBytes += volumeSizes[m.Name]
+				// Removed along with the container.
+				usedVolumes[m.Name] = true
+			}
+		}
+		candidates = append(candidates, *candidate)
+	}
+
+	for _, n := range networks.Items {
+		if !ownedArtifact(n.Labels) || usedNetworks[n.ID] || guard.labels(n.Labels) || guard.names[n.Name] {
+			continue
+		}
+		since := max(n.Created.Unix(), lastUsed[domain.GCNetwork+":"+n.ID])
+		if since > cutoff {
+			continue
+		}
+		candidates = append(candidates, domain.GCCandidate{Kind: domain.GCNetwork, ID: n.ID, Name: n.Name, UnusedSince: since})
+	}
+
+	if volumes {
+		for _, v := range usage.Volumes.Items {
+			if !ownedArtifact(v.Labels) || usedVolumes[v.Name] || guard.labels(v.Labels) || guard.names[v.Name] {
+				continue
+			}
+			if v.UsageData != nil && v.UsageData.RefCount > 0 {
+				continue
+			}
+			since := lastUsed[domain.GCVolume+":"+v.Name]
+			if created, err := time.Parse(time.RFC3339, v.CreatedAt); err == nil {
+				since = max(since, created.Unix())
+			}
+			if since > cutoff {
+				continue
+			}
+			candidates = append(candidates, domain.GCCandidate{Kind: domain.GCVolume, ID: v.Name, Name: v.Name, SizeBytes: volumeSizes[v.Name], UnusedSince: since})
+		}
+	}
+
+	for _, img := range usage.Images.Items {
+		key := domain.GCImage + ":" + img.ID
+		// Pulled images are shared with everything else on the host. Besides
+		// images devcon or compose built, only those a devcon container has
+		// used are devcon's to remove, and only once no container uses them.
+		_, seen := lastUsed[key]
+		if !ownedArtifact(img.Labels) && !seen {
+			continue
+		}
+		if usedImages[img.ID] || guard.labels(img.Labels) || guard.image(img.RepoTags) {
+			continue
+		}
+		since := max(img.Created, lastUsed[key])
+		if since > cutoff {
+			continue
+		}
+		name := img.ID
+		if !danglingImage(img.RepoTags) {
+			name = img.RepoTags[0]
+		}
+		candidates = append(candidates, domain.GCCandidate{Kind: domain.GCImage, ID: img.ID, Name: name, SizeBytes: img.Size, UnusedSince: since})
+	}
+	return candidates, nil
+}
+
+// containerCandidate returns nil for a container that must stay: one devcon
+// did not create, one that is running, protected, or stopped too recently.
+func (a *ContainerApp) containerCandidate(ctx context.Context, c container.Summary, guard gcGuard, cutoff int64) (*domain.GCCandidate, error) {
+	if !ownedArtifact(c.Labels) || guard.labels(c.Labels) || guard.names[firstContainerName(c.Names)] {
+		return nil, nil
+	}
+	switch c.State {
+	case container.StateExited, container.StateCreated, container.StateDead:
+	default:
+		return nil, nil
+	}
+	since := c.Created
+	inspect, err := a.containerService.InsepectContainer(ctx, c.ID)
+	if err != nil {
+		return nil, err
+	}
+	if finished, err := time.Parse(time.RFC3339Nano, inspect.Container.State.FinishedAt); err == nil && finished.Unix() > since {
+		since = finished.Unix()
+	}
+	if since > cutoff {
+		return nil, nil
+	}
+	return &domain.GCCandidate{Kind: domain.GCContainer, ID: c.ID, Name: firstContainerName(c.Names), UnusedSince: since}, nil
+}
+
+func (a *ContainerApp) removeCandidate(ctx context.Context, candidate domain.GCCandidate) error {
+	switch candidate.Kind {
+	case domain.GCContainer:
+		if err := a.untrackResource(ctx, candidate.ID); err != nil {
+			return err
+		}
+		return a.containerService.DeleteContainerWithVolumes(ctx, candidate.ID)
+	case domain.GCNetwork:
+		return a.containerService.RemoveNetwork(ctx, candidate.ID)
+	case domain.GCVolume:
+		return a.containerService.RemoveVolume(ctx, candidate.ID)
+	case domain.GCImage:
+		return a.containerService.RemoveImage(ctx, candidate.ID)
+	}
+	return fmt.Errorf("unknown artifact kind %q", candidate.Kind)
+}
+
+// observeArtifactUsage records now as the last use of every image, network
+// and volume a devcon container holds. containers is listed when nil.
+func (a *ContainerApp) observeArtifactUsage(ctx context.Context, containers []container.Summary) error {
+	if containers == nil {
+		list, err := a.containerService.ListContainers(ctx)
+		if err != nil {
+			return err
+		}
+		containers = list.Items
+	}
+	usage, err := a.gcService.GetUsage(ctx)
+	if err != nil {
+		return err
+	}
+	now := time.Now().Unix()
+	for _, c := range containers {
+		if !ownedArtifact(c.Labels) {
+			continue
+		}
+		usage[domain.GCImage+":"+c.ImageID] = now
+		if c.NetworkSettings != nil {
+			for _, n := range c.NetworkSettings.Networks {
+				usage[domain.GCNetwork+":"+n.NetworkID] = now
+			}
+		}
+		for _, m := range c.Mounts {
+			if m.Name != "" {
+				usage[domain.GCVolume+":"+m.Name] = now
+			}
+		}
+	}
+	return a.gcService.SaveUsage(ctx, usage)
+}
+
+func (a *ContainerApp) forgetArtifactUsage(ctx context.Context, candidates []domain.GCCandidate) error {
+	usage, err := a.gcService.GetUsage(ctx)
+	if err != nil {
+		return err
+	}
+	for _, candidate := range candidates {
+		if candidate.Removed {
+			delete(usage, candidate.Kind+":"+candidate.ID)
+		}
+	}
+	return a.gcService.SaveUsage(ctx, usage)
+}
+
+// gcGuard holds the names collection must not touch.
+type gcGuard struct {
+	names map[string]bool
+}
+
+// protectedNames adds the targets of schedules to the configured names, so a
+// stopped resource a schedule would start is kept, and the resources in the
+// trash.
+func (a *ContainerApp) protectedNames(ctx context.Context, protected []string) (gcGuard, error) {
+	guard := gcGuard{names: make(map[string]bool, len(protected))}
+	for _, name := range protected {
+		guard.names[name] = true
+	}
+	schedules, err := a.scheduleService.ListSchedules(ctx)
+	if err != nil {
+		return guard, err
+	}
+	for _, s := range schedules {
+		guard.names[s.Target] = true
+	}
+	// The trash purges its own resources once they can no longer be restored.
+	trashed, err := a.trashedNames(ctx)
+	if err != nil {
+		return guard, err
+	}
+	for name := range trashed {
+		guard.names[name] = true
+	}
+	return guard, nil
+}
+
+func (g gcGuard) labels(labels map[string]string) bool {
+	if labels[protectedLabel] == "true" {
+		return true
+	}
+	for _, key := range []string{resourceNameLabel, composeProjectLabel, workspaceLabel} {
+		if value := labels[key]; value != "" && g.names[value] {
+			return true
+		}
+	}
+	return false
+}
+
+func (g gcGuard) image(tags []string) bool {
+	for _, tag := range tags {
+		repo, _, _ := strings.Cut(tag, ":")
+		if g.names[tag] || g.names[repo] {
+			return true
+		}
+	}
+	return false
+}
+
+func ownedArtifact(labels map[string]string) bool {
+	if labels[composeProjectLabel] != "" {
+		return true
+	}
+	for key := range labels {
+		if strings.HasPrefix(key, "devcon.") {
+			return true
+		}
+	}
+	return false
+}
+
+func danglingImage(tags []string) bool {
+	return len(tags) == 0 || (len(tags) == 1 && tags[0] == "<none>:<none>")
+}
+
+func gcMaxAge(value string) (time.Duration, error) {
+	age, err := time.ParseDuration(value)
+	if err != nil || age <= 0 {
+		return 0, fmt.Errorf("invalid max age %q", value)
+	}
+	return age, nil
+}
+
+// resourceProtected reports whether collection keeps a resource regardless
+// of its age.
+func (a *ContainerApp) resourceProtected(ctx context.Context, name string, labels map[string]string) (bool, error) {
+	cfg, err := a.gcService.GetConfig(ctx)
+	if err != nil {
+		return false, err
+	}
+	guard, err := a.protectedNames(ctx, cfg.Protected)
+	if err != nil {
+		return false, err
+	}
+	return guard.labels(labels) || guard.names[name], nil
+}
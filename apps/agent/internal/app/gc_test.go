@@ -0,0 +1,66 @@
+package app
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOwnedArtifact(t *testing.T) {
+	tests := []struct {
+		labels map[string]string
+		want   bool
+	}{
+		{nil, false},
+		{map[string]string{"maintainer": "someone"}, false},
+		{map[string]string{composeProjectLabel: "shop"}, true},
+		{map[string]string{composeProjectLabel: ""}, false},
+		{map[string]string{"devcon.type": "postgres"}, true},
+		{map[string]string{"devcon": "x"}, false},
+	}
+	for _, tt := range tests {
+		if got := ownedArtifact(tt.labels); got != tt.want {
+			t.Errorf("ownedArtifact(%v) = %v, want %v", tt.labels, got, tt.want)
+		}
+	}
+}
+
+func TestDanglingImage(t *testing.T) {
+	tests := []struct {
+		tags []string
+		want bool
+	}{
+		{nil, true},
+		{[]string{"<none>:<none>"}, true},
+		{[]string{"devcon/app:latest"}, false},
+		{[]string{"<none>:<none>", "devcon/app:latest"}, false},
+	}
+	for _, tt := range tests {
+		if got := danglingImage(tt.tags); got != tt.want {
+			t.Errorf("danglingImage(%v) = %v, want %v", tt.tags, got, tt.want)
+		}
+	}
+}
+
+func TestGCMaxAge(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"168h", 168 * time.Hour, false},
+		{"30m", 30 * time.Minute, false},
+		{"0", 0, true},
+		{"-1h", 0, true},
+		{"7d", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := gcMaxAge(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("gcMaxAge(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("gcMaxAge(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,257 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+)
+
+const groupLabel = "devcon.group"
+
+const eventGroup = "group"
+
+// ListGroups returns every group with at least one member outside the trash.
+func (a *ContainerApp) ListGroups(ctx context.Context) ([]domain.Group, error) {
+	resources, err := a.ListResources(ctx, false, false)
+	if err != nil {
+		return nil, err
+	}
+	groups := make([]domain.Group, 0)
+	index := make(map[string]int)
+	for _, resource := range resources {
+		if resource.Group == "" {
+			continue
+		}
+		i, ok := index[resource.Group]
+		if !ok {
+			i = len(groups)
+			index[resource.Group] = i
+			groups = append(groups, domain.Group{Name: resource.Group})
+		}
+		groups[i].Members = append(groups[i].Members, resource)
+	}
+	for i := range groups {
+		summarizeGroup(&groups[i])
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Name < groups[j].Name })
+	return groups, nil
+}
+
+func (a *ContainerApp) GetGroup(ctx context.Context, name string) (*domain.Group, error) {
+	resources, err := a.ListResources(ctx, false, false)
+	if err != nil {
+		return nil, err
+	}
+	group := &domain.Group{Name: name}
+	for _, resource := range resources {
+		if resource.Group == name {
+			group.Members = append(group.Members, resource)
+		}
+	}
+	if len(group.Members) == 0 {
+		return nil, fmt.Errorf("group %s not found", name)
+	}
+	summarizeGroup(group)
+	return group, nil
+}
+
+func summarizeGroup(group *domain.Group) {
+	sort.Slice(group.Members, func(i, j int) bool { return group.Members[i].Name < group.Members[j].Name })
+	group.Total = len(group.Members)
+	group.Running = 0
+	for _, member := range group.Members {
+		if member.Status == "RUNNING" {
+			group.Running++
+		}
+	}
+	group.Status = stackStatus(group.Members)
+}
+
+// AddGroupMembers puts resources in a group, creating it if needed. A
+// resource can be in one group at a time, so one already in another group
+// moves. Docker cannot change the labels of a container, so each resource is
+// recreated with the same configuration and volumes. When one fails, the
+// result still lists the resources changed before it.
+func (a *ContainerApp) AddGroupMembers(ctx context.Context, group string, resources []string) (*domain.GroupMembersResult, error) {
+	if !resourceNameRegexp.MatchString(group) {
+		return nil, fmt.Errorf("invalid group name %q", group)
+	}
+	result := &domain.GroupMembersResult{Group: group, Changed: make([]string, 0)}
+	for _, resource := range resources {
+		name, changed, err := a.relabelResource(ctx, resource, func(labels map[string]string) bool {
+			if labels[groupLabel] == group {
+				return false
+			}
+			labels[groupLabel] = group
+			return true
+		})
+		if err != nil {
+			return result, err
+		}
+		if changed {
+			result.Changed = append(result.Changed, name)
+			a.publishEvent(eventGroup, name, "added to group %s", group)
+		}
+	}
+	return result, nil
+}
+
+// RemoveGroupMembers takes resources out of a group. Resources that are not
+// in it are left alone. Like AddGroupMembers, it returns what changed before
+// a failure.
+func (a *ContainerApp) RemoveGroupMembers(ctx context.Context, group string, resources []string) (*domain.GroupMembersResult, error) {
+	result := &domain.GroupMembersResult{Group: group, Changed: make([]string, 0)}
+	for _, resource := range resources {
+		name, changed, err := a.relabelResource(ctx, resource, func(labels map[string]string) bool {
+			if labels[groupLabel] != group {
+				return false
+			}
+			delete(labels, groupLabel)
+			return true
+		})
+		if err != nil {
+			return result, err
+		}
+		if changed {
+			result.Changed = append(result.Changed, name)
+			a.publishEvent(eventGroup, name, "removed from group %s", group)
+		}
+	}
+	return result, nil
+}
+
+// relabelResource recreates a resource with the labels edit leaves it with,
+// or does nothing when edit reports no change. A failed recreate puts the old
+// container back.
+func (a *ContainerApp) relabelResource(ctx context.Context, identifier string, edit func(labels map[string]string) bool) (string, bool, error) {
+	inspect, err := a.containerService.InsepectContainer(ctx, identifier)
+	if err != nil {
+		return "", false, err
+	}
+	c := inspect.Container
+	name := firstContainerName([]string{c.Name})
+	if c.Config.Labels[composeProjectLabel] != "" {
+		return "", false, fmt.Errorf("resource %s belongs to stack %s and is grouped by it", name, c.Config.Labels[composeProjectLabel])
+	}
+	trashed, err := a.trashService.GetTrash(ctx, name)
+	if err != nil {
+		return "", false, err
+	}
+	if trashed != nil {
+		return "", false, fmt.Errorf("resource %s is in the trash", name)
+	}
+
+	labels := make(map[string]string, len(c.Config.Labels))
+	for k, v := range c.Config.Labels {
+		labels[k] = v
+	}
+	if !edit(labels) {
+		return name, false, nil
+	}
+	overrides := &domain.ContainerOverrides{Name: name, Labels: labels, KeepAnonymousVolumes: true}
+	for k := range c.Config.Labels {
+		if _, ok := labels[k]; !ok {
+			overrides.RemoveLabels = append(overrides.RemoveLabels, k)
+		}
+	}
+
+	// Hold the reconciler off so it does not act on the stopped or renamed
+	// container meanwhile.
+	a.reconciler.mu.Lock()
+	defer a.reconciler.mu.Unlock()
+
+	running := c.State != nil && c.State.Running
+	createdID, err := a.replaceContainer(ctx, c.ID, name, running, func() (string, error) {
+		created, err := a.containerService.CreateContainerFrom(ctx, inspect, overrides)
+		if err != nil {
+			return "", fmt.Errorf("failed to recreate %s: %w", name, err)
+		}
+		if running {
+			return created.ID, a.containerService.StartContainer(ctx, created.ID)
+		}
+		return created.ID, nil
+	})
+	if err != nil {
+		return "", false, err
+	}
+
+	desired, err := a.reconcileService.GetDesiredState(ctx, name)
+	if err != nil {
+		return "", false, err
+	}
+	if desired != nil {
+		recreated, err := a.containerService.InsepectContainer(ctx, createdID)
+		if err != nil {
+			return "", false, err
+		}
+		if err := a.trackResource(ctx, recreated, desired.State, true); err != nil {
+			return "", false, err
+		}
+	}
+	return name, true, nil
+}
+
+// GroupAction runs a bulk action on every member of a group. Deleting a
+// group moves its members to the trash.
+func (a *ContainerApp) GroupAction(ctx context.Context, name, action string) (*domain.BulkReport, error) {
+	group, err := a.GetGroup(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	req := domain.BulkRequest{IDs: make([]string, 0, len(group.Members))}
+	for _, member := range group.Members {
+		req.IDs = append(req.IDs, member.ID)
+	}
+	report, err := a.Bulk(ctx, action, req)
+	if err != nil {
+		return nil, err
+	}
+	a.publishEvent(eventGroup, name, "%s: %d succeeded, %d failed", action, report.Succeeded, report.Failed)
+	return report, nil
+}
+
+// GetGroupLogs merges the logs of the members of a group by time, keeping the
+// last tail lines. Each line is prefixed with the member it came from.
+func (a *ContainerApp) GetGroupLogs(ctx context.Context, name string, tail int) (string, error) {
+	group, err := a.GetGroup(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	if tail <= 0 {
+		tail = 200
+	}
+
+	type logLine struct {
+		at   time.Time
+		text string
+	}
+	lines := make([]logLine, 0)
+	for _, member := range group.Members {
+		logs, err := a.containerService.GetContainerLogs(ctx, member.ID, tail)
+		if err != nil {
+			return "", err
+		}
+		for _, line := range strings.Split(strings.TrimRight(logs, "\n"), "\n") {
+			if line == "" {
+				continue
+			}
+			stamp, _, _ := strings.Cut(line, " ")
+			at, _ := time.Parse(time.RFC3339Nano, stamp)
+			lines = append(lines, logLine{at: at, text: member.Name + " | " + line})
+		}
+	}
+	sort.SliceStable(lines, func(i, j int) bool { return lines[i].at.Before(lines[j].at) })
+	if len(lines) > tail {
+		lines = lines[len(lines)-tail:]
+	}
+
+	var b strings.Builder
+	for _, line := range lines {
+		b.WriteString(line.text + "\n")
+	}
+	return b.String(), nil
+}
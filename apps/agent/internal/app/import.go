@@ -0,0 +1,156 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/config"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/compose"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+)
+
+var defaultComposeFiles = []string{"compose.yaml", "compose.yml", "docker-compose.yaml", "docker-compose.yml"}
+
+func (a *ContainerApp) ImportStack(ctx context.Context, req domain.StackImport) (*domain.StackApplyResult, error) {
+	dir, err := allowedImportDir(req.Path)
+	if err != nil {
+		return nil, err
+	}
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		name = filepath.Base(dir)
+	}
+
+	source, err := readStackDir(dir, req.Files, req.Profiles, req.EnvFile)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.containerService.PingDaemon(ctx); err != nil {
+		return nil, err
+	}
+	return a.applyStack(ctx, name, *source, "imported from "+dir)
+}
+
+// ReloadStack re-reads an imported stack's files from its source directory
+// and converges the running stack to them.
+func (a *ContainerApp) ReloadStack(ctx context.Context, project string) (*domain.StackApplyResult, error) {
+	stack, err := a.GetStackDefinition(ctx, project)
+	if err != nil {
+		return nil, err
+	}
+	current := stack.Current()
+	if current.Dir == "" {
+		return nil, fmt.Errorf("stack %s was not imported from a directory", project)
+	}
+	dir, err := allowedImportDir(current.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	source, err := readStackDir(dir, current.Files, current.Profiles, current.EnvFileName)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.containerService.PingDaemon(ctx); err != nil {
+		return nil, err
+	}
+	return a.applyStack(ctx, stack.Name, *source, "reloaded from "+dir)
+}
+
+func allowedImportDir(path string) (string, error) {
+	if !filepath.IsAbs(path) {
+		return "", fmt.Errorf("stack path %q must be absolute", path)
+	}
+	dir, err := filepath.EvalSymlinks(filepath.Clean(path))
+	if err != nil {
+		return "", err
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		return "", err
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("stack path %s is not a directory", dir)
+	}
+
+	for _, root := range config.ImportAllowlist() {
+		root, err := filepath.EvalSymlinks(filepath.Clean(root))
+		if err != nil {
+			continue
+		}
+		rel, err := filepath.Rel(root, dir)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return dir, nil
+		}
+	}
+	return "", fmt.Errorf("stack path %s is outside the import allowlist", dir)
+}
+
+// readStackDir reads compose files the way docker compose picks them: the
+// given files in order, or the first default file plus its override. A
+// missing default .env is fine, an explicitly named env file is not.
+func readStackDir(dir string, files, profiles []string, envFile string) (*domain.StackSource, error) {
+	if len(files) == 0 {
+		base := ""
+		for _, candidate := range defaultComposeFiles {
+			if _, err := os.Stat(filepath.Join(dir, candidate)); err == nil {
+				base = candidate
+				break
+			}
+		}
+		if base == "" {
+			return nil, fmt.Errorf("no compose file found in %s", dir)
+		}
+		files = []string{base}
+		ext := filepath.Ext(base)
+		override := strings.TrimSuffix(base, ext) + ".override" + ext
+		if _, err := os.Stat(filepath.Join(dir, override)); err == nil {
+			files = append(files, override)
+		}
+	}
+
+	source := &domain.StackSource{Dir: dir, Files: files, Profiles: profiles, EnvFileName: envFile}
+	for i, file := range files {
+		path, err := stackDirFile(dir, file)
+		if err != nil {
+			return nil, err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			source.Compose = string(content)
+		} else {
+			source.Overrides = append(source.Overrides, string(content))
+		}
+	}
+
+	name := envFile
+	if name == "" {
+		name = ".env"
+	}
+	var content []byte
+	path, err := stackDirFile(dir, name)
+	if err == nil {
+		content, err = os.ReadFile(path)
+	}
+	if err != nil && !(envFile == "" && errors.Is(err, os.ErrNotExist)) {
+		return nil, err
+	}
+	source.EnvFile = string(content)
+	return source, nil
+}
+
+// stackDirFile resolves a file named relative to the stack directory and
+// makes sure it does not lead out of it, through ".." or a symlink.
+func stackDirFile(dir, name string) (string, error) {
+	if filepath.IsAbs(name) || strings.HasPrefix(filepath.Clean(name), "..") {
+		return "", fmt.Errorf("file %q must be relative to the stack directory", name)
+	}
+	return compose.ProjectPath(dir, name)
+}
@@ -0,0 +1,105 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadStackDir(t *testing.T) {
+	tests := []struct {
+		name          string
+		files         map[string]string
+		requested     []string
+		envFile       string
+		wantFiles     []string
+		wantOverrides int
+		wantEnv       string
+		wantErr       string
+	}{
+		{
+			name:      "default file",
+			files:     map[string]string{"docker-compose.yml": "name: a\n"},
+			wantFiles: []string{"docker-compose.yml"},
+		},
+		{
+			name:          "default file and override",
+			files:         map[string]string{"compose.yaml": "name: a\n", "compose.override.yaml": "x-a: 1\n", ".env": "TAG=1\n"},
+			wantFiles:     []string{"compose.yaml", "compose.override.yaml"},
+			wantOverrides: 1,
+			wantEnv:       "TAG=1\n",
+		},
+		{
+			name:      "compose.yaml wins over docker-compose.yml",
+			files:     map[string]string{"compose.yaml": "name: a\n", "docker-compose.yml": "name: b\n"},
+			wantFiles: []string{"compose.yaml"},
+		},
+		{
+			name:          "explicit files and env file",
+			files:         map[string]string{"base.yml": "name: a\n", "dev/extra.yml": "x-a: 1\n", "dev.env": "TAG=2\n"},
+			requested:     []string{"base.yml", "dev/extra.yml"},
+			envFile:       "dev.env",
+			wantFiles:     []string{"base.yml", "dev/extra.yml"},
+			wantOverrides: 1,
+			wantEnv:       "TAG=2\n",
+		},
+		{name: "no compose file", files: map[string]string{"README.md": ""}, wantErr: "no compose file found"},
+		{name: "missing explicit env file", files: map[string]string{"compose.yaml": "name: a\n"}, envFile: "dev.env", wantErr: "no such file or directory"},
+		{name: "absolute file", files: map[string]string{"compose.yaml": ""}, requested: []string{"/etc/hosts"}, wantErr: "must be relative to the stack directory"},
+		{name: "parent file", files: map[string]string{"compose.yaml": ""}, requested: []string{"../compose.yaml"}, wantErr: "must be relative to the stack directory"},
+		{name: "parent env file", files: map[string]string{"compose.yaml": ""}, envFile: "sub/../../x.env", wantErr: "must be relative to the stack directory"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				path := filepath.Join(dir, name)
+				if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			source, err := readStackDir(dir, tt.requested, nil, tt.envFile)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("readStackDir() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("readStackDir() error = %v", err)
+			}
+			if !reflect.DeepEqual(source.Files, tt.wantFiles) {
+				t.Errorf("Files = %v, want %v", source.Files, tt.wantFiles)
+			}
+			if len(source.Overrides) != tt.wantOverrides {
+				t.Errorf("got %d overrides, want %d", len(source.Overrides), tt.wantOverrides)
+			}
+			if source.EnvFile != tt.wantEnv {
+				t.Errorf("EnvFile = %q, want %q", source.EnvFile, tt.wantEnv)
+			}
+		})
+	}
+}
+
+func TestReadStackDirRejectsSymlinkOut(t *testing.T) {
+	dir := t.TempDir()
+	outside := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "compose.yaml"), []byte("name: a\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(outside, "secret.env"), []byte("TOKEN=x\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(outside, "secret.env"), filepath.Join(dir, ".env")); err != nil {
+		t.Fatal(err)
+	}
+	_, err := readStackDir(dir, nil, nil, "")
+	if err == nil || !strings.Contains(err.Error(), "outside the project directory") {
+		t.Errorf("readStackDir() error = %v, want the env file rejected", err)
+	}
+}
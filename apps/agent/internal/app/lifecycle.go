@@ -0,0 +1,357 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/config"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+	dockerclient "github.com/moby/moby/client"
+)
+
+const lifecycleInterval = 30 * time.Second
+
+// A resource is idle while it uses less than idleCPUPercent of the host CPU
+// and moves fewer than idleNetworkBytes between two checks.
+const (
+	idleCPUPercent   = 1.0
+	idleNetworkBytes = 4096
+)
+
+const (
+	eventLifecycleUpdated = "lifecycle_updated"
+	eventExpiryWarning    = "expiry_warning"
+	eventExpired          = "expired"
+	eventIdleWarning      = "idle_warning"
+	eventIdleStopped      = "idle_stopped"
+)
+
+type lifecycleMonitor struct {
+	mu      sync.Mutex
+	samples map[string]domain.ContainerStats
+}
+
+// SetLifecycle sets or clears the TTL and idle timeout of a resource.
+func (a *ContainerApp) SetLifecycle(ctx context.Context, identifier string, req domain.LifecycleRequest) (*domain.Lifecycle, error) {
+	name, err := a.lifecycleResource(ctx, identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	a.lifecycle.mu.Lock()
+	defer a.lifecycle.mu.Unlock()
+	lifecycle, err := a.lifecycleService.GetLifecycle(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if lifecycle == nil {
+		lifecycle = &domain.Lifecycle{Resource: name}
+	}
+	if err := applyLifecycleRequest(lifecycle, req, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := a.saveLifecycle(ctx, lifecycle); err != nil {
+		return nil, err
+	}
+	a.publishEvent(eventLifecycleUpdated, name, "%s", describeTimers(*lifecycle))
+	return lifecycle, nil
+}
+
+// ExtendLifecycle pushes the expiry and the idle stop of a resource back.
+func (a *ContainerApp) ExtendLifecycle(ctx context.Context, identifier, by string) (*domain.Lifecycle, error) {
+	d, err := time.ParseDuration(by)
+	if err != nil || d <= 0 {
+		return nil, fmt.Errorf("invalid duration %q", by)
+	}
+	name, err := a.lifecycleResource(ctx, identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	a.lifecycle.mu.Lock()
+	defer a.lifecycle.mu.Unlock()
+	lifecycle, err := a.lifecycleService.GetLifecycle(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if lifecycle == nil {
+		return nil, fmt.Errorf("resource %s has no TTL or idle timeout", name)
+	}
+	now := time.Now().Unix()
+	if lifecycle.ExpiresAt != 0 {
+		lifecycle.ExpiresAt = max(lifecycle.ExpiresAt, now) + int64(d.Seconds())
+		lifecycle.ExpiryWarned = false
+	}
+	if lifecycle.IdleTimeout != 0 {
+		stopAt := max(lifecycle.LastActiveAt+lifecycle.IdleTimeout, now) + int64(d.Seconds())
+		lifecycle.LastActiveAt = stopAt - lifecycle.IdleTimeout
+		lifecycle.IdleWarned = false
+	}
+	if err := a.saveLifecycle(ctx, lifecycle); err != nil {
+		return nil, err
+	}
+	a.publishEvent(eventLifecycleUpdated, name, "extended by %s: %s", d, describeTimers(*lifecycle))
+	return lifecycle, nil
+}
+
+// newLifecycleRequest checks the timers asked for with a new resource before
+// it is created. It is nil when none were asked for.
+func newLifecycleRequest(ttl, action, idle string) (*domain.LifecycleRequest, error) {
+	req := domain.LifecycleRequest{TTL: ttl, TTLAction: action, IdleTimeout: idle}
+	if req == (domain.LifecycleRequest{}) {
+		return nil, nil
+	}
+	if err := applyLifecycleRequest(&domain.Lifecycle{}, req, time.Now()); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+func (a *ContainerApp) lifecycleResource(ctx context.Context, identifier string) (string, error) {
+	inspect, err := a.containerService.InsepectContainer(ctx, identifier)
+	if err != nil {
+		return "", err
+	}
+	if inspect.Container.Config.Labels[composeProjectLabel] != "" {
+		return "", fmt.Errorf("resource %s belongs to a stack", identifier)
+	}
+	return firstContainerName([]string{inspect.Container.Name}), nil
+}
+
+// saveLifecycle drops the record once no timer is left.
+func (a *ContainerApp) saveLifecycle(ctx context.Context, lifecycle *domain.Lifecycle) error {
+	lifecycle.UpdatedAt = time.Now().Unix()
+	if lifecycle.ExpiresAt == 0 && lifecycle.IdleTimeout == 0 {
+		return a.lifecycleService.DeleteLifecycle(ctx, lifecycle.Resource)
+	}
+	return a.lifecycleService.SaveLifecycle(ctx, lifecycle)
+}
+
+func applyLifecycleRequest(lifecycle *domain.Lifecycle, req domain.LifecycleRequest, now time.Time) error {
+	switch req.TTLAction {
+	case "", domain.ExpiryStop, domain.ExpiryDelete:
+	default:
+		return fmt.Errorf("invalid ttl action %q: use stop or delete", req.TTLAction)
+	}
+	ttl, err := parseTimer(req.TTL)
+	if err != nil {
+		return err
+	}
+	idle, err := parseTimer(req.IdleTimeout)
+	if err != nil {
+		return err
+	}
+
+	if req.TTL != "" {
+		lifecycle.ExpiresAt = 0
+		if ttl > 0 {
+			lifecycle.ExpiresAt = now.Add(ttl).Unix()
+		}
+		lifecycle.ExpiryWarned = false
+	}
+	if req.TTLAction != "" {
+		lifecycle.ExpiryAction = req.TTLAction
+	}
+	if lifecycle.ExpiresAt == 0 {
+		lifecycle.ExpiryAction = ""
+	} else if lifecycle.ExpiryAction == "" {
+		lifecycle.ExpiryAction = domain.ExpiryDelete
+	}
+	if req.IdleTimeout != "" {
+		lifecycle.IdleTimeout = int64(idle.Seconds())
+		lifecycle.LastActiveAt = now.Unix()
+		lifecycle.IdleWarned = false
+	}
+	return nil
+}
+
+// parseTimer reads a timer duration; "0" clears the timer.
+func parseTimer(value string) (time.Duration, error) {
+	if value == "" || value == "0" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < time.Second {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	return d, nil
+}
+
+func lifecycleTimers(lifecycle domain.Lifecycle) *domain.ResourceTimers {
+	timers := &domain.ResourceTimers{
+		ExpiresAt:    lifecycle.ExpiresAt,
+		ExpiryAction: lifecycle.ExpiryAction,
+		IdleTimeout:  lifecycle.IdleTimeout,
+	}
+	if lifecycle.IdleTimeout != 0 {
+		timers.IdleStopAt = lifecycle.LastActiveAt + lifecycle.IdleTimeout
+	}
+	return timers
+}
+
+func describeTimers(lifecycle domain.Lifecycle) string {
+	switch {
+	case lifecycle.ExpiresAt != 0 && lifecycle.IdleTimeout != 0:
+		return fmt.Sprintf("%s at %s, idle timeout %s", lifecycle.ExpiryAction, formatUnix(lifecycle.ExpiresAt), time.Duration(lifecycle.IdleTimeout)*time.Second)
+	case lifecycle.ExpiresAt != 0:
+		return fmt.Sprintf("%s at %s", lifecycle.ExpiryAction, formatUnix(lifecycle.ExpiresAt))
+	case lifecycle.IdleTimeout != 0:
+		return fmt.Sprintf("idle timeout %s", time.Duration(lifecycle.IdleTimeout)*time.Second)
+	}
+	return "timers cleared"
+}
+
+func formatUnix(t int64) string {
+	return time.Unix(t, 0).Format(time.RFC3339)
+}
+
+// StartLifecycleMonitor fires TTLs and idle stops, warning ahead of both.
+func (a *ContainerApp) StartLifecycleMonitor() {
+	go func() {
+		ticker := time.NewTicker(lifecycleInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			ctx := context.Background()
+			lifecycles, err := a.lifecycleService.ListLifecycles(ctx)
+			if err != nil {
+				log.Println("lifecycle:", err)
+				continue
+			}
+			for _, lifecycle := range lifecycles {
+				if err := a.checkLifecycle(ctx, lifecycle.Resource); err != nil {
+					log.Printf("lifecycle %s: %v", lifecycle.Resource, err)
+				}
+			}
+		}
+	}()
+}
+
+func (a *ContainerApp) checkLifecycle(ctx context.Context, resource string) error {
+	a.lifecycle.mu.Lock()
+	defer a.lifecycle.mu.Unlock()
+
+	// Re-read under the lock so an extension made meanwhile wins.
+	lifecycle, err := a.lifecycleService.GetLifecycle(ctx, resource)
+	if err != nil || lifecycle == nil {
+		return err
+	}
+	inspect, found, err := a.inspectByName(ctx, resource)
+	if err != nil {
+		return err
+	}
+	if !found {
+		delete(a.lifecycle.samples, resource)
+		return a.lifecycleService.DeleteLifecycle(ctx, resource)
+	}
+	now := time.Now()
+	warning := config.LifecycleWarning()
+	running := inspect.Container.State != nil && inspect.Container.State.Running && !inspect.Container.State.Paused
+
+	if lifecycle.ExpiresAt != 0 {
+		expires := time.Unix(lifecycle.ExpiresAt, 0)
+		switch {
+		case !now.Before(expires) && lifecycle.ExpiryAction == domain.ExpiryDelete:
+			if err := a.Delete(ctx, inspect.Container.ID); err != nil {
+				return err
+			}
+			delete(a.lifecycle.samples, resource)
+			a.publishEvent(eventExpired, resource, "TTL expired, resource moved to the trash")
+			return nil
+		case !now.Before(expires):
+			if running {
+				if err := a.Stop(ctx, inspect.Container.ID); err != nil {
+					return err
+				}
+				running = false
+			}
+			lifecycle.ExpiresAt = 0
+			lifecycle.ExpiryAction = ""
+			lifecycle.ExpiryWarned = false
+			a.publishEvent(eventExpired, resource, "TTL expired, resource stopped")
+		case !lifecycle.ExpiryWarned && now.Add(warning).After(expires):
+			lifecycle.ExpiryWarned = true
+			a.publishEvent(eventExpiryWarning, resource, "TTL expires in %s, resource will be %s", expires.Sub(now).Round(time.Second), expiryVerb(lifecycle.ExpiryAction))
+		}
+	}
+
+	if lifecycle.IdleTimeout != 0 {
+		if err := a.checkIdle(ctx, lifecycle, inspect, running, now, warning); err != nil {
+			return err
+		}
+	}
+	return a.saveLifecycle(ctx, lifecycle)
+}
+
+// checkIdle samples the resource and stops it once it has been idle for its
+// timeout. A stopped resource starts its idle timer over.
+func (a *ContainerApp) checkIdle(ctx context.Context, lifecycle *domain.Lifecycle, inspect dockerclient.ContainerInspectResult, running bool, now time.Time, warning time.Duration) error {
+	resource := lifecycle.Resource
+	if !running {
+		delete(a.lifecycle.samples, resource)
+		lifecycle.LastActiveAt = max(lifecycle.LastActiveAt, now.Unix())
+		lifecycle.IdleWarned = false
+		return nil
+	}
+
+	stats, err := a.containerService.ContainerStats(ctx, inspect.Container.ID)
+	if err != nil {
+		return err
+	}
+	if a.lifecycle.samples == nil {
+		a.lifecycle.samples = make(map[string]domain.ContainerStats)
+	}
+	previous, sampled := a.lifecycle.samples[resource]
+	a.lifecycle.samples[resource] = *stats
+	if sampled && active(previous, *stats) {
+		lifecycle.LastActiveAt = max(lifecycle.LastActiveAt, now.Unix())
+		lifecycle.IdleWarned = false
+		return nil
+	}
+
+	stopAt := time.Unix(lifecycle.LastActiveAt+lifecycle.IdleTimeout, 0)
+	switch {
+	case !now.Before(stopAt):
+		if err := a.Stop(ctx, inspect.Container.ID); err != nil {
+			return err
+		}
+		delete(a.lifecycle.samples, resource)
+		lifecycle.LastActiveAt = now.Unix()
+		lifecycle.IdleWarned = false
+		a.publishEvent(eventIdleStopped, resource, "stopped after %s without activity", time.Duration(lifecycle.IdleTimeout)*time.Second)
+	case !lifecycle.IdleWarned && now.Add(warning).After(stopAt):
+		lifecycle.IdleWarned = true
+		a.publishEvent(eventIdleWarning, resource, "idle, will be stopped in %s", stopAt.Sub(now).Round(time.Second))
+	}
+	return nil
+}
+
+// active compares two samples. Counters that went down mean the container
+// restarted in between, which counts as activity.
+func active(previous, current domain.ContainerStats) bool {
+	if current.CPUTotal < previous.CPUTotal || current.NetworkBytes < previous.NetworkBytes {
+		return true
+	}
+	if current.NetworkBytes-previous.NetworkBytes > idleNetworkBytes {
+		return true
+	}
+	if current.SystemCPU <= previous.SystemCPU {
+		return false
+	}
+	cpus := float64(max(current.OnlineCPUs, 1))
+	percent := float64(current.CPUTotal-previous.CPUTotal) / float64(current.SystemCPU-previous.SystemCPU) * cpus * 100
+	return percent > idleCPUPercent
+}
+
+func expiryVerb(action string) string {
+	if action == domain.ExpiryStop {
+		return "stopped"
+	}
+	return "deleted"
+}
+
+func (a *ContainerApp) ListLifecycles(ctx context.Context) ([]domain.Lifecycle, error) {
+	return a.lifecycleService.ListLifecycles(ctx)
+}
@@ -0,0 +1,89 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+)
+
+func TestParseTimer(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"0", 0, false},
+		{"90m", 90 * time.Minute, false},
+		{"1s", time.Second, false},
+		{"500ms", 0, true},
+		{"-1h", 0, true},
+		{"soon", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := parseTimer(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseTimer(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseTimer(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestApplyLifecycleRequest(t *testing.T) {
+	now := time.Unix(1_000_000, 0)
+	tests := []struct {
+		name    string
+		current domain.Lifecycle
+		req     domain.LifecycleRequest
+		want    domain.Lifecycle
+		wantErr bool
+	}{
+		{
+			name: "ttl defaults to delete",
+			req:  domain.LifecycleRequest{TTL: "1h"},
+			want: domain.Lifecycle{ExpiresAt: now.Unix() + 3600, ExpiryAction: domain.ExpiryDelete},
+		},
+		{
+			name: "ttl with stop",
+			req:  domain.LifecycleRequest{TTL: "1h", TTLAction: domain.ExpiryStop},
+			want: domain.Lifecycle{ExpiresAt: now.Unix() + 3600, ExpiryAction: domain.ExpiryStop},
+		},
+		{
+			name:    "zero ttl clears expiry",
+			current: domain.Lifecycle{ExpiresAt: 5, ExpiryAction: domain.ExpiryStop, ExpiryWarned: true},
+			req:     domain.LifecycleRequest{TTL: "0"},
+			want:    domain.Lifecycle{},
+		},
+		{
+			name:    "idle timeout resets activity",
+			current: domain.Lifecycle{ExpiresAt: 5, ExpiryAction: domain.ExpiryStop, IdleWarned: true},
+			req:     domain.LifecycleRequest{IdleTimeout: "30m"},
+			want:    domain.Lifecycle{ExpiresAt: 5, ExpiryAction: domain.ExpiryStop, IdleTimeout: 1800, LastActiveAt: now.Unix()},
+		},
+		{
+			name:    "unknown action",
+			req:     domain.LifecycleRequest{TTL: "1h", TTLAction: "pause"},
+			wantErr: true,
+		},
+		{
+			name:    "bad idle timeout",
+			req:     domain.LifecycleRequest{IdleTimeout: "later"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		got := tt.current
+		err := applyLifecycleRequest(&got, tt.req, now)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("%s: got %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
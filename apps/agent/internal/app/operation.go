@@ -0,0 +1,282 @@
+package app
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/config"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+)
+
+const eventOperation = "operation"
+
+// operationLimit bounds how many finished operations are kept, whatever their
+// age.
+const operationLimit = 200
+
+var phaseProgress = map[string]int{
+	domain.PhaseQueued:   0,
+	domain.PhasePulling:  10,
+	domain.PhaseCreating: 40,
+	domain.PhaseStarting: 60,
+	domain.PhaseWaiting:  80,
+	domain.PhaseDone:     100,
+}
+
+type operationKey struct{}
+
+type operationTable struct {
+	mu         sync.Mutex
+	operations map[string]*trackedOperation
+}
+
+// trackedOperation is an operation with what is needed to cancel it and to
+// wake its watchers. A watcher channel holds at most one pending signal, so
+// a slow watcher skips intermediate states but always sees the latest one.
+type trackedOperation struct {
+	op       domain.Operation
+	cancel   context.CancelFunc
+	watchers map[chan struct{}]struct{}
+}
+
+// RunOperation runs fn in the background and returns the operation tracking
+// it. fn's context is cancelled by CancelOperation and carries the operation
+// so the phases fn goes through are recorded.
+func (a *ContainerApp) RunOperation(kind, target string, fn func(ctx context.Context) (any, error)) domain.Operation {
+	ctx, cancel := context.WithCancel(context.Background())
+	now := time.Now().Unix()
+	tracked := &trackedOperation{
+		op: domain.Operation{
+			ID:        newOperationID(),
+			Kind:      kind,
+			Target:    target,
+			Status:    domain.OperationRunning,
+			Phase:     domain.PhaseQueued,
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+		cancel:   cancel,
+		watchers: make(map[chan struct{}]struct{}),
+	}
+
+	a.operations.mu.Lock()
+	if a.operations.operations == nil {
+		a.operations.operations = make(map[string]*trackedOperation)
+	}
+	a.pruneOperations()
+	a.operations.operations[tracked.op.ID] = tracked
+	op := tracked.op
+	a.operations.mu.Unlock()
+
+	go func() {
+		defer cancel()
+		result, err := fn(context.WithValue(ctx, operationKey{}, op.ID))
+		a.finishOperation(op.ID, result, err, ctx.Err() != nil)
+	}()
+	return op
+}
+
+// operationPhase records the phase reached by the operation ctx belongs to.
+// Outside an operation it does nothing.
+func (a *ContainerApp) operationPhase(ctx context.Context, phase, format string, args ...any) {
+	id, ok := ctx.Value(operationKey{}).(string)
+	if !ok {
+		return
+	}
+	a.updateOperation(id, func(op *domain.Operation) {
+		op.Phase = phase
+		op.Message = fmt.Sprintf(format, args...)
+		// Phases repeat for every service of a stack; progress never goes back.
+		op.Progress = max(op.Progress, phaseProgress[phase])
+	})
+}
+
+func (a *ContainerApp) finishOperation(id string, result any, err error, canceled bool) {
+	var op domain.Operation
+	a.updateOperation(id, func(o *domain.Operation) {
+		o.FinishedAt = time.Now().Unix()
+		switch {
+		case canceled:
+			o.Status = domain.OperationCanceled
+			o.Error = "operation canceled"
+		case err != nil:
+			o.Status = domain.OperationFailed
+			o.Error = err.Error()
+			o.Result = result
+		default:
+			o.Status = domain.OperationSucceeded
+			o.Phase = domain.PhaseDone
+			o.Progress = phaseProgress[domain.PhaseDone]
+			o.Message = ""
+			o.Result = result
+		}
+		op = *o
+	})
+
+	a.operations.mu.Lock()
+	if tracked, ok := a.operations.operations[id]; ok {
+		for ch := range tracked.watchers {
+			close(ch)
+		}
+		tracked.watchers = nil
+	}
+	a.operations.mu.Unlock()
+
+	if op.Error != "" {
+		a.publishEvent(eventOperation, op.Target, "%s %s: %s", op.Kind, op.Status, op.Error)
+	} else {
+		a.publishEvent(eventOperation, op.Target, "%s %s", op.Kind, op.Status)
+	}
+}
+
+func (a *ContainerApp) updateOperation(id string, update func(op *domain.Operation)) {
+	a.operations.mu.Lock()
+	defer a.operations.mu.Unlock()
+	tracked, ok := a.operations.operations[id]
+	if !ok {
+		return
+	}
+	update(&tracked.op)
+	tracked.op.UpdatedAt = time.Now().Unix()
+	for ch := range tracked.watchers {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (a *ContainerApp) ListOperations() []domain.Operation {
+	a.operations.mu.Lock()
+	defer a.operations.mu.Unlock()
+	a.pruneOperations()
+	operations := make([]domain.Operation, 0, len(a.operations.operations))
+	for _, tracked := range a.operations.operations {
+		operations = append(operations, tracked.op)
+	}
+	sort.Slice(operations, func(i, j int) bool {
+		if operations[i].CreatedAt != operations[j].CreatedAt {
+			return operations[i].CreatedAt > operations[j].CreatedAt
+		}
+		return operations[i].ID < operations[j].ID
+	})
+	return operations
+}
+
+func (a *ContainerApp) GetOperation(id string) (*domain.Operation, error) {
+	a.operations.mu.Lock()
+	defer a.operations.mu.Unlock()
+	tracked, ok := a.operations.operations[id]
+	if !ok {
+		return nil, fmt.Errorf("operation %s not found", id)
+	}
+	op := tracked.op
+	return &op, nil
+}
+
+// CancelOperation stops a running operation. Work already done, such as a
+// pulled image or a created container, is kept.
+func (a *ContainerApp) CancelOperation(id string) (*domain.Operation, error) {
+	op, err := a.GetOperation(id)
+	if err != nil {
+		return nil, err
+	}
+	if op.FinishedAt != 0 {
+		return nil, errors.New("operation already finished")
+	}
+	a.operations.mu.Lock()
+	tracked, ok := a.operations.operations[id]
+	a.operations.mu.Unlock()
+	if ok {
+		tracked.cancel()
+	}
+	return op, nil
+}
+
+// WatchOperation delivers the operation now and after every change until it
+// finishes or ctx is done, then closes the channel.
+func (a *ContainerApp) WatchOperation(ctx context.Context, id string) (<-chan domain.Operation, error) {
+	a.operations.mu.Lock()
+	tracked, ok := a.operations.operations[id]
+	if !ok {
+		a.operations.mu.Unlock()
+		return nil, fmt.Errorf("operation %s not found", id)
+	}
+	signal := make(chan struct{}, 1)
+	first := tracked.op
+	if tracked.watchers != nil {
+		tracked.watchers[signal] = struct{}{}
+	} else {
+		close(signal)
+	}
+	a.operations.mu.Unlock()
+
+	updates := make(chan domain.Operation)
+	go func() {
+		defer close(updates)
+		defer func() {
+			a.operations.mu.Lock()
+			delete(tracked.watchers, signal)
+			a.operations.mu.Unlock()
+		}()
+		op := &first
+		for {
+			select {
+			case updates <- *op:
+			case <-ctx.Done():
+				return
+			}
+			if op.FinishedAt != 0 {
+				return
+			}
+			select {
+			case <-signal:
+			case <-ctx.Done():
+				return
+			}
+			var err error
+			if op, err = a.GetOperation(id); err != nil {
+				return
+			}
+		}
+	}()
+	return updates, nil
+}
+
+// pruneOperations drops finished operations past the retention and then the
+// oldest ones beyond operationLimit. The caller holds the lock.
+func (a *ContainerApp) pruneOperations() {
+	cutoff := time.Now().Add(-config.OperationRetention()).Unix()
+	finished := make([]domain.Operation, 0)
+	for id, tracked := range a.operations.operations {
+		if tracked.op.FinishedAt == 0 {
+			continue
+		}
+		if tracked.op.FinishedAt < cutoff {
+			delete(a.operations.operations, id)
+			continue
+		}
+		finished = append(finished, tracked.op)
+	}
+	if len(finished) <= operationLimit {
+		return
+	}
+	sort.Slice(finished, func(i, j int) bool { return finished[i].FinishedAt < finished[j].FinishedAt })
+	for _, op := range finished[:len(finished)-operationLimit] {
+		delete(a.operations.operations, op.ID)
+	}
+}
+
+func newOperationID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("op-%d", time.Now().UnixNano())
+	}
+	return "op-" + hex.EncodeToString(b)
+}
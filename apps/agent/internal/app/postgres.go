@@ -0,0 +1,182 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/connection"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/postgres"
+)
+
+type postgresTarget struct {
+	id       string
+	username string
+	password string
+	database string
+}
+
+func (a *ContainerApp) ListPostgresDatabases(ctx context.Context, identifier string) ([]domain.PostgresDatabase, error) {
+	databases := make([]domain.PostgresDatabase, 0)
+	return databases, a.postgresJSON(ctx, identifier, "", postgres.ListDatabasesSQL, &databases)
+}
+
+func (a *ContainerApp) CreatePostgresDatabase(ctx context.Context, identifier string, req domain.PostgresDatabaseRequest) error {
+	stmt, err := postgres.CreateDatabaseSQL(req)
+	if err != nil {
+		return err
+	}
+	return a.postgresExec(ctx, identifier, stmt)
+}
+
+func (a *ContainerApp) DropPostgresDatabase(ctx context.Context, identifier string, name string, force bool) error {
+	stmt, err := postgres.DropDatabaseSQL(name, force)
+	if err != nil {
+		return err
+	}
+	return a.postgresExec(ctx, identifier, stmt)
+}
+
+func (a *ContainerApp) ListPostgresRoles(ctx context.Context, identifier string) ([]domain.PostgresRole, error) {
+	roles := make([]domain.PostgresRole, 0)
+	return roles, a.postgresJSON(ctx, identifier, "", postgres.ListRolesSQL, &roles)
+}
+
+func (a *ContainerApp) CreatePostgresRole(ctx context.Context, identifier string, req domain.PostgresRoleRequest) error {
+	stmt, err := postgres.CreateRoleSQL(req)
+	if err != nil {
+		return err
+	}
+	return a.postgresExec(ctx, identifier, stmt)
+}
+
+func (a *ContainerApp) DropPostgresRole(ctx context.Context, identifier string, name string) error {
+	stmt, err := postgres.DropRoleSQL(name)
+	if err != nil {
+		return err
+	}
+	return a.postgresExec(ctx, identifier, stmt)
+}
+
+func (a *ContainerApp) PostgresConnections(ctx context.Context, identifier string) ([]domain.PostgresConnection, error) {
+	connections := make([]domain.PostgresConnection, 0)
+	return connections, a.postgresJSON(ctx, identifier, "", postgres.ConnectionsSQL, &connections)
+}
+
+func (a *ContainerApp) PostgresTableSizes(ctx context.Context, identifier string, database string) ([]domain.PostgresTableSize, error) {
+	tables := make([]domain.PostgresTableSize, 0)
+	return tables, a.postgresJSON(ctx, identifier, database, postgres.TableSizesSQL, &tables)
+}
+
+func (a *ContainerApp) PostgresQuery(ctx context.Context, identifier string, req domain.PostgresQueryRequest) (*domain.QueryResult, error) {
+	if strings.TrimSpace(req.SQL) == "" {
+		return nil, fmt.Errorf("sql cannot be empty")
+	}
+	target, err := a.postgresTarget(ctx, identifier)
+	if err != nil {
+		return nil, err
+	}
+	out, err := a.runPsql(ctx, target, req.Database, req.SQL, true)
+	if err != nil {
+		return nil, err
+	}
+	result := postgres.ParseTable(out)
+	return &result, nil
+}
+
+func (a *ContainerApp) postgresJSON(ctx context.Context, identifier, database, query string, v any) error {
+	target, err := a.postgresTarget(ctx, identifier)
+	if err != nil {
+		return err
+	}
+	out, err := a.runPsql(ctx, target, database, postgres.JSONSQL(query), false)
+	if err != nil {
+		return err
+	}
+	return postgres.DecodeJSON(out, v)
+}
+
+func (a *ContainerApp) postgresExec(ctx context.Context, identifier, stmt string) error {
+	target, err := a.postgresTarget(ctx, identifier)
+	if err != nil {
+		return err
+	}
+	_, err = a.runPsql(ctx, target, "", stmt, false)
+	return err
+}
+
+// runPsql execs psql inside the container. Admin statements default to the
+// maintenance database so dropping the configured database still works.
+func (a *ContainerApp) runPsql(ctx context.Context, target *postgresTarget, database, sql string, tabular bool) ([]byte, error) {
+	if database == "" {
+		database = target.database
+	}
+	spec := domain.ExecSpec{Cmd: postgres.Command(target.username, database, sql, tabular)}
+	if target.password != "" {
+		spec.Env = []string{"PGPASSWORD=" + target.password}
+	}
+	result, err := a.containerService.ExecContainer(ctx, target.id, spec)
+	if err != nil {
+		return nil, err
+	}
+	if result.ExitCode != 0 {
+		return nil, postgres.Error(result.ExitCode, result.Stderr)
+	}
+	return result.Stdout, nil
+}
+
+func (a *ContainerApp) postgresTarget(ctx context.Context, identifier string) (*postgresTarget, error) {
+	id, env, err := a.typedResource(ctx, identifier, "postgres")
+	if err != nil {
+		return nil, err
+	}
+	creds, _ := connection.CredentialsFor("postgres", env)
+	return &postgresTarget{id: id, username: creds.Username, password: creds.Password, database: "postgres"}, nil
+}
+
+type runningResource struct {
+	id           string
+	name         string
+	resourceType string
+	env          map[string]string
+}
+
+// typedResource resolves a running resource of the given type and returns its
+// ID and environment.
+func (a *ContainerApp) typedResource(ctx context.Context, identifier, resourceType string) (string, map[string]string, error) {
+	res, err := a.resolveRunning(ctx, identifier)
+	if err != nil {
+		return "", nil, err
+	}
+	if res.resourceType != resourceType {
+		return "", nil, fmt.Errorf("resource %s is %s, not %s", identifier, res.resourceType, resourceType)
+	}
+	return res.id, res.env, nil
+}
+
+func (a *ContainerApp) resolveRunning(ctx context.Context, identifier string) (*runningResource, error) {
+	if identifier == "" {
+		return nil, fmt.Errorf("container id cannot be empty")
+	}
+	summary, err := a.containerService.FindContainer(ctx, identifier)
+	if err != nil {
+		return nil, err
+	}
+	if summary.ID == "" {
+		return nil, fmt.Errorf("resource %s not found", identifier)
+	}
+	if summary.State != "running" {
+		return nil, fmt.Errorf("resource %s is not running", identifier)
+	}
+	inspect, err := a.containerService.InsepectContainer(ctx, summary.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &runningResource{
+		id:           summary.ID,
+		name:         firstContainerName(summary.Names),
+		resourceType: a.detectType(summary),
+		env:          envMap(inspect.Container.Config.Env),
+	}, nil
+}
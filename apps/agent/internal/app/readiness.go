@@ -0,0 +1,185 @@
+package app
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/deps"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/readiness"
+	dockerclient "github.com/moby/moby/client"
+)
+
+const readinessLabel = "devcon.readiness"
+
+// readinessLogTail bounds how much of the log a log probe reads per attempt.
+const readinessLogTail = 1000
+
+// readinessLabels validates the probe a new resource declares and stores it
+// in labels.
+func readinessLabels(probe *domain.ReadinessProbe, labels map[string]string) error {
+	if probe == nil {
+		return nil
+	}
+	normalized, err := readiness.Normalize(*probe)
+	if err != nil {
+		return err
+	}
+	labels[readinessLabel] = readiness.Encode(normalized)
+	return nil
+}
+
+// readinessProbe is the probe a container declares. Without one, its docker
+// healthcheck is used, then its lowest exposed tcp port, and otherwise it is
+// ready as soon as it runs.
+func readinessProbe(inspect dockerclient.ContainerInspectResult) domain.ReadinessProbe {
+	c := inspect.Container
+	if c.Config != nil {
+		if probe, err := readiness.Decode(c.Config.Labels[readinessLabel]); err == nil && probe != nil {
+			return *probe
+		}
+		if hc := c.Config.Healthcheck; hc != nil && len(hc.Test) > 0 && hc.Test[0] != "NONE" {
+			return domain.ReadinessProbe{Type: readiness.ProbeHealth}
+		}
+	}
+	if _, err := dialAddress(inspect, ""); err == nil {
+		return domain.ReadinessProbe{Type: readiness.ProbePort}
+	}
+	return domain.ReadinessProbe{Type: readiness.ProbeRunning}
+}
+
+// WaitReady blocks until the resource passes its readiness probe or the probe
+// times out. begin is when the caller asked for the start and is only used
+// for the timing in the report. A probe that fails is reported, not returned
+// as an error.
+func (a *ContainerApp) WaitReady(ctx context.Context, identifier string, begin time.Time) (*domain.Readiness, error) {
+	inspect, err := a.containerService.InsepectContainer(ctx, identifier)
+	if err != nil {
+		return nil, err
+	}
+	probe := readinessProbe(inspect)
+	var pattern *regexp.Regexp
+	if probe.Type == readiness.ProbeLog {
+		if pattern, err = regexp.Compile(probe.Pattern); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &domain.Readiness{Probe: readiness.Describe(probe)}
+	a.operationPhase(ctx, domain.PhaseWaiting, "waiting for %s", result.Probe)
+	probeStart := time.Now()
+	timeout := readiness.Timeout(probe)
+	deadline := probeStart.Add(timeout)
+	for {
+		result.Attempts++
+		ready, err := a.probeReady(ctx, inspect, probe, pattern)
+		if err != nil {
+			result.Error = err.Error()
+			break
+		}
+		if ready {
+			result.Ready = true
+			break
+		}
+		if time.Now().After(deadline) {
+			result.Error = fmt.Sprintf("not ready after %s", timeout)
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(readiness.Interval(probe)):
+		}
+		if inspect, err = a.containerService.InsepectContainer(ctx, inspect.Container.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	done := time.Now()
+	result.StartMs = probeStart.Sub(begin).Milliseconds()
+	result.ReadyMs = done.Sub(probeStart).Milliseconds()
+	result.TotalMs = done.Sub(begin).Milliseconds()
+	return result, nil
+}
+
+func (a *ContainerApp) probeReady(ctx context.Context, inspect dockerclient.ContainerInspectResult, probe domain.ReadinessProbe, pattern *regexp.Regexp) (bool, error) {
+	running, err := conditionMet(inspect, deps.ConditionStarted, "")
+	if err != nil || !running {
+		return false, err
+	}
+	switch probe.Type {
+	case readiness.ProbeHealth:
+		return conditionMet(inspect, deps.ConditionHealthy, "")
+	case readiness.ProbePort:
+		return conditionMet(inspect, deps.ConditionPortOpen, probe.Port)
+	case readiness.ProbeHTTP:
+		return httpReady(ctx, inspect, probe)
+	case readiness.ProbeExec:
+		result, err := a.containerService.ExecContainer(ctx, inspect.Container.ID, domain.ExecSpec{Cmd: probe.Command})
+		// The exec itself can fail while the container is still starting.
+		return err == nil && result.ExitCode == 0, nil
+	case readiness.ProbeLog:
+		return a.logReady(ctx, inspect, pattern)
+	}
+	return true, nil
+}
+
+func httpReady(ctx context.Context, inspect dockerclient.ContainerInspectResult, probe domain.ReadinessProbe) (bool, error) {
+	address, err := dialAddress(inspect, probe.Port)
+	if err != nil {
+		return false, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+address+probe.Path, nil)
+	if err != nil {
+		return false, err
+	}
+	client := &http.Client{Timeout: 2 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return false, nil
+	}
+	resp.Body.Close()
+	if probe.Status != 0 {
+		return resp.StatusCode == probe.Status, nil
+	}
+	return resp.StatusCode >= 200 && resp.StatusCode < 300, nil
+}
+
+// logReady matches only lines written since the container last started, so
+// a restart is not satisfied by the previous run's output.
+func (a *ContainerApp) logReady(ctx context.Context, inspect dockerclient.ContainerInspectResult, pattern *regexp.Regexp) (bool, error) {
+	logs, err := a.containerService.GetContainerLogs(ctx, inspect.Container.ID, readinessLogTail)
+	if err != nil {
+		return false, err
+	}
+	started, _ := time.Parse(time.RFC3339Nano, inspect.Container.State.StartedAt)
+	scanner := bufio.NewScanner(strings.NewReader(logs))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		stamp, line, ok := strings.Cut(scanner.Text(), " ")
+		if !ok {
+			continue
+		}
+		if at, err := time.Parse(time.RFC3339Nano, stamp); err == nil && at.Before(started) {
+			continue
+		}
+		if pattern.MatchString(line) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// resourceReadiness reads the probe a container declares.
+func resourceReadiness(inspect dockerclient.ContainerInspectResult) *domain.ReadinessProbe {
+	if inspect.Container.Config == nil {
+		return nil
+	}
+	probe, _ := readiness.Decode(inspect.Container.Config.Labels[readinessLabel])
+	return probe
+}
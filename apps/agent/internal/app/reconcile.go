@@ -0,0 +1,347 @@
+package app
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/config"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+	dockerclient "github.com/moby/moby/client"
+)
+
+const (
+	reconcileNone    = "none"
+	reconcileFixed   = "fixed"
+	reconcileAlerted = "alerted"
+	reconcileIgnored = "ignored"
+	reconcileFailed  = "failed"
+)
+
+// eventSettleDelay gives devcon's own start and stop calls time to record the
+// new desired state before an event for the same container is reconciled.
+const eventSettleDelay = 2 * time.Second
+
+type reconciler struct {
+	mu sync.Mutex
+}
+
+func validDriftPolicy(policy string) bool {
+	switch policy {
+	case domain.DriftPolicyAutoFix, domain.DriftPolicyAlert, domain.DriftPolicyIgnore:
+		return true
+	}
+	return false
+}
+
+// setDesiredState records what the caller is about to ask of a resource.
+// It is written before the docker call so the reconciler never undoes it.
+// Stack containers are left to stack convergence.
+func (a *ContainerApp) setDesiredState(ctx context.Context, identifier, state string) error {
+	inspect, err := a.containerService.InsepectContainer(ctx, identifier)
+	if err != nil {
+		return err
+	}
+	return a.trackResource(ctx, inspect, state, false)
+}
+
+// trackResource saves the desired state of a container. fresh marks a
+// container devcon has just created, whose configuration becomes the
+// reference for config drift.
+func (a *ContainerApp) trackResource(ctx context.Context, inspect dockerclient.ContainerInspectResult, state string, fresh bool) error {
+	c := inspect.Container
+	if c.Config == nil || c.Config.Labels[composeProjectLabel] != "" {
+		return nil
+	}
+	name := firstContainerName([]string{c.Name})
+	desired, err := a.reconcileService.GetDesiredState(ctx, name)
+	if err != nil {
+		return err
+	}
+	if desired == nil {
+		desired = &domain.DesiredState{Resource: name, Policy: config.DriftPolicy()}
+		fresh = true
+	}
+	if fresh {
+		desired.ContainerID = c.ID
+		desired.ConfigHash = containerConfigHash(inspect)
+	}
+	desired.State = state
+	desired.UpdatedAt = time.Now().Unix()
+	return a.reconcileService.SaveDesiredState(ctx, desired)
+}
+
+func (a *ContainerApp) untrackResource(ctx context.Context, identifier string) error {
+	inspect, err := a.containerService.InsepectContainer(ctx, identifier)
+	if err != nil {
+		return err
+	}
+	name := firstContainerName([]string{inspect.Container.Name})
+	if err := a.lifecycleService.DeleteLifecycle(ctx, name); err != nil {
+		return err
+	}
+	return a.reconcileService.DeleteDesiredState(ctx, name)
+}
+
+// SetDriftPolicy changes how drift of a resource is handled. Resources devcon
+// has not tracked yet start from their current state.
+func (a *ContainerApp) SetDriftPolicy(ctx context.Context, identifier, policy string) (*domain.DesiredState, error) {
+	if !validDriftPolicy(policy) {
+		return nil, fmt.Errorf("invalid drift policy %q: use auto_fix, alert or ignore", policy)
+	}
+	inspect, err := a.containerService.InsepectContainer(ctx, identifier)
+	if err != nil {
+		return nil, err
+	}
+	if inspect.Container.Config.Labels[composeProjectLabel] != "" {
+		return nil, fmt.Errorf("resource %s belongs to a stack and is reconciled by it", identifier)
+	}
+	name := firstContainerName([]string{inspect.Container.Name})
+	desired, err := a.reconcileService.GetDesiredState(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if desired == nil {
+		state := domain.DesiredStopped
+		if inspect.Container.State != nil && inspect.Container.State.Running {
+			state = domain.DesiredRunning
+		}
+		desired = &domain.DesiredState{
+			Resource:    name,
+			ContainerID: inspect.Container.ID,
+			State:       state,
+			ConfigHash:  containerConfigHash(inspect),
+		}
+	}
+	desired.Policy = policy
+	desired.UpdatedAt = time.Now().Unix()
+	if err := a.reconcileService.SaveDesiredState(ctx, desired); err != nil {
+		return nil, err
+	}
+	return desired, nil
+}
+
+func (a *ContainerApp) ListDesiredStates(ctx context.Context) ([]domain.DesiredState, error) {
+	return a.reconcileService.ListDesiredStates(ctx)
+}
+
+// Reconcile compares tracked resources with docker and handles drift by each
+// resource's policy. An empty resource reconciles everything.
+func (a *ContainerApp) Reconcile(ctx context.Context, resource string) ([]domain.ReconcileResult, error) {
+	states, err := a.reconcileService.ListDesiredStates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if resource != "" {
+		if inspect, err := a.containerService.InsepectContainer(ctx, resource); err == nil {
+			resource = firstContainerName([]string{inspect.Container.Name})
+		}
+		states = slices.DeleteFunc(states, func(s domain.DesiredState) bool { return s.Resource != resource })
+		if len(states) == 0 {
+			return nil, fmt.Errorf("resource %s is not tracked", resource)
+		}
+	}
+
+	results := make([]domain.ReconcileResult, 0, len(states))
+	for _, state := range states {
+		result, err := a.reconcileResource(ctx, state.Resource)
+		if err != nil {
+			return nil, err
+		}
+		if result != nil {
+			results = append(results, *result)
+		}
+	}
+	return results, nil
+}
+
+func (a *ContainerApp) reconcileResource(ctx context.Context, resource string) (*domain.ReconcileResult, error) {
+	a.reconciler.mu.Lock()
+	defer a.reconciler.mu.Unlock()
+
+	// Re-read under the lock so a change recorded meanwhile wins.
+	desired, err := a.reconcileService.GetDesiredState(ctx, resource)
+	if err != nil || desired == nil {
+		return nil, err
+	}
+	result := &domain.ReconcileResult{Resource: resource, CheckedAt: time.Now().Unix(), Action: reconcileNone}
+	previous := desired.LastReconcile
+
+	if desired.Policy == domain.DriftPolicyIgnore {
+		result.Action = reconcileIgnored
+	} else {
+		inspect, found, err := a.inspectByName(ctx, resource)
+		if err != nil {
+			return nil, err
+		}
+		result.Drift = driftOf(*desired, inspect, found)
+		if len(result.Drift) > 0 {
+			a.handleDrift(ctx, *desired, inspect, found, result)
+		}
+	}
+
+	if len(result.Drift) > 0 && (previous == nil || !slices.Equal(previous.Drift, result.Drift)) {
+		log.Printf("drift on %s: %v (%s)", resource, result.Drift, result.Action)
+	}
+	desired.LastReconcile = result
+	if err := a.reconcileService.SaveDesiredState(ctx, desired); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// handleDrift only corrects the run state. A missing or replaced container
+// cannot be rebuilt from the desired state alone, so it is always reported.
+func (a *ContainerApp) handleDrift(ctx context.Context, desired domain.DesiredState, inspect dockerclient.ContainerInspectResult, found bool, result *domain.ReconcileResult) {
+	result.Action = reconcileAlerted
+	if desired.Policy != domain.DriftPolicyAutoFix || !found {
+		return
+	}
+	if inspect.Container.ID != desired.ContainerID && containerConfigHash(inspect) != desired.ConfigHash {
+		return
+	}
+	running := inspect.Container.State != nil && inspect.Container.State.Running
+	var err error
+	switch {
+	case desired.State == domain.DesiredRunning && !running:
+		err = a.containerService.StartContainer(ctx, inspect.Container.ID)
+	case desired.State == domain.DesiredStopped && running:
+		err = a.containerService.StopContainer(ctx, inspect.Container.ID)
+	default:
+		return
+	}
+	if err != nil {
+		result.Action = reconcileFailed
+		result.Error = err.Error()
+		return
+	}
+	result.Action = reconcileFixed
+}
+
+func driftOf(desired domain.DesiredState, inspect dockerclient.ContainerInspectResult, found bool) []string {
+	if !found {
+		return []string{"container missing"}
+	}
+	drift := make([]string, 0)
+	running := inspect.Container.State != nil && inspect.Container.State.Running
+	if desired.State == domain.DesiredRunning && !running {
+		drift = append(drift, "expected running, found "+string(inspect.Container.State.Status))
+	}
+	if desired.State == domain.DesiredStopped && running {
+		drift = append(drift, "expected stopped, found running")
+	}
+	if desired.ConfigHash != "" && containerConfigHash(inspect) != desired.ConfigHash {
+		drift = append(drift, "configuration changed outside devcon")
+	}
+	return drift
+}
+
+func (a *ContainerApp) inspectByName(ctx context.Context, name string) (dockerclient.ContainerInspectResult, bool, error) {
+	list, err := a.containerService.ListContainers(ctx)
+	if err != nil {
+		return dockerclient.ContainerInspectResult{}, false, err
+	}
+	for _, c := range list.Items {
+		if firstContainerName(c.Names) == name {
+			inspect, err := a.containerService.InsepectContainer(ctx, c.ID)
+			return inspect, err == nil, err
+		}
+	}
+	return dockerclient.ContainerInspectResult{}, false, nil
+}
+
+// resourceDrift is the drift shown in resource details, computed live rather
+// than taken from the last reconcile.
+func (a *ContainerApp) resourceDrift(ctx context.Context, inspect dockerclient.ContainerInspectResult) (*domain.ResourceDrift, error) {
+	desired, err := a.reconcileService.GetDesiredState(ctx, firstContainerName([]string{inspect.Container.Name}))
+	if err != nil || desired == nil {
+		return nil, err
+	}
+	drift := &domain.ResourceDrift{
+		Policy:        desired.Policy,
+		DesiredState:  desired.State,
+		LastReconcile: desired.LastReconcile,
+	}
+	if desired.Policy != domain.DriftPolicyIgnore {
+		drift.Drift = driftOf(*desired, inspect, true)
+		drift.Drifted = len(drift.Drift) > 0
+	}
+	return drift, nil
+}
+
+// containerConfigHash covers what devcon sets when creating a container, not
+// runtime state, so restarts keep the hash stable.
+func containerConfigHash(inspect dockerclient.ContainerInspectResult) string {
+	c := inspect.Container
+	payload := struct {
+		Image      string   `json:"image"`
+		Env        []string `json:"env"`
+		Cmd        []string `json:"cmd"`
+		Entrypoint []string `json:"entrypoint"`
+		Labels     any      `json:"labels"`
+		Ports      any      `json:"ports"`
+		Binds      []string `json:"binds"`
+		Mounts     any      `json:"mounts"`
+	}{}
+	if c.Config != nil {
+		payload.Image = c.Config.Image
+		payload.Env = c.Config.Env
+		payload.Cmd = c.Config.Cmd
+		payload.Entrypoint = c.Config.Entrypoint
+		payload.Labels = c.Config.Labels
+	}
+	if c.HostConfig != nil {
+		payload.Ports = c.HostConfig.PortBindings
+		payload.Binds = c.HostConfig.Binds
+		payload.Mounts = c.HostConfig.Mounts
+	}
+	data, _ := json.Marshal(payload)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// StartReconciler reconciles every tracked resource on an interval and
+// reacts to container events in between.
+func (a *ContainerApp) StartReconciler() {
+	go func() {
+		ticker := time.NewTicker(config.ReconcileInterval())
+		defer ticker.Stop()
+		for range ticker.C {
+			if _, err := a.Reconcile(context.Background(), ""); err != nil {
+				log.Println("reconciler:", err)
+			}
+		}
+	}()
+	go a.watchContainerEvents()
+}
+
+func (a *ContainerApp) watchContainerEvents() {
+	for {
+		ctx, cancel := context.WithCancel(context.Background())
+		events, errs := a.containerService.ContainerEvents(ctx)
+		for event := range events {
+			switch event.Action {
+			case "start", "die", "stop", "destroy", "pause", "unpause":
+			default:
+				continue
+			}
+			name := event.Name
+			time.AfterFunc(eventSettleDelay, func() {
+				if _, err := a.reconcileResource(context.Background(), name); err != nil {
+					log.Println("reconciler:", err)
+				}
+			})
+		}
+		if err := <-errs; err != nil {
+			log.Println("reconciler: container events:", err)
+		}
+		cancel()
+		time.Sleep(5 * time.Second)
+	}
+}
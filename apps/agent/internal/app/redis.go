@@ -0,0 +1,111 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/connection"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/redis"
+)
+
+func (a *ContainerApp) RedisInfo(ctx context.Context, identifier string, section string) ([]domain.RedisInfoSection, error) {
+	cmd, err := redis.InfoCommand(0, section)
+	if err != nil {
+		return nil, err
+	}
+	out, err := a.runRedisCli(ctx, identifier, cmd)
+	if err != nil {
+		return nil, err
+	}
+	return redis.ParseInfo(out)
+}
+
+func (a *ContainerApp) RedisKeyspace(ctx context.Context, identifier string) (*domain.RedisKeyspace, error) {
+	out, err := a.runRedisCli(ctx, identifier, redis.Command(0, "INFO"))
+	if err != nil {
+		return nil, err
+	}
+	sections, err := redis.ParseInfo(out)
+	if err != nil {
+		return nil, err
+	}
+	keyspace := redis.Keyspace(sections)
+	return &keyspace, nil
+}
+
+func (a *ContainerApp) RedisScanKeys(ctx context.Context, identifier string, req domain.RedisScanRequest) (*domain.RedisScanResult, error) {
+	if req.DB < 0 {
+		return nil, fmt.Errorf("invalid database %d", req.DB)
+	}
+	out, err := a.runRedisCli(ctx, identifier, redis.ScanCommand(req))
+	if err != nil {
+		return nil, err
+	}
+	return redis.ParseScan(out)
+}
+
+func (a *ContainerApp) RedisGetValue(ctx context.Context, identifier string, db int, key string) (*domain.RedisValue, error) {
+	if key == "" {
+		return nil, fmt.Errorf("key cannot be empty")
+	}
+	out, err := a.runRedisCli(ctx, identifier, redis.ValueCommand(db, key))
+	if err != nil {
+		return nil, err
+	}
+	value, err := redis.ParseValue(out)
+	if err != nil {
+		return nil, err
+	}
+	if value == nil {
+		return nil, fmt.Errorf("key %q not found", key)
+	}
+	return value, nil
+}
+
+// RedisDeleteKeys returns how many of the keys existed.
+func (a *ContainerApp) RedisDeleteKeys(ctx context.Context, identifier string, req domain.RedisDeleteRequest) (int64, error) {
+	if len(req.Keys) == 0 {
+		return 0, fmt.Errorf("no keys to delete")
+	}
+	out, err := a.runRedisCli(ctx, identifier, redis.Command(req.DB, append([]string{"DEL"}, req.Keys...)...))
+	if err != nil {
+		return 0, err
+	}
+	return redis.ParseInteger(out)
+}
+
+func (a *ContainerApp) RedisFlushDB(ctx context.Context, identifier string, db int, confirm bool) error {
+	if !confirm {
+		return fmt.Errorf("flushing database %d deletes every key in it; confirm to proceed", db)
+	}
+	out, err := a.runRedisCli(ctx, identifier, redis.Command(db, "FLUSHDB"))
+	if err != nil {
+		return err
+	}
+	return redis.ParseOK(out)
+}
+
+func (a *ContainerApp) runRedisCli(ctx context.Context, identifier string, cmd []string) ([]byte, error) {
+	id, env, err := a.typedResource(ctx, identifier, "redis")
+	if err != nil {
+		return nil, err
+	}
+	spec := domain.ExecSpec{Cmd: cmd}
+	if creds, _ := connection.CredentialsFor("redis", env); creds.Password != "" {
+		spec.Env = []string{"REDISCLI_AUTH=" + creds.Password}
+	}
+	result, err := a.containerService.ExecContainer(ctx, id, spec)
+	if err != nil {
+		return nil, err
+	}
+	if result.ExitCode != 0 {
+		message := strings.TrimSpace(string(result.Stderr))
+		if message == "" {
+			message = strings.TrimSpace(string(result.Stdout))
+		}
+		return nil, fmt.Errorf("redis-cli exited with code %d: %s", result.ExitCode, message)
+	}
+	return result.Stdout, nil
+}
@@ -0,0 +1,60 @@
+package app
+
+import (
+	"context"
+	"strconv"
+	"time"
+)
+
+// asideContainer is a container renamed out of the way of its replacement.
+// It is kept until the replacement is known to work, so a failure can put it
+// back.
+type asideContainer struct {
+	id      string
+	name    string
+	running bool
+}
+
+// setAside stops a container and renames it so a replacement can take its
+// name.
+func (a *ContainerApp) setAside(ctx context.Context, id, name string, running bool) (asideContainer, error) {
+	aside := asideContainer{id: id, name: name, running: running}
+	if running {
+		if err := a.containerService.StopContainer(ctx, id); err != nil {
+			return aside, err
+		}
+	}
+	if err := a.containerService.RenameContainer(ctx, id, name+"-replaced-"+strconv.FormatInt(time.Now().Unix(), 10)); err != nil {
+		a.putBack(ctx, aside)
+		return aside, err
+	}
+	return aside, nil
+}
+
+// putBack gives a container set aside its name back and starts it again if
+// it was running.
+func (a *ContainerApp) putBack(ctx context.Context, aside asideContainer) {
+	_ = a.containerService.RenameContainer(ctx, aside.id, aside.name)
+	if aside.running {
+		_ = a.containerService.StartContainer(ctx, aside.id)
+	}
+}
+
+// replaceContainer swaps a container for the one create makes under the same
+// name and removes the old one. When create fails, the container it returns,
+// if any, is removed and the old one is put back.
+func (a *ContainerApp) replaceContainer(ctx context.Context, id, name string, running bool, create func() (string, error)) (string, error) {
+	aside, err := a.setAside(ctx, id, name, running)
+	if err != nil {
+		return "", err
+	}
+	created, err := create()
+	if err != nil {
+		if created != "" {
+			_ = a.containerService.DeleteContainer(ctx, created)
+		}
+		a.putBack(ctx, aside)
+		return "", err
+	}
+	return created, a.containerService.DeleteContainer(ctx, id)
+}
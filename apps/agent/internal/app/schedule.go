@@ -0,0 +1,222 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/schedule"
+)
+
+const eventScheduleRun = "schedule_run"
+
+func (a *ContainerApp) CreateSchedule(ctx context.Context, req domain.ScheduleRequest) (*domain.Schedule, error) {
+	if err := a.checkScheduleRequest(ctx, &req); err != nil {
+		return nil, err
+	}
+	existing, err := a.scheduleService.GetSchedule(ctx, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("schedule %s already exists", req.Name)
+	}
+	now := time.Now().Unix()
+	s := &domain.Schedule{CreatedAt: now}
+	return a.saveSchedule(ctx, s, req)
+}
+
+// UpdateSchedule replaces the definition of a schedule and keeps its history.
+func (a *ContainerApp) UpdateSchedule(ctx context.Context, name string, req domain.ScheduleRequest) (*domain.Schedule, error) {
+	req.Name = name
+	if err := a.checkScheduleRequest(ctx, &req); err != nil {
+		return nil, err
+	}
+	s, err := a.GetSchedule(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return a.saveSchedule(ctx, s, req)
+}
+
+func (a *ContainerApp) saveSchedule(ctx context.Context, s *domain.Schedule, req domain.ScheduleRequest) (*domain.Schedule, error) {
+	s.Name = req.Name
+	s.Cron = req.Cron
+	s.TimeZone = req.TimeZone
+	s.Action = req.Action
+	s.TargetKind = req.TargetKind
+	s.Target = req.Target
+	s.Disabled = req.Disabled
+	s.UpdatedAt = time.Now().Unix()
+	s.NextRunAt = 0
+	if err := a.scheduleService.SaveSchedule(ctx, s); err != nil {
+		return nil, err
+	}
+	if err := a.syncSchedules(ctx); err != nil {
+		return nil, err
+	}
+	s.NextRunAt = schedule.Next(*s, time.Now())
+	return s, nil
+}
+
+// checkScheduleRequest validates the request and that its target exists now.
+// A target removed later makes the runs fail, which shows in the history.
+func (a *ContainerApp) checkScheduleRequest(ctx context.Context, req *domain.ScheduleRequest) error {
+	if err := schedule.Validate(req); err != nil {
+		return err
+	}
+	if req.TargetKind == domain.ScheduleTargetStack {
+		return a.requireStack(ctx, req.Target)
+	}
+	_, err := a.containerService.InsepectContainer(ctx, req.Target)
+	return err
+}
+
+func (a *ContainerApp) ListSchedules(ctx context.Context) ([]domain.Schedule, error) {
+	schedules, err := a.scheduleService.ListSchedules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	for i := range schedules {
+		schedules[i].NextRunAt = schedule.Next(schedules[i], now)
+	}
+	return schedules, nil
+}
+
+func (a *ContainerApp) GetSchedule(ctx context.Context, name string) (*domain.Schedule, error) {
+	s, err := a.scheduleService.GetSchedule(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if s == nil {
+		return nil, fmt.Errorf("schedule %s not found", name)
+	}
+	s.NextRunAt = schedule.Next(*s, time.Now())
+	return s, nil
+}
+
+func (a *ContainerApp) DeleteSchedule(ctx context.Context, name string) error {
+	if _, err := a.GetSchedule(ctx, name); err != nil {
+		return err
+	}
+	if err := a.scheduleService.DeleteSchedule(ctx, name); err != nil {
+		return err
+	}
+	return a.syncSchedules(ctx)
+}
+
+func (a *ContainerApp) ListScheduleRuns(ctx context.Context, name string) ([]domain.ScheduleRun, error) {
+	if _, err := a.GetSchedule(ctx, name); err != nil {
+		return nil, err
+	}
+	return a.scheduleService.ListRuns(ctx, name)
+}
+
+// RunSchedule runs a schedule's action now, whether or not it is disabled.
+func (a *ContainerApp) RunSchedule(ctx context.Context, name string) (*domain.ScheduleRun, error) {
+	s, err := a.GetSchedule(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return a.runSchedule(ctx, *s, schedule.TriggerManual)
+}
+
+// StartScheduler runs schedules when their cron expressions fire. Schedules
+// are re-read every minute so changes made from another process, such as the
+// CLI, are picked up.
+func (a *ContainerApp) StartScheduler() error {
+	a.scheduler = newCronSync("scheduler")
+	return a.scheduler.start(func() error { return a.syncSchedules(context.Background()) })
+}
+
+func (a *ContainerApp) syncSchedules(ctx context.Context) error {
+	if a.scheduler == nil {
+		return nil
+	}
+	schedules, err := a.scheduleService.ListSchedules(ctx)
+	if err != nil {
+		return err
+	}
+	wanted := make(map[string]string, len(schedules))
+	for _, s := range schedules {
+		if !s.Disabled {
+			wanted[s.Name] = schedule.Spec(s.Cron, s.TimeZone)
+		}
+	}
+	a.scheduler.sync(wanted, a.runScheduled)
+	return nil
+}
+
+func (a *ContainerApp) runScheduled(name string) {
+	ctx := context.Background()
+	// Re-read so a schedule disabled since the last sync does not run.
+	s, err := a.scheduleService.GetSchedule(ctx, name)
+	if err != nil || s == nil || s.Disabled {
+		return
+	}
+	if _, err := a.runSchedule(ctx, *s, schedule.TriggerSchedule); err != nil {
+		log.Printf("scheduler: %s: %v", name, err)
+	}
+}
+
+// runSchedule executes the action and records the run. The returned error is
+// only about recording it; a failed action is reported in the run.
+func (a *ContainerApp) runSchedule(ctx context.Context, s domain.Schedule, trigger string) (*domain.ScheduleRun, error) {
+	run := domain.ScheduleRun{
+		Schedule:  s.Name,
+		Action:    s.Action,
+		Target:    s.Target,
+		Trigger:   trigger,
+		StartedAt: time.Now().Unix(),
+		Outcome:   schedule.OutcomeSuccess,
+	}
+	if err := a.scheduleAction(ctx, s); err != nil {
+		run.Outcome = schedule.OutcomeFailed
+		run.Error = err.Error()
+	}
+	run.FinishedAt = time.Now().Unix()
+
+	if run.Error != "" {
+		a.publishEvent(eventScheduleRun, s.Target, "schedule %s: %s failed: %s", s.Name, s.Action, run.Error)
+	} else {
+		a.publishEvent(eventScheduleRun, s.Target, "schedule %s: %s", s.Name, s.Action)
+	}
+	if err := a.scheduleService.AddRun(ctx, run, schedule.HistoryLimit); err != nil {
+		return nil, err
+	}
+	latest, err := a.scheduleService.GetSchedule(ctx, s.Name)
+	if err != nil || latest == nil {
+		return &run, err
+	}
+	latest.LastRun = &run
+	if err := a.scheduleService.SaveSchedule(ctx, latest); err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+func (a *ContainerApp) scheduleAction(ctx context.Context, s domain.Schedule) error {
+	if s.TargetKind == domain.ScheduleTargetStack {
+		switch s.Action {
+		case domain.ScheduleStart:
+			return a.StartStack(ctx, s.Target)
+		case domain.ScheduleStop:
+			return a.StopStack(ctx, s.Target)
+		case domain.ScheduleRestart:
+			return a.RestartStack(ctx, s.Target)
+		}
+	} else {
+		switch s.Action {
+		case domain.ScheduleStart:
+			return a.Start(ctx, s.Target)
+		case domain.ScheduleStop:
+			return a.Stop(ctx, s.Target)
+		case domain.ScheduleRestart:
+			return a.Restart(ctx, s.Target)
+		}
+	}
+	return fmt.Errorf("unknown action %q", s.Action)
+}
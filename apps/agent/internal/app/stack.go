@@ -0,0 +1,513 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+	"github.com/moby/moby/api/types/container"
+)
+
+const (
+	stackStatusRunning  = "RUNNING"
+	stackStatusDegraded = "DEGRADED"
+	stackStatusStopped  = "STOPPED"
+)
+
+func ParseServiceRef(ref string) (string, string, error) {
+	project, service, ok := strings.Cut(strings.TrimSpace(ref), "/")
+	if !ok || project == "" || service == "" || strings.Contains(service, "/") {
+		return "", "", fmt.Errorf("service must be addressed as project/service, got %q", ref)
+	}
+	return project, service, nil
+}
+
+func (a *ContainerApp) StartStack(ctx context.Context, project string) error {
+	if err := a.requireStack(ctx, project); err != nil {
+		return err
+	}
+	return a.containerService.StartComposeProject(ctx, project)
+}
+
+func (a *ContainerApp) StopStack(ctx context.Context, project string) error {
+	if err := a.requireStack(ctx, project); err != nil {
+		return err
+	}
+	return a.containerService.StopComposeProject(ctx, project)
+}
+
+func (a *ContainerApp) RestartStack(ctx context.Context, project string) error {
+	if err := a.requireStack(ctx, project); err != nil {
+		return err
+	}
+	return a.containerService.RestartComposeProject(ctx, project)
+}
+
+// DownStack removes a stack's containers and networks, and its volumes and
+// built images when asked. When a removal fails, the report lists what was
+// removed before it.
+func (a *ContainerApp) DownStack(ctx context.Context, project string, opts domain.StackDownOptions) (*domain.StackDownReport, error) {
+	if strings.TrimSpace(project) == "" {
+		return nil, fmt.Errorf("stack name cannot be empty")
+	}
+
+	containers, err := a.containerService.FindContainersByComposeProject(ctx, project)
+	if err != nil {
+		return nil, err
+	}
+	networks, err := a.containerService.ListNetworks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.StackDownReport{
+		Project:    project,
+		DryRun:     opts.DryRun,
+		Containers: make([]string, 0, len(containers)),
+		Networks:   make([]string, 0),
+		Volumes:    make([]string, 0),
+		Images:     make([]string, 0),
+	}
+	for _, c := range containers {
+		report.Containers = append(report.Containers, firstContainerName(c.Names))
+	}
+	networkIDs := make([]string, 0)
+	for _, n := range networks.Items {
+		if n.Labels[composeProjectLabel] == project {
+			networkIDs = append(networkIDs, n.ID)
+			report.Networks = append(report.Networks, n.Name)
+		}
+	}
+	if opts.Volumes {
+		volumes, err := a.containerService.ListVolumes(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range volumes.Items {
+			if v.Labels[composeProjectLabel] == project {
+				report.Volumes = append(report.Volumes, v.Name)
+			}
+		}
+	}
+	var imageIDs []string
+	if opts.Images {
+		imageIDs, report.Images, err = a.stackBuiltImages(ctx, project, containers)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(containers) == 0 && len(networkIDs) == 0 && len(report.Volumes) == 0 {
+		return nil, fmt.Errorf("stack %s not found", project)
+	}
+	if opts.DryRun {
+		return report, nil
+	}
+
+	// From here on the report lists what has been removed, so a failure
+	// still tells the caller what is already gone.
+	planned := *report
+	report.Containers = make([]string, 0, len(containers))
+	report.Networks = make([]string, 0, len(networkIDs))
+	report.Volumes = make([]string, 0, len(planned.Volumes))
+	report.Images = make([]string, 0, len(imageIDs))
+	for _, c := range containers {
+		remove := a.containerService.DeleteContainer
+		if opts.Volumes {
+			remove = a.containerService.DeleteContainerWithVolumes
+		}
+		if err := remove(ctx, c.ID); err != nil {
+			return report, fmt.Errorf("failed to remove container %s: %w", firstContainerName(c.Names), err)
+		}
+		report.Containers = append(report.Containers, firstContainerName(c.Names))
+	}
+	for i, id := range networkIDs {
+		if err := a.containerService.RemoveNetwork(ctx, id); err != nil {
+			return report, fmt.Errorf("failed to remove network %s: %w", planned.Networks[i], err)
+		}
+		report.Networks = append(report.Networks, planned.Networks[i])
+	}
+	for _, name := range planned.Volumes {
+		if err := a.containerService.RemoveVolume(ctx, name); err != nil {
+			return report, fmt.Errorf("failed to remove volume %s: %w", name, err)
+		}
+		report.Volumes = append(report.Volumes, name)
+	}
+	for i, id := range imageIDs {
+		if err := a.containerService.RemoveImage(ctx, id); err != nil {
+			return report, fmt.Errorf("failed to remove image %s: %w", planned.Images[i], err)
+		}
+		report.Images = append(report.Images, planned.Images[i])
+	}
+	return report, nil
+}
+
+// stackBuiltImages only returns images devcon or compose built for the
+// project; pulled images such as postgres:16 are shared and left alone.
+func (a *ContainerApp) stackBuiltImages(ctx context.Context, project string, containers []container.Summary) ([]string, []string, error) {
+	images, err := a.containerService.ListImages(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	builtNames := make(map[string]bool)
+	for _, c := range containers {
+		if service := c.Labels[composeServiceLabel]; service != "" {
+			builtNames[project+"-"+service] = true
+		}
+	}
+
+	ids := make([]string, 0)
+	names := make([]string, 0)
+	for _, img := range images.Items {
+		match := img.Labels[composeProjectLabel] == project
+		for _, tag := range img.RepoTags {
+			repo, _, _ := strings.Cut(tag, ":")
+			if builtNames[repo] {
+				match = true
+			}
+		}
+		if !match {
+			continue
+		}
+		ids = append(ids, img.ID)
+		if len(img.RepoTags) > 0 {
+			names = append(names, img.RepoTags[0])
+		} else {
+			names = append(names, img.ID)
+		}
+	}
+	return ids, names, nil
+}
+
+func (a *ContainerApp) StartService(ctx context.Context, project, service string) error {
+	containers, err := a.serviceContainers(ctx, project, service)
+	if err != nil {
+		return err
+	}
+	for _, c := range containers {
+		if c.State == "running" {
+			continue
+		}
+		if err := a.containerService.StartContainer(ctx, c.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *ContainerApp) StopService(ctx context.Context, project, service string) error {
+	containers, err := a.serviceContainers(ctx, project, service)
+	if err != nil {
+		return err
+	}
+	for _, c := range containers {
+		if c.State != "running" {
+			continue
+		}
+		if err := a.containerService.StopContainer(ctx, c.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *ContainerApp) RestartService(ctx context.Context, project, service string) error {
+	containers, err := a.serviceContainers(ctx, project, service)
+	if err != nil {
+		return err
+	}
+	for _, c := range containers {
+		if err := a.containerService.RestartContainer(ctx, c.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *ContainerApp) RecreateService(ctx context.Context, project, service string) error {
+	containers, err := a.serviceContainers(ctx, project, service)
+	if err != nil {
+		return err
+	}
+	for _, c := range containers {
+		inspect, err := a.containerService.InsepectContainer(ctx, c.ID)
+		if err != nil {
+			return err
+		}
+		name := strings.TrimPrefix(inspect.Container.Name, "/")
+		if _, err := a.replaceContainer(ctx, c.ID, name, c.State == "running", func() (string, error) {
+			created, err := a.containerService.CreateContainerFrom(ctx, inspect, &domain.ContainerOverrides{Name: name})
+			if err != nil {
+				return "", fmt.Errorf("failed to recreate %s: %w", name, err)
+			}
+			return created.ID, a.containerService.StartContainer(ctx, created.ID)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *ContainerApp) GetServiceLogs(ctx context.Context, project, service string, tail int) (string, error) {
+	containers, err := a.serviceContainers(ctx, project, service)
+	if err != nil {
+		return "", err
+	}
+	if tail <= 0 {
+		tail = 200
+	}
+
+	var b strings.Builder
+	for _, c := range containers {
+		logs, err := a.containerService.GetContainerLogs(ctx, c.ID, tail)
+		if err != nil {
+			return "", err
+		}
+		name := firstContainerName(c.Names)
+		for _, line := range strings.Split(strings.TrimRight(logs, "\n"), "\n") {
+			if line == "" {
+				continue
+			}
+			b.WriteString(name + " | " + line + "\n")
+		}
+	}
+	return b.String(), nil
+}
+
+// ScaleService runs the given number of replicas of a service. Scaling to
+// zero stops the first replica instead of deleting it, so it stays the
+// template when the service is scaled up again.
+func (a *ContainerApp) ScaleService(ctx context.Context, project, service string, replicas int) (*domain.ServiceScaleResult, error) {
+	if replicas < 0 {
+		return nil, fmt.Errorf("replicas cannot be negative")
+	}
+	containers, err := a.serviceContainers(ctx, project, service)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.ServiceScaleResult{Project: project, Service: service, Replicas: replicas}
+	keep := max(replicas, 1)
+	if keep < len(containers) {
+		for _, c := range containers[keep:] {
+			if err := a.containerService.DeleteContainer(ctx, c.ID); err != nil {
+				return nil, err
+			}
+			result.Removed = append(result.Removed, firstContainerName(c.Names))
+		}
+		containers = containers[:keep]
+	}
+	if replicas == 0 {
+		if c := containers[0]; c.State == "running" {
+			if err := a.containerService.StopContainer(ctx, c.ID); err != nil {
+				return nil, err
+			}
+			result.Stopped = append(result.Stopped, firstContainerName(c.Names))
+		}
+		return result, nil
+	}
+	for _, c := range containers {
+		if c.State == "running" {
+			continue
+		}
+		if err := a.containerService.StartContainer(ctx, c.ID); err != nil {
+			return nil, err
+		}
+		result.Started = append(result.Started, firstContainerName(c.Names))
+	}
+	if replicas == len(containers) {
+		return result, nil
+	}
+
+	template, err := a.containerService.InsepectContainer(ctx, containers[0].ID)
+	if err != nil {
+		return nil, err
+	}
+	if name := strings.TrimPrefix(template.Container.Name, "/"); name != composeReplicaName(project, service, containerNumber(containers[0])) {
+		return nil, fmt.Errorf("service %s uses a fixed container name %s and cannot be scaled", service, name)
+	}
+	for port, bindings := range template.Container.HostConfig.PortBindings {
+		for _, binding := range bindings {
+			if binding.HostPort != "" && binding.HostPort != "0" {
+				return nil, fmt.Errorf("service %s publishes fixed host port %s for %s and cannot be scaled", service, binding.HostPort, port)
+			}
+		}
+	}
+
+	used := make(map[int]bool, len(containers))
+	for _, c := range containers {
+		used[containerNumber(c)] = true
+	}
+	next := 1
+	for count := len(containers); count < replicas; count++ {
+		for used[next] {
+			next++
+		}
+		used[next] = true
+		name := composeReplicaName(project, service, next)
+		created, err := a.containerService.CreateContainerFrom(ctx, template, &domain.ContainerOverrides{
+			Name:   name,
+			Labels: map[string]string{composeNumberLabel: strconv.Itoa(next)},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create replica %s: %w", name, err)
+		}
+		if err := a.containerService.StartContainer(ctx, created.ID); err != nil {
+			return nil, err
+		}
+		result.Created = append(result.Created, name)
+	}
+	return result, nil
+}
+
+func (a *ContainerApp) requireStack(ctx context.Context, project string) error {
+	if strings.TrimSpace(project) == "" {
+		return fmt.Errorf("stack name cannot be empty")
+	}
+	containers, err := a.containerService.FindContainersByComposeProject(ctx, project)
+	if err != nil {
+		return err
+	}
+	if len(containers) == 0 {
+		return fmt.Errorf("stack %s not found", project)
+	}
+	return nil
+}
+
+func (a *ContainerApp) serviceContainers(ctx context.Context, project, service string) ([]container.Summary, error) {
+	if strings.TrimSpace(project) == "" || strings.TrimSpace(service) == "" {
+		return nil, fmt.Errorf("stack and service names cannot be empty")
+	}
+	containers, err := a.containerService.FindContainersByComposeService(ctx, project, service)
+	if err != nil {
+		return nil, err
+	}
+	if len(containers) == 0 {
+		return nil, fmt.Errorf("service %s/%s not found", project, service)
+	}
+	sort.Slice(containers, func(i, j int) bool {
+		return containerNumber(containers[i]) < containerNumber(containers[j])
+	})
+	return containers, nil
+}
+
+func containerNumber(c container.Summary) int {
+	n, err := strconv.Atoi(c.Labels[composeNumberLabel])
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+func composeReplicaName(project, service string, number int) string {
+	return fmt.Sprintf("%s-%s-%d", project, service, number)
+}
+
+func (a *ContainerApp) GetStackDefinition(ctx context.Context, project string) (*domain.StackDefinition, error) {
+	stack, err := a.stackService.GetStack(ctx, project)
+	if err != nil {
+		return nil, err
+	}
+	if stack == nil || len(stack.Versions) == 0 {
+		return nil, fmt.Errorf("no stored definition for stack %s", project)
+	}
+	return stack, nil
+}
+
+func (a *ContainerApp) GetStackVersion(ctx context.Context, project string, version int) (*domain.StackVersion, error) {
+	stack, err := a.GetStackDefinition(ctx, project)
+	if err != nil {
+		return nil, err
+	}
+	v := stack.Version(version)
+	if v == nil {
+		return nil, fmt.Errorf("stack %s has no version %d", project, version)
+	}
+	return v, nil
+}
+
+// RollbackStack re-applies a stored version as a new version, so the history
+// stays append-only. A zero version means the one before the current.
+func (a *ContainerApp) RollbackStack(ctx context.Context, project string, version int) (*domain.StackApplyResult, error) {
+	stack, err := a.GetStackDefinition(ctx, project)
+	if err != nil {
+		return nil, err
+	}
+	target := stack.Previous()
+	if version > 0 {
+		target = stack.Version(version)
+	}
+	if target == nil {
+		if version > 0 {
+			return nil, fmt.Errorf("stack %s has no version %d", project, version)
+		}
+		return nil, fmt.Errorf("stack %s has no previous version", project)
+	}
+	if err := a.containerService.PingDaemon(ctx); err != nil {
+		return nil, err
+	}
+	return a.applyStack(ctx, stack.Name, target.StackSource, fmt.Sprintf("rollback to v%d", target.Version))
+}
+
+// groupStackResources folds compose containers into one stack resource per
+// project, keeping the containers as its services. Other resources keep
+// their position in the list.
+func groupStackResources(resources []domain.Resource) []domain.Resource {
+	grouped := make([]domain.Resource, 0, len(resources))
+	stacks := make(map[string]int)
+	for _, resource := range resources {
+		if resource.Stack == "" {
+			grouped = append(grouped, resource)
+			continue
+		}
+		i, ok := stacks[resource.Stack]
+		if !ok {
+			i = len(grouped)
+			stacks[resource.Stack] = i
+			grouped = append(grouped, domain.Resource{
+				ID:            resource.Stack,
+				Name:          resource.Stack,
+				Image:         "docker-compose stack",
+				Type:          "stack",
+				Stack:         resource.Stack,
+				HostPorts:     make([]string, 0),
+				ContainerPort: make([]string, 0),
+			})
+		}
+		stack := &grouped[i]
+		stack.Services = append(stack.Services, resource)
+		stack.HostPorts = append(stack.HostPorts, resource.HostPorts...)
+		stack.ContainerPort = append(stack.ContainerPort, resource.ContainerPort...)
+		if stack.CreatedAt == 0 || resource.CreatedAt < stack.CreatedAt {
+			stack.CreatedAt = resource.CreatedAt
+		}
+	}
+
+	for _, i := range stacks {
+		stack := &grouped[i]
+		sort.Slice(stack.Services, func(a, b int) bool { return stack.Services[a].Name < stack.Services[b].Name })
+		stack.Status = stackStatus(stack.Services)
+	}
+	return grouped
+}
+
+func stackStatus(services []domain.Resource) string {
+	running := 0
+	for _, service := range services {
+		if service.Status == "RUNNING" {
+			running++
+		}
+	}
+	switch running {
+	case len(services):
+		return stackStatusRunning
+	case 0:
+		return stackStatusStopped
+	default:
+		return stackStatusDegraded
+	}
+}
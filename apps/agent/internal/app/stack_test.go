@@ -0,0 +1,126 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+	"github.com/moby/moby/api/types/container"
+)
+
+func TestParseServiceRef(t *testing.T) {
+	tests := []struct {
+		ref         string
+		wantProject string
+		wantService string
+		wantErr     bool
+	}{
+		{"shop/web", "shop", "web", false},
+		{" shop/web ", "shop", "web", false},
+		{"shop", "", "", true},
+		{"shop/", "", "", true},
+		{"/web", "", "", true},
+		{"shop/web/1", "", "", true},
+	}
+	for _, tt := range tests {
+		project, service, err := ParseServiceRef(tt.ref)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseServiceRef(%q) error = %v, wantErr %v", tt.ref, err, tt.wantErr)
+			continue
+		}
+		if project != tt.wantProject || service != tt.wantService {
+			t.Errorf("ParseServiceRef(%q) = %q, %q, want %q, %q", tt.ref, project, service, tt.wantProject, tt.wantService)
+		}
+	}
+}
+
+func TestComposeReplicaName(t *testing.T) {
+	tests := []struct {
+		project, service string
+		number           int
+		want             string
+	}{
+		{"shop", "web", 1, "shop-web-1"},
+		{"shop", "api-v2", 12, "shop-api-v2-12"},
+		{"my.shop", "db_main", 3, "my.shop-db_main-3"},
+	}
+	for _, tt := range tests {
+		if got := composeReplicaName(tt.project, tt.service, tt.number); got != tt.want {
+			t.Errorf("composeReplicaName(%q, %q, %d) = %q, want %q", tt.project, tt.service, tt.number, got, tt.want)
+		}
+	}
+}
+
+func TestContainerNumber(t *testing.T) {
+	tests := []struct {
+		labels map[string]string
+		want   int
+	}{
+		{map[string]string{composeNumberLabel: "3"}, 3},
+		{map[string]string{composeNumberLabel: "x"}, 0},
+		{map[string]string{}, 0},
+		{nil, 0},
+	}
+	for _, tt := range tests {
+		if got := containerNumber(container.Summary{Labels: tt.labels}); got != tt.want {
+			t.Errorf("containerNumber(%v) = %d, want %d", tt.labels, got, tt.want)
+		}
+	}
+}
+
+func TestStackStatus(t *testing.T) {
+	tests := []struct {
+		statuses []string
+		want     string
+	}{
+		{[]string{"RUNNING", "RUNNING"}, stackStatusRunning},
+		{[]string{"RUNNING", "EXITED"}, stackStatusDegraded},
+		{[]string{"EXITED", "CREATED"}, stackStatusStopped},
+	}
+	for _, tt := range tests {
+		services := make([]domain.Resource, 0, len(tt.statuses))
+		for _, status := range tt.statuses {
+			services = append(services, domain.Resource{Status: status})
+		}
+		if got := stackStatus(services); got != tt.want {
+			t.Errorf("stackStatus(%v) = %q, want %q", tt.statuses, got, tt.want)
+		}
+	}
+}
+
+func TestGroupStackResources(t *testing.T) {
+	resources := []domain.Resource{
+		{ID: "1", Name: "redis", Status: "RUNNING", CreatedAt: 10},
+		{ID: "2", Name: "shop-web-1", Stack: "shop", Status: "RUNNING", HostPorts: []string{"8080"}, CreatedAt: 30},
+		{ID: "3", Name: "postgres", Status: "EXITED", CreatedAt: 5},
+		{ID: "4", Name: "shop-db-1", Stack: "shop", Status: "EXITED", HostPorts: []string{"5432"}, CreatedAt: 20},
+		{ID: "5", Name: "blog-app-1", Stack: "blog", Status: "RUNNING", CreatedAt: 40},
+	}
+	grouped := groupStackResources(resources)
+
+	names := make([]string, 0, len(grouped))
+	for _, resource := range grouped {
+		names = append(names, resource.Name)
+	}
+	if want := []string{"redis", "shop", "postgres", "blog"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("grouped = %v, want %v", names, want)
+	}
+
+	shop := grouped[1]
+	services := make([]string, 0, len(shop.Services))
+	for _, service := range shop.Services {
+		services = append(services, service.Name)
+	}
+	if want := []string{"shop-db-1", "shop-web-1"}; !reflect.DeepEqual(services, want) {
+		t.Errorf("shop services = %v, want %v", services, want)
+	}
+	if shop.Type != "stack" || shop.Status != stackStatusDegraded || shop.CreatedAt != 20 {
+		t.Errorf("shop = type %q status %q created %d, want stack %s 20", shop.Type, shop.Status, shop.CreatedAt, stackStatusDegraded)
+	}
+	if want := []string{"8080", "5432"}; !reflect.DeepEqual(shop.HostPorts, want) {
+		t.Errorf("shop host ports = %v, want %v", shop.HostPorts, want)
+	}
+	if grouped[3].Status != stackStatusRunning {
+		t.Errorf("blog status = %q, want %s", grouped[3].Status, stackStatusRunning)
+	}
+}
@@ -0,0 +1,93 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/template"
+)
+
+func (a *ContainerApp) ListTemplates(ctx context.Context) ([]domain.Template, error) {
+	return a.templateService.ListTemplates(ctx)
+}
+
+func (a *ContainerApp) GetTemplate(ctx context.Context, name string) (*domain.Template, error) {
+	return a.templateService.GetTemplate(ctx, name)
+}
+
+func (a *ContainerApp) CreateFromTemplate(ctx context.Context, name string, req domain.TemplateRequest) (*domain.TemplateInstance, error) {
+	if err := a.containerService.PingDaemon(ctx); err != nil {
+		return nil, err
+	}
+	t, err := a.templateService.GetTemplate(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	spec, instance, err := template.Render(*t, req)
+	if err != nil {
+		return nil, err
+	}
+	timers, err := newLifecycleRequest(req.TTL, req.TTLAction, req.IdleTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := a.containerService.FindContainer(ctx, spec.Name)
+	if err != nil {
+		return nil, err
+	}
+	if existing.ID != "" {
+		return nil, fmt.Errorf("resource %s already exists", spec.Name)
+	}
+
+	spec.Labels[resourceNameLabel] = spec.Name
+	spec.Labels[resourceTypeLabel] = t.Type
+	spec.Labels[templateLabel] = t.Name
+	if err := readinessLabels(t.Readiness, spec.Labels); err != nil {
+		return nil, fmt.Errorf("template %s: %w", t.Name, err)
+	}
+	for _, volume := range instance.Volumes {
+		exists, err := a.containerService.VolumeExists(ctx, volume)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			continue
+		}
+		if err := a.containerService.CreateVolume(ctx, &domain.VolumeSpec{
+			Name:   volume,
+			Labels: map[string]string{resourceNameLabel: spec.Name, templateLabel: t.Name},
+		}); err != nil {
+			return nil, fmt.Errorf("failed to create volume %s: %w", volume, err)
+		}
+	}
+
+	a.operationPhase(ctx, domain.PhasePulling, "pulling %s", spec.Image)
+	if err := a.containerService.EnsureImage(ctx, spec.Image); err != nil {
+		return nil, err
+	}
+	a.operationPhase(ctx, domain.PhaseCreating, "creating %s", spec.Name)
+	created, err := a.containerService.CreateContainerFromSpec(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+	a.operationPhase(ctx, domain.PhaseStarting, "starting %s", spec.Name)
+	if err := a.containerService.StartContainer(ctx, created.ID); err != nil {
+		return nil, err
+	}
+	inspect, err := a.containerService.InsepectContainer(ctx, created.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.trackResource(ctx, inspect, domain.DesiredRunning, true); err != nil {
+		return nil, err
+	}
+	if timers != nil {
+		if _, err := a.SetLifecycle(ctx, created.ID, *timers); err != nil {
+			return nil, err
+		}
+	}
+	instance.Resource = buildDevconStatus(inspect, false)
+	return instance, nil
+}
@@ -0,0 +1,219 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/config"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+	"github.com/moby/moby/api/types/mount"
+)
+
+const (
+	eventTrashed  = "trashed"
+	eventRestored = "restored"
+	eventPurged   = "purged"
+)
+
+const trashPurgeInterval = time.Minute
+
+// DeleteResource moves a resource to the trash: its container is stopped and
+// kept until the retention passes. With req.Purge, or req.Confirm set to the
+// resource name, the container is removed right away instead. The returned
+// entry is nil when nothing went to the trash.
+func (a *ContainerApp) DeleteResource(ctx context.Context, id string, req domain.DeleteRequest) (*domain.TrashEntry, error) {
+	if id == "" {
+		return nil, fmt.Errorf("container id cannot be empty")
+	}
+	inspect, err := a.containerService.InsepectContainer(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	name := firstContainerName([]string{inspect.Container.Name})
+	if req.Confirm != "" && req.Confirm != name {
+		return nil, fmt.Errorf("confirmation %q does not match resource %s", req.Confirm, name)
+	}
+	if req.Purge || req.Confirm != "" {
+		if err := a.untrackResource(ctx, id); err != nil {
+			return nil, err
+		}
+		if err := a.containerService.DeleteContainer(ctx, id); err != nil {
+			return nil, err
+		}
+		if err := a.trashService.DeleteTrash(ctx, name); err != nil {
+			return nil, err
+		}
+		a.publishEvent(eventPurged, name, "deleted without going to the trash")
+		return nil, nil
+	}
+
+	existing, err := a.trashService.GetTrash(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return existing, nil
+	}
+
+	summary, err := a.containerService.FindContainer(ctx, inspect.Container.ID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	entry := &domain.TrashEntry{
+		Resource:    name,
+		ContainerID: inspect.Container.ID,
+		Type:        a.detectType(summary),
+		WasRunning:  inspect.Container.State.Running,
+		DeletedAt:   now.Unix(),
+		PurgeAt:     now.Add(config.TrashRetention()).Unix(),
+	}
+	if inspect.Container.Config != nil {
+		entry.Image = inspect.Container.Config.Image
+	}
+	for _, m := range inspect.Container.Mounts {
+		if m.Type == mount.TypeVolume && m.Name != "" {
+			entry.Volumes = append(entry.Volumes, m.Name)
+		}
+	}
+	if entry.Desired, err = a.reconcileService.GetDesiredState(ctx, name); err != nil {
+		return nil, err
+	}
+	if entry.Lifecycle, err = a.lifecycleService.GetLifecycle(ctx, name); err != nil {
+		return nil, err
+	}
+
+	// Untrack before stopping so the reconciler does not start it again.
+	if err := a.untrackResource(ctx, id); err != nil {
+		return nil, err
+	}
+	if entry.WasRunning {
+		if err := a.containerService.StopContainer(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+	if err := a.trashService.SaveTrash(ctx, entry); err != nil {
+		return nil, err
+	}
+	a.publishEvent(eventTrashed, name, "moved to the trash until %s", time.Unix(entry.PurgeAt, 0).Format(time.RFC3339))
+	return entry, nil
+}
+
+func (a *ContainerApp) ListTrash(ctx context.Context) ([]domain.TrashEntry, error) {
+	return a.trashService.ListTrash(ctx)
+}
+
+func (a *ContainerApp) getTrash(ctx context.Context, resource string) (*domain.TrashEntry, error) {
+	entry, err := a.trashService.GetTrash(ctx, strings.TrimPrefix(resource, "/"))
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, fmt.Errorf("resource %s is not in the trash", resource)
+	}
+	return entry, nil
+}
+
+// RestoreResource takes a resource out of the trash, puts its tracking and
+// timers back and starts it again if it was running. An expired TTL is
+// dropped so the resource is not deleted again straight away.
+func (a *ContainerApp) RestoreResource(ctx context.Context, resource string) (*domain.TrashEntry, error) {
+	entry, err := a.getTrash(ctx, resource)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := a.containerService.InsepectContainer(ctx, entry.ContainerID); err != nil {
+		return nil, fmt.Errorf("container of %s is gone: %w", entry.Resource, err)
+	}
+	if err := a.trashService.DeleteTrash(ctx, entry.Resource); err != nil {
+		return nil, err
+	}
+
+	if entry.Desired != nil {
+		if err := a.reconcileService.SaveDesiredState(ctx, entry.Desired); err != nil {
+			return nil, err
+		}
+	}
+	if lifecycle := entry.Lifecycle; lifecycle != nil {
+		if lifecycle.ExpiresAt != 0 && lifecycle.ExpiresAt <= time.Now().Unix() {
+			lifecycle.ExpiresAt, lifecycle.ExpiryAction, lifecycle.ExpiryWarned = 0, "", false
+		}
+		lifecycle.LastActiveAt = time.Now().Unix()
+		lifecycle.IdleWarned = false
+		if err := a.lifecycleService.SaveLifecycle(ctx, lifecycle); err != nil {
+			return nil, err
+		}
+	}
+	if entry.WasRunning {
+		if err := a.Start(ctx, entry.ContainerID); err != nil {
+			return nil, err
+		}
+	}
+	a.publishEvent(eventRestored, entry.Resource, "restored from the trash")
+	return entry, nil
+}
+
+// PurgeResource removes a trashed resource for good.
+func (a *ContainerApp) PurgeResource(ctx context.Context, resource string) error {
+	entry, err := a.getTrash(ctx, resource)
+	if err != nil {
+		return err
+	}
+	return a.purgeTrash(ctx, *entry)
+}
+
+func (a *ContainerApp) purgeTrash(ctx context.Context, entry domain.TrashEntry) error {
+	// The container may already have been removed outside devcon.
+	if _, err := a.containerService.InsepectContainer(ctx, entry.ContainerID); err == nil {
+		if err := a.containerService.DeleteContainer(ctx, entry.ContainerID); err != nil {
+			return err
+		}
+	}
+	if err := a.trashService.DeleteTrash(ctx, entry.Resource); err != nil {
+		return err
+	}
+	a.publishEvent(eventPurged, entry.Resource, "removed from the trash")
+	return nil
+}
+
+// StartTrashPurger removes trashed resources once their recovery window has
+// passed.
+func (a *ContainerApp) StartTrashPurger() {
+	go func() {
+		ticker := time.NewTicker(trashPurgeInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			ctx := context.Background()
+			entries, err := a.trashService.ListTrash(ctx)
+			if err != nil {
+				log.Println("trash:", err)
+				continue
+			}
+			now := time.Now().Unix()
+			for _, entry := range entries {
+				if entry.PurgeAt > now {
+					continue
+				}
+				if err := a.purgeTrash(ctx, entry); err != nil {
+					log.Printf("trash %s: %v", entry.Resource, err)
+				}
+			}
+		}
+	}()
+}
+
+// trashedNames returns the names of the resources in the trash.
+func (a *ContainerApp) trashedNames(ctx context.Context) (map[string]bool, error) {
+	entries, err := a.trashService.ListTrash(ctx)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		names[entry.Resource] = true
+	}
+	return names, nil
+}
@@ -0,0 +1,483 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/deps"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/detect"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/workspace"
+	"github.com/moby/moby/api/types/container"
+)
+
+const (
+	workspaceLabel = "devcon.workspace"
+	specHashLabel  = "devcon.spec_hash"
+)
+
+const (
+	workspaceCreate    = "create"
+	workspaceUpdate    = "update"
+	workspaceDelete    = "delete"
+	workspaceUnchanged = "unchanged"
+	workspaceOrphaned  = "orphaned"
+)
+
+// workspaceStep is a planned change and the function that carries it out.
+// Unchanged steps may still run to record ownership.
+type workspaceStep struct {
+	change domain.WorkspaceChange
+	run    func(ctx context.Context) error
+}
+
+func (a *ContainerApp) PlanWorkspace(ctx context.Context, req domain.WorkspaceRequest) (*domain.WorkspacePlan, error) {
+	spec, err := workspace.Parse([]byte(req.Spec), req.Dir)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.containerService.PingDaemon(ctx); err != nil {
+		return nil, err
+	}
+	steps, err := a.planWorkspace(ctx, spec, req)
+	if err != nil {
+		return nil, err
+	}
+	return workspacePlan(spec, req.Prune, steps), nil
+}
+
+// ApplyWorkspace converges docker to the spec. Things the spec no longer
+// lists are only removed when the request asks to prune.
+func (a *ContainerApp) ApplyWorkspace(ctx context.Context, req domain.WorkspaceRequest) (*domain.WorkspacePlan, error) {
+	spec, err := workspace.Parse([]byte(req.Spec), req.Dir)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.containerService.PingDaemon(ctx); err != nil {
+		return nil, err
+	}
+	steps, err := a.planWorkspace(ctx, spec, req)
+	if err != nil {
+		return nil, err
+	}
+	for _, step := range steps {
+		if step.run == nil {
+			continue
+		}
+		if err := step.run(ctx); err != nil {
+			return nil, fmt.Errorf("failed to %s %s %s: %w", step.change.Action, step.change.Kind, step.change.Name, err)
+		}
+	}
+	plan := workspacePlan(spec, req.Prune, steps)
+	plan.Applied = true
+	return plan, nil
+}
+
+func workspacePlan(spec *domain.WorkspaceSpec, prune bool, steps []workspaceStep) *domain.WorkspacePlan {
+	plan := &domain.WorkspacePlan{Workspace: spec.Name, Prune: prune, Changes: make([]domain.WorkspaceChange, 0, len(steps))}
+	for _, step := range steps {
+		plan.Changes = append(plan.Changes, step.change)
+	}
+	return plan
+}
+
+// planWorkspace diffs the spec against docker. Creates run networks first and
+// resources last; deletes run after everything else.
+func (a *ContainerApp) planWorkspace(ctx context.Context, spec *domain.WorkspaceSpec, req domain.WorkspaceRequest) ([]workspaceStep, error) {
+	steps := make([]workspaceStep, 0)
+
+	networks, err := a.containerService.ListNetworks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	networkLabels := make(map[string]map[string]string, len(networks.Items))
+	for _, n := range networks.Items {
+		networkLabels[n.Name] = n.Labels
+	}
+	for _, network := range spec.Networks {
+		steps = append(steps, a.planWorkspaceNetwork(spec.Name, network, networkLabels))
+	}
+
+	volumes, err := a.containerService.ListVolumes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	volumeLabels := make(map[string]map[string]string, len(volumes.Items))
+	for _, v := range volumes.Items {
+		volumeLabels[v.Name] = v.Labels
+	}
+	for _, volume := range spec.Volumes {
+		steps = append(steps, a.planWorkspaceVolume(spec.Name, volume, volumeLabels))
+	}
+
+	for _, stack := range spec.Stacks {
+		step, err := a.planWorkspaceStack(ctx, spec.Name, stack)
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, step)
+	}
+
+	list, err := a.containerService.ListContainers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]container.Summary, len(list.Items))
+	for _, c := range list.Items {
+		byName[firstContainerName(c.Names)] = c
+	}
+	for _, resource := range spec.Resources {
+		step, err := a.planWorkspaceResource(ctx, spec.Name, resource, byName, req.Adopt)
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, step)
+	}
+
+	orphans, err := a.planWorkspaceOrphans(ctx, spec, list.Items, networkLabels, volumeLabels)
+	if err != nil {
+		return nil, err
+	}
+	for _, step := range orphans {
+		if !req.Prune {
+			step.change.Action = workspaceOrphaned
+			step.change.Reason = "not in spec, apply with prune to delete"
+			step.run = nil
+		}
+		steps = append(steps, step)
+	}
+	return steps, nil
+}
+
+func (a *ContainerApp) planWorkspaceNetwork(ws string, network domain.WorkspaceNetwork, existing map[string]map[string]string) workspaceStep {
+	change := domain.WorkspaceChange{Kind: "network", Name: network.Name}
+	labels, ok := existing[network.Name]
+	if ok {
+		change.Action = workspaceUnchanged
+		if labels[workspaceLabel] != ws {
+			change.Reason = "exists outside the workspace"
+		}
+		return workspaceStep{change: change}
+	}
+	change.Action = workspaceCreate
+	return workspaceStep{change: change, run: func(ctx context.Context) error {
+		_, err := a.containerService.CreateNetwork(ctx, &domain.NetworkSpec{
+			Name:     network.Name,
+			Driver:   network.Driver,
+			Internal: network.Internal,
+			Labels:   withLabel(network.Labels, workspaceLabel, ws),
+		})
+		return err
+	}}
+}
+
+func (a *ContainerApp) planWorkspaceVolume(ws string, volume domain.WorkspaceVolume, existing map[string]map[string]string) workspaceStep {
+	change := domain.WorkspaceChange{Kind: "volume", Name: volume.Name}
+	labels, ok := existing[volume.Name]
+	if ok {
+		change.Action = workspaceUnchanged
+		if labels[workspaceLabel] != ws {
+			change.Reason = "exists outside the workspace"
+		}
+		return workspaceStep{change: change}
+	}
+	change.Action = workspaceCreate
+	return workspaceStep{change: change, run: func(ctx context.Context) error {
+		return a.containerService.CreateVolume(ctx, &domain.VolumeSpec{
+			Name:   volume.Name,
+			Driver: volume.Driver,
+			Labels: withLabel(volume.Labels, workspaceLabel, ws),
+		})
+	}}
+}
+
+func (a *ContainerApp) planWorkspaceStack(ctx context.Context, ws string, stack domain.WorkspaceStack) (workspaceStep, error) {
+	change := domain.WorkspaceChange{Kind: "stack", Name: stack.Name}
+	source := domain.StackSource{Compose: stack.Compose, Overrides: stack.Overrides, Profiles: stack.Profiles}
+	if stack.Path != "" {
+		dir, err := allowedImportDir(stack.Path)
+		if err != nil {
+			return workspaceStep{}, fmt.Errorf("stack %s: %w", stack.Name, err)
+		}
+		read, err := readStackDir(dir, stack.Files, stack.Profiles, stack.EnvFile)
+		if err != nil {
+			return workspaceStep{}, fmt.Errorf("stack %s: %w", stack.Name, err)
+		}
+		source = *read
+	}
+	project, _, err := parseComposeStack(stack.Name, source)
+	if err != nil {
+		return workspaceStep{}, fmt.Errorf("stack %s: %w", stack.Name, err)
+	}
+
+	containers, err := a.containerService.FindContainersByComposeProject(ctx, project.Name)
+	if err != nil {
+		return workspaceStep{}, err
+	}
+	drift := composeDrift(project, containers)
+	switch {
+	case len(containers) == 0:
+		change.Action = workspaceCreate
+	case len(drift) > 0:
+		change.Action = workspaceUpdate
+		change.Reason = strings.Join(drift, ", ")
+	default:
+		change.Action = workspaceUnchanged
+	}
+
+	claim := func(ctx context.Context) error {
+		return a.stackService.SetWorkspace(ctx, project.Name, ws)
+	}
+	if change.Action == workspaceUnchanged {
+		return workspaceStep{change: change, run: claim}, nil
+	}
+	return workspaceStep{change: change, run: func(ctx context.Context) error {
+		if _, err := a.applyStack(ctx, stack.Name, source, "applied from workspace "+ws); err != nil {
+			return err
+		}
+		return claim(ctx)
+	}}, nil
+}
+
+// composeDrift describes how the running containers differ from project.
+// Services that are built locally only count when they are missing, since
+// their hash depends on the image the next build produces.
+func composeDrift(project *domain.ComposeProject, containers []container.Summary) []string {
+	byService := make(map[string][]container.Summary)
+	for _, c := range containers {
+		byService[c.Labels[composeServiceLabel]] = append(byService[c.Labels[composeServiceLabel]], c)
+	}
+	drift := make([]string, 0)
+	declared := make(map[string]bool, len(project.Services))
+	for _, service := range project.Services {
+		declared[service.Name] = true
+		current := byService[service.Name]
+		switch {
+		case len(current) == 0:
+			drift = append(drift, service.Name+" missing")
+		case service.Build == nil && !replicasMatch(current, composeConfigHash(project, service)):
+			drift = append(drift, service.Name+" changed")
+		default:
+			for _, c := range current {
+				if c.State != "running" {
+					drift = append(drift, service.Name+" stopped")
+					break
+				}
+			}
+		}
+	}
+	extra := make([]string, 0)
+	for name := range byService {
+		if !declared[name] {
+			extra = append(extra, name+" removed")
+		}
+	}
+	sort.Strings(extra)
+	return append(drift, extra...)
+}
+
+// planWorkspaceResource plans one resource. A resource that needs replacing
+// is only removed once its replacement has started; until then a failure puts
+// it back.
+func (a *ContainerApp) planWorkspaceResource(ctx context.Context, ws string, resource domain.WorkspaceResource, byName map[string]container.Summary, adopt bool) (workspaceStep, error) {
+	change := domain.WorkspaceChange{Kind: "resource", Name: resource.Name}
+	hash := workspace.Hash(resource)
+	existing, ok := byName[resource.Name]
+	if ok && existing.Labels[composeProjectLabel] != "" {
+		return workspaceStep{}, fmt.Errorf("resource %s: name is used by stack %s", resource.Name, existing.Labels[composeProjectLabel])
+	}
+	if ok && existing.Labels[workspaceLabel] != ws && !adopt {
+		return workspaceStep{}, fmt.Errorf("resource %s exists outside the workspace; apply with adopt to replace it", resource.Name)
+	}
+	if _, err := a.checkDependencies(ctx, resource.Name, resource.DependsOn, false); err != nil {
+		return workspaceStep{}, fmt.Errorf("resource %s: %w", resource.Name, err)
+	}
+
+	switch {
+	case !ok:
+		change.Action = workspaceCreate
+	case existing.Labels[workspaceLabel] != ws:
+		change.Action = workspaceUpdate
+		change.Reason = "adopted from outside the workspace"
+	case existing.Labels[specHashLabel] != hash:
+		change.Action = workspaceUpdate
+		change.Reason = "definition changed"
+	case existing.State != "running":
+		change.Action = workspaceUpdate
+		change.Reason = "not running"
+		return workspaceStep{change: change, run: func(ctx context.Context) error {
+			return a.Start(ctx, existing.ID)
+		}}, nil
+	default:
+		change.Action = workspaceUnchanged
+		return workspaceStep{change: change}, nil
+	}
+
+	return workspaceStep{change: change, run: func(ctx context.Context) error {
+		// Earlier steps may have created resources, so check again before
+		// anything is touched.
+		if _, err := a.checkDependencies(ctx, resource.Name, resource.DependsOn, false); err != nil {
+			return err
+		}
+		if err := a.containerService.EnsureImage(ctx, resource.Image); err != nil {
+			return err
+		}
+		create := func() (string, error) {
+			created, err := a.containerService.CreateContainerFromSpec(ctx, a.workspaceContainerSpec(ws, resource, hash))
+			if err != nil {
+				return "", err
+			}
+			return created.ID, a.Start(ctx, created.ID)
+		}
+		var id string
+		var err error
+		if ok {
+			id, err = a.replaceContainer(ctx, existing.ID, resource.Name, existing.State == "running", create)
+		} else if id, err = create(); err != nil && id != "" {
+			_ = a.containerService.DeleteContainer(ctx, id)
+		}
+		if err != nil {
+			return err
+		}
+		inspect, err := a.containerService.InsepectContainer(ctx, id)
+		if err != nil {
+			return err
+		}
+		return a.trackResource(ctx, inspect, domain.DesiredRunning, true)
+	}}, nil
+}
+
+func (a *ContainerApp) workspaceContainerSpec(ws string, resource domain.WorkspaceResource, hash string) *domain.ContainerSpec {
+	spec := &domain.ContainerSpec{
+		Name:          resource.Name,
+		Image:         resource.Image,
+		Command:       resource.Command,
+		Env:           resource.Env,
+		Ports:         workspace.Ports(resource),
+		Mounts:        workspace.Mounts(resource),
+		RestartPolicy: resource.Restart,
+		Labels:        withLabel(resource.Labels, resourceNameLabel, resource.Name),
+	}
+	resourceType := resource.Type
+	if resourceType == "" {
+		ports := make([]string, 0, len(spec.Ports))
+		for _, port := range spec.Ports {
+			ports = append(ports, port.ContainerPort+"/"+port.Protocol)
+		}
+		resourceType = a.detector.Detect(detect.Subject{Image: resource.Image, Ports: ports, Command: strings.Join(resource.Command, " ")})
+	}
+	spec.Labels[resourceTypeLabel] = resourceType
+	spec.Labels[workspaceLabel] = ws
+	spec.Labels[specHashLabel] = hash
+	if len(resource.DependsOn) > 0 {
+		spec.Labels[dependsOnLabel] = deps.Encode(resource.DependsOn)
+	}
+	for _, network := range resource.Networks {
+		spec.Networks = append(spec.Networks, domain.NetworkAttachment{Name: network, Aliases: []string{resource.Name}})
+	}
+	return spec
+}
+
+// planWorkspaceOrphans finds what this workspace created earlier that the
+// spec no longer lists. Things created outside the workspace are never
+// touched.
+func (a *ContainerApp) planWorkspaceOrphans(ctx context.Context, spec *domain.WorkspaceSpec, containers []container.Summary, networks, volumes map[string]map[string]string) ([]workspaceStep, error) {
+	listed := make(map[string]bool)
+	for _, r := range spec.Resources {
+		listed["resource/"+r.Name] = true
+	}
+	for _, s := range spec.Stacks {
+		listed["stack/"+composeProjectName(s.Name)] = true
+	}
+	for _, v := range spec.Volumes {
+		listed["volume/"+v.Name] = true
+	}
+	for _, n := range spec.Networks {
+		listed["network/"+n.Name] = true
+	}
+
+	trashed, err := a.trashedNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+	steps := make([]workspaceStep, 0)
+	running := make(map[string]bool)
+	for _, c := range containers {
+		if project := c.Labels[composeProjectLabel]; project != "" {
+			running[project] = true
+		}
+		name := firstContainerName(c.Names)
+		if c.Labels[workspaceLabel] != spec.Name || c.Labels[composeProjectLabel] != "" || listed["resource/"+name] || trashed[name] {
+			continue
+		}
+		id := c.ID
+		steps = append(steps, workspaceStep{
+			change: domain.WorkspaceChange{Kind: "resource", Name: name, Action: workspaceDelete},
+			run:    func(ctx context.Context) error { return a.Delete(ctx, id) },
+		})
+	}
+
+	stacks, err := a.stackService.ListStacks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, stack := range stacks {
+		if stack.Workspace != spec.Name || listed["stack/"+stack.Project] || !running[stack.Project] {
+			continue
+		}
+		project := stack.Project
+		steps = append(steps, workspaceStep{
+			change: domain.WorkspaceChange{Kind: "stack", Name: stack.Name, Action: workspaceDelete},
+			run: func(ctx context.Context) error {
+				if _, err := a.DownStack(ctx, project, domain.StackDownOptions{}); err != nil {
+					return err
+				}
+				return a.stackService.SetWorkspace(ctx, project, "")
+			},
+		})
+	}
+
+	for _, name := range sortedLabelOwners(volumes, spec.Name) {
+		if listed["volume/"+name] {
+			continue
+		}
+		volume := name
+		steps = append(steps, workspaceStep{
+			change: domain.WorkspaceChange{Kind: "volume", Name: volume, Action: workspaceDelete},
+			run:    func(ctx context.Context) error { return a.containerService.RemoveVolume(ctx, volume) },
+		})
+	}
+	for _, name := range sortedLabelOwners(networks, spec.Name) {
+		if listed["network/"+name] {
+			continue
+		}
+		network := name
+		steps = append(steps, workspaceStep{
+			change: domain.WorkspaceChange{Kind: "network", Name: network, Action: workspaceDelete},
+			run:    func(ctx context.Context) error { return a.containerService.RemoveNetwork(ctx, network) },
+		})
+	}
+	return steps, nil
+}
+
+func sortedLabelOwners(items map[string]map[string]string, ws string) []string {
+	names := make([]string, 0)
+	for name, labels := range items {
+		if labels[workspaceLabel] == ws {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func withLabel(labels map[string]string, key, value string) map[string]string {
+	merged := make(map[string]string, len(labels)+1)
+	for k, v := range labels {
+		merged[k] = v
+	}
+	merged[key] = value
+	return merged
+}
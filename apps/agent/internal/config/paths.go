@@ -0,0 +1,102 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/util"
+)
+
+func DataDir() string {
+	if dir := util.GodotEnv("DEVCON_DATA_DIR"); dir != "" {
+		return dir
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(os.TempDir(), "devcon")
+	}
+	return filepath.Join(home, ".devcon")
+}
+
+// ImportAllowlist lists the host directories stacks may be imported from,
+// read from DEVCON_IMPORT_ALLOWLIST and defaulting to the home directory.
+func ImportAllowlist() []string {
+	if list := util.GodotEnv("DEVCON_IMPORT_ALLOWLIST"); list != "" {
+		return filepath.SplitList(list)
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil
+	}
+	return []string{home}
+}
+
+func TemplatesDir() string {
+	if dir := util.GodotEnv("DEVCON_TEMPLATES_DIR"); dir != "" {
+		return dir
+	}
+	return filepath.Join(DataDir(), "templates")
+}
+
+func DetectRulesFile() string {
+	if file := util.GodotEnv("DEVCON_DETECT_RULES"); file != "" {
+		return file
+	}
+	return filepath.Join(DataDir(), "detect.yaml")
+}
+
+func BackupsDir() string {
+	if dir := util.GodotEnv("DEVCON_BACKUPS_DIR"); dir != "" {
+		return dir
+	}
+	return filepath.Join(DataDir(), "backups")
+}
+
+// DriftPolicy is the policy given to resources devcon starts tracking, read
+// from DEVCON_DRIFT_POLICY.
+func DriftPolicy() string {
+	if policy := util.GodotEnv("DEVCON_DRIFT_POLICY"); policy != "" {
+		return policy
+	}
+	return "auto_fix"
+}
+
+// envDuration reads a positive Go duration such as "90s" or "12h" from the
+// environment variable key, falling back to def when it is unset or invalid.
+func envDuration(key string, def time.Duration) time.Duration {
+	if d, err := time.ParseDuration(util.GodotEnv(key)); err == nil && d > 0 {
+		return d
+	}
+	return def
+}
+
+// ReconcileInterval is how often every tracked resource is compared with
+// docker.
+func ReconcileInterval() time.Duration {
+	return envDuration("DEVCON_RECONCILE_INTERVAL", 30*time.Second)
+}
+
+// LifecycleWarning is how long before a TTL or idle stop fires that a warning
+// event is published.
+func LifecycleWarning() time.Duration {
+	return envDuration("DEVCON_LIFECYCLE_WARNING", 5*time.Minute)
+}
+
+// GCMaxAge is how long a devcon artifact must be unused before garbage
+// collection removes it.
+func GCMaxAge() time.Duration {
+	return envDuration("DEVCON_GC_MAX_AGE", 7*24*time.Hour)
+}
+
+// OperationRetention is how long finished operations are kept for clients to
+// read.
+func OperationRetention() time.Duration {
+	return envDuration("DEVCON_OPERATION_RETENTION", time.Hour)
+}
+
+// TrashRetention is how long a deleted resource can be restored before the
+// purge job removes it.
+func TrashRetention() time.Duration {
+	return envDuration("DEVCON_TRASH_RETENTION", 24*time.Hour)
+}
@@ -0,0 +1,198 @@
+package backup
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/connection"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/postgres"
+	"github.com/robfig/cron/v3"
+)
+
+const (
+	FormatPgDump    = "pg_dump"
+	FormatMySQLDump = "mysqldump"
+	FormatRDB       = "rdb"
+
+	TriggerManual   = "manual"
+	TriggerSchedule = "schedule"
+)
+
+// Plan is a command to exec in the resource container. Prefix is streamed
+// to the command's stdin ahead of the dump when restoring.
+type Plan struct {
+	Format    string
+	Extension string
+	Database  string
+	Cmd       []string
+	Env       []string
+	Prefix    string
+}
+
+// mysqlShell runs the MariaDB client binaries when present, since recent
+// MariaDB images no longer ship the mysql* names.
+const mysqlShell = `preferred=$1 fallback=$2; shift 2; if command -v "$preferred" >/dev/null 2>&1; then exec "$preferred" "$@"; fi; exec "$fallback" "$@"`
+
+// DumpPlan builds the command that writes a logical dump to stdout. An empty
+// database selects the resource's configured one; MySQL resources without
+// one are dumped whole.
+func DumpPlan(resourceType string, env map[string]string, database string) (*Plan, error) {
+	switch resourceType {
+	case "postgres":
+		creds, _ := connection.CredentialsFor(resourceType, env)
+		if database == "" {
+			database = creds.Database
+		}
+		return &Plan{
+			Format:    FormatPgDump,
+			Extension: ".sql",
+			Database:  database,
+			Cmd:       []string{"pg_dump", "-U", creds.Username, "-d", database, "--clean", "--if-exists", "--no-owner", "--no-acl"},
+			Env:       passwordEnv("PGPASSWORD", creds.Password),
+		}, nil
+	case "mysql":
+		user, password, configured := mysqlCredentials(env)
+		if database == "" {
+			database = configured
+		}
+		args := []string{"--single-transaction", "--routines", "--triggers", "-u", user}
+		if database == "" {
+			args = append(args, "--all-databases")
+		} else {
+			args = append(args, database)
+		}
+		return &Plan{
+			Format:    FormatMySQLDump,
+			Extension: ".sql",
+			Database:  database,
+			Cmd:       mysqlCommand("mariadb-dump", "mysqldump", args),
+			Env:       passwordEnv("MYSQL_PWD", password),
+		}, nil
+	case "redis":
+		creds, _ := connection.CredentialsFor(resourceType, env)
+		script := `redis-cli --rdb /tmp/devcon-backup.rdb >&2 && cat /tmp/devcon-backup.rdb; status=$?; rm -f /tmp/devcon-backup.rdb; exit $status`
+		return &Plan{
+			Format:    FormatRDB,
+			Extension: ".rdb",
+			Cmd:       []string{"sh", "-c", script},
+			Env:       passwordEnv("REDISCLI_AUTH", creds.Password),
+		}, nil
+	}
+	return nil, fmt.Errorf("backups are not supported for resource type %s", resourceType)
+}
+
+// RestorePlan builds the command that reads a SQL dump from stdin. The
+// database is created first when it does not exist. RDB restores replace the
+// data file instead and are handled by the caller.
+func RestorePlan(b domain.Backup, env map[string]string, database string) (*Plan, error) {
+	if database == "" {
+		database = b.Database
+	}
+	switch b.Format {
+	case FormatPgDump:
+		creds, _ := connection.CredentialsFor("postgres", env)
+		if database == "" {
+			database = creds.Database
+		}
+		quoted, err := postgres.QuoteIdent(database)
+		if err != nil {
+			return nil, err
+		}
+		prefix := fmt.Sprintf("SELECT %s WHERE NOT EXISTS (SELECT 1 FROM pg_database WHERE datname = %s)\\gexec\n\\connect %s\n",
+			postgres.QuoteLiteral("CREATE DATABASE "+quoted), postgres.QuoteLiteral(database), quoted)
+		return &Plan{
+			Format:   b.Format,
+			Database: database,
+			Cmd:      []string{"psql", "-X", "-q", "-v", "ON_ERROR_STOP=1", "-U", creds.Username, "-d", "postgres"},
+			Env:      passwordEnv("PGPASSWORD", creds.Password),
+			Prefix:   prefix,
+		}, nil
+	case FormatMySQLDump:
+		user, password, _ := mysqlCredentials(env)
+		plan := &Plan{
+			Format:   b.Format,
+			Database: database,
+			Cmd:      mysqlCommand("mariadb", "mysql", []string{"-u", user}),
+			Env:      passwordEnv("MYSQL_PWD", password),
+		}
+		if database != "" {
+			quoted := "`" + strings.ReplaceAll(database, "`", "``") + "`"
+			plan.Prefix = "CREATE DATABASE IF NOT EXISTS " + quoted + ";\nUSE " + quoted + ";\n"
+		}
+		return plan, nil
+	}
+	return nil, fmt.Errorf("backup format %s cannot be restored through a client", b.Format)
+}
+
+// FormatFor reports the dump format a resource type produces.
+func FormatFor(resourceType string) string {
+	switch resourceType {
+	case "postgres":
+		return FormatPgDump
+	case "mysql":
+		return FormatMySQLDump
+	case "redis":
+		return FormatRDB
+	}
+	return ""
+}
+
+// mysqlCredentials prefers root so dumps include routines and triggers.
+func mysqlCredentials(env map[string]string) (string, string, string) {
+	creds, _ := connection.CredentialsFor("mysql", env)
+	for _, key := range []string{"MYSQL_ROOT_PASSWORD", "MARIADB_ROOT_PASSWORD"} {
+		if password := env[key]; password != "" {
+			return "root", password, creds.Database
+		}
+	}
+	return creds.Username, creds.Password, creds.Database
+}
+
+func mysqlCommand(preferred, fallback string, args []string) []string {
+	return append([]string{"sh", "-c", mysqlShell, "sh", preferred, fallback}, args...)
+}
+
+func passwordEnv(name, password string) []string {
+	if password == "" {
+		return nil
+	}
+	return []string{name + "=" + password}
+}
+
+var idRegexp = regexp.MustCompile(`[^a-zA-Z0-9_.-]+`)
+
+func NewID(resource string, at time.Time) string {
+	return idRegexp.ReplaceAllString(resource, "-") + "-" + at.UTC().Format("20060102-150405.000")
+}
+
+func ValidateSchedule(schedule string) error {
+	if strings.TrimSpace(schedule) == "" {
+		return fmt.Errorf("schedule cannot be empty")
+	}
+	if _, err := cron.ParseStandard(schedule); err != nil {
+		return fmt.Errorf("invalid schedule %q: %w", schedule, err)
+	}
+	return nil
+}
+
+// Expired returns the backups retention removes. The newest is always kept.
+func Expired(backups []domain.Backup, retention domain.BackupRetention, now time.Time) []domain.Backup {
+	sorted := append([]domain.Backup(nil), backups...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].CreatedAt > sorted[j].CreatedAt })
+
+	expired := make([]domain.Backup, 0)
+	cutoff := now.AddDate(0, 0, -retention.MaxAgeDays).Unix()
+	for i, b := range sorted {
+		if i == 0 {
+			continue
+		}
+		if (retention.KeepLast > 0 && i >= retention.KeepLast) || (retention.MaxAgeDays > 0 && b.CreatedAt < cutoff) {
+			expired = append(expired, b)
+		}
+	}
+	return expired
+}
@@ -0,0 +1,94 @@
+package bulk
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+)
+
+const (
+	DefaultConcurrency = 4
+	MaxConcurrency     = 16
+)
+
+// Validate checks the action and request and fills in the default
+// concurrency. Something must be selected so a bare request never acts on
+// every resource.
+func Validate(action string, req *domain.BulkRequest) error {
+	switch action {
+	case domain.BulkStart, domain.BulkStop, domain.BulkRestart, domain.BulkDelete:
+	default:
+		return fmt.Errorf("invalid action %q: use start, stop, restart or delete", action)
+	}
+	if len(req.IDs) == 0 && strings.TrimSpace(req.Type) == "" && strings.TrimSpace(req.Selector) == "" {
+		return fmt.Errorf("select resources by ids, type or selector")
+	}
+	if _, err := ParseSelector(req.Selector); err != nil {
+		return err
+	}
+	switch {
+	case req.Concurrency == 0:
+		req.Concurrency = DefaultConcurrency
+	case req.Concurrency < 0 || req.Concurrency > MaxConcurrency:
+		return fmt.Errorf("concurrency must be between 1 and %d", MaxConcurrency)
+	}
+	return nil
+}
+
+type requirement struct {
+	key    string
+	value  string
+	negate bool
+	exists bool
+}
+
+// Selector matches labels against every one of its requirements.
+type Selector []requirement
+
+// ParseSelector reads "key=value", "key==value", "key!=value", "key" and
+// "!key" requirements separated by commas.
+func ParseSelector(expr string) (Selector, error) {
+	var selector Selector
+	for _, part := range strings.Split(expr, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		var r requirement
+		switch {
+		case strings.Contains(part, "!="):
+			r.key, r.value, _ = strings.Cut(part, "!=")
+			r.negate = true
+		case strings.Contains(part, "="):
+			r.key, r.value, _ = strings.Cut(part, "=")
+			r.value = strings.TrimPrefix(r.value, "=")
+		case strings.HasPrefix(part, "!"):
+			r.key, r.exists, r.negate = part[1:], true, true
+		default:
+			r.key, r.exists = part, true
+		}
+		r.key, r.value = strings.TrimSpace(r.key), strings.TrimSpace(r.value)
+		if r.key == "" {
+			return nil, fmt.Errorf("invalid selector requirement %q", part)
+		}
+		selector = append(selector, r)
+	}
+	return selector, nil
+}
+
+func (s Selector) Matches(labels map[string]string) bool {
+	for _, r := range s {
+		value, ok := labels[r.key]
+		var match bool
+		if r.exists {
+			match = ok
+		} else {
+			match = ok && value == r.value
+		}
+		if match == r.negate {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,80 @@
+package bulk
+
+import (
+	"testing"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+)
+
+func TestSelectorMatches(t *testing.T) {
+	labels := map[string]string{"env": "dev", "tier": "db", "devcon.protected": "true"}
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{"", true},
+		{"env=dev", true},
+		{"env==dev", true},
+		{"env=prod", false},
+		{"env!=prod", true},
+		{"env!=dev", false},
+		{"missing!=x", true},
+		{"tier", true},
+		{"missing", false},
+		{"!missing", true},
+		{"!devcon.protected", false},
+		{"env=dev, tier=db", true},
+		{"env=dev,tier=cache", false},
+		{" env = dev ,, ", true},
+	}
+	for _, tt := range tests {
+		selector, err := ParseSelector(tt.expr)
+		if err != nil {
+			t.Errorf("ParseSelector(%q): %v", tt.expr, err)
+			continue
+		}
+		if got := selector.Matches(labels); got != tt.want {
+			t.Errorf("ParseSelector(%q).Matches = %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestParseSelectorErrors(t *testing.T) {
+	for _, expr := range []string{"=dev", "!=dev", "!", "env=dev,=x"} {
+		if _, err := ParseSelector(expr); err == nil {
+			t.Errorf("ParseSelector(%q) succeeded, want an error", expr)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name            string
+		action          string
+		req             domain.BulkRequest
+		wantErr         bool
+		wantConcurrency int
+	}{
+		{"ids", domain.BulkStart, domain.BulkRequest{IDs: []string{"api"}}, false, DefaultConcurrency},
+		{"type", domain.BulkStop, domain.BulkRequest{Type: "postgres", Concurrency: 2}, false, 2},
+		{"selector", domain.BulkDelete, domain.BulkRequest{Selector: "env=dev"}, false, DefaultConcurrency},
+		{"max concurrency", domain.BulkRestart, domain.BulkRequest{Type: "redis", Concurrency: MaxConcurrency}, false, MaxConcurrency},
+		{"unknown action", "pause", domain.BulkRequest{IDs: []string{"api"}}, true, 0},
+		{"nothing selected", domain.BulkStart, domain.BulkRequest{Type: " "}, true, 0},
+		{"bad selector", domain.BulkStart, domain.BulkRequest{Selector: "=x"}, true, 0},
+		{"negative concurrency", domain.BulkStart, domain.BulkRequest{IDs: []string{"api"}, Concurrency: -1}, true, 0},
+		{"too much concurrency", domain.BulkStart, domain.BulkRequest{IDs: []string{"api"}, Concurrency: MaxConcurrency + 1}, true, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := tt.req
+			err := Validate(tt.action, &req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && req.Concurrency != tt.wantConcurrency {
+				t.Errorf("Concurrency = %d, want %d", req.Concurrency, tt.wantConcurrency)
+			}
+		})
+	}
+}
@@ -0,0 +1,257 @@
+package compose
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+)
+
+// ParseEnvFile reads a .env document: KEY=value lines, optional "export"
+// prefixes, # comments and single or double quoted values.
+func ParseEnvFile(content []byte) (map[string]string, error) {
+	env := make(map[string]string)
+	var issues []domain.ComposeIssue
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		text = strings.TrimPrefix(text, "export ")
+		key, value, ok := strings.Cut(text, "=")
+		key = strings.TrimSpace(key)
+		if !ok || !isName(key) {
+			issues = append(issues, domain.ComposeIssue{Path: ".env", Line: line, Message: "expected KEY=value"})
+			continue
+		}
+		value, err := envFileValue(strings.TrimSpace(value))
+		if err != nil {
+			issues = append(issues, domain.ComposeIssue{Path: ".env", Line: line, Message: err.Error()})
+			continue
+		}
+		env[key] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(issues) > 0 {
+		return nil, &domain.ComposeValidationError{Issues: issues}
+	}
+	return env, nil
+}
+
+func envFileValue(value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	switch quote := value[0]; quote {
+	case '\'', '"':
+		end := strings.LastIndexByte(value, quote)
+		if end == 0 {
+			return "", fmt.Errorf("unterminated quoted value")
+		}
+		inner := value[1:end]
+		if quote == '\'' {
+			return inner, nil
+		}
+		replacer := strings.NewReplacer(`\n`, "\n", `\t`, "\t", `\"`, `"`, `\\`, `\`)
+		return replacer.Replace(inner), nil
+	}
+	if i := strings.Index(value, " #"); i >= 0 {
+		value = strings.TrimSpace(value[:i])
+	}
+	return value, nil
+}
+
+type interpolator struct {
+	env   map[string]string
+	unset map[string]bool
+}
+
+func newInterpolator(env map[string]string) *interpolator {
+	return &interpolator{env: env, unset: make(map[string]bool)}
+}
+
+// interpolate substitutes variables in every string value of the document.
+// Keys are left as written, like docker compose does.
+func (p *composeParser) interpolate(in *interpolator, path []string, value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			v[key] = p.interpolate(in, sub(path, key), item)
+		}
+		return v
+	case []any:
+		for i, item := range v {
+			v[i] = p.interpolate(in, sub(path, index(i)), item)
+		}
+		return v
+	case string:
+		expanded, err := in.expand(v)
+		if err != nil {
+			p.fail(path, "%s", err.Error())
+			return v
+		}
+		return expanded
+	default:
+		return value
+	}
+}
+
+func (in *interpolator) warnings() []string {
+	names := make([]string, 0, len(in.unset))
+	for name := range in.unset {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	warnings := make([]string, 0, len(names))
+	for _, name := range names {
+		warnings = append(warnings, fmt.Sprintf("variable %s is not set, defaulting to a blank string", name))
+	}
+	return warnings
+}
+
+func (in *interpolator) expand(s string) (string, error) {
+	if !strings.Contains(s, "$") {
+		return s, nil
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '$' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+		next := s[i+1]
+		switch {
+		case next == '$':
+			b.WriteByte('$')
+			i++
+		case next == '{':
+			end := matchingBrace(s, i+1)
+			if end < 0 {
+				return "", fmt.Errorf("unterminated variable reference in %q", s)
+			}
+			value, err := in.expandBraced(s[i+2 : end])
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(value)
+			i = end
+		case isNameStart(next):
+			j := i + 1
+			for j < len(s) && isNameChar(s[j]) {
+				j++
+			}
+			b.WriteString(in.lookup(s[i+1 : j]))
+			i = j - 1
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String(), nil
+}
+
+func (in *interpolator) expandBraced(expr string) (string, error) {
+	name, op, arg := expr, "", ""
+	for i := 0; i < len(expr); i++ {
+		if isNameChar(expr[i]) {
+			continue
+		}
+		name = expr[:i]
+		rest := expr[i:]
+		for _, candidate := range []string{":-", ":?", ":+", "-", "?", "+"} {
+			if strings.HasPrefix(rest, candidate) {
+				op, arg = candidate, rest[len(candidate):]
+				break
+			}
+		}
+		if op == "" {
+			return "", fmt.Errorf("invalid variable reference ${%s}", expr)
+		}
+		break
+	}
+	if !isName(name) {
+		return "", fmt.Errorf("invalid variable reference ${%s}", expr)
+	}
+
+	value, set := in.env[name]
+	switch op {
+	case ":-":
+		if !set || value == "" {
+			return in.expand(arg)
+		}
+	case "-":
+		if !set {
+			return in.expand(arg)
+		}
+	case ":?", "?":
+		if !set || (op == ":?" && value == "") {
+			if arg == "" {
+				return "", fmt.Errorf("required variable %s is not set", name)
+			}
+			return "", fmt.Errorf("required variable %s is not set: %s", name, arg)
+		}
+	case ":+":
+		if set && value != "" {
+			return in.expand(arg)
+		}
+		return "", nil
+	case "+":
+		if set {
+			return in.expand(arg)
+		}
+		return "", nil
+	default:
+		return in.lookup(name), nil
+	}
+	return value, nil
+}
+
+func (in *interpolator) lookup(name string) string {
+	value, ok := in.env[name]
+	if !ok {
+		in.unset[name] = true
+	}
+	return value
+}
+
+func matchingBrace(s string, open int) int {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func isName(s string) bool {
+	if s == "" || !isNameStart(s[0]) {
+		return false
+	}
+	for i := 1; i < len(s); i++ {
+		if !isNameChar(s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isNameChar(c byte) bool {
+	return isNameStart(c) || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,123 @@
+package compose
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExpand(t *testing.T) {
+	env := map[string]string{"TAG": "16", "EMPTY": "", "HOST": "db"}
+	tests := []struct {
+		in      string
+		want    string
+		wantErr string
+	}{
+		{"postgres:16", "postgres:16", ""},
+		{"postgres:$TAG", "postgres:16", ""},
+		{"postgres:${TAG}-alpine", "postgres:16-alpine", ""},
+		{"$$TAG", "$TAG", ""},
+		{"cost: 5$", "cost: 5$", ""},
+		{"${MISSING}", "", ""},
+		{"${MISSING:-15}", "15", ""},
+		{"${EMPTY:-15}", "15", ""},
+		{"${EMPTY-15}", "", ""},
+		{"${MISSING-15}", "15", ""},
+		{"${MISSING:-${HOST}:5432}", "db:5432", ""},
+		{"${TAG:+set}", "set", ""},
+		{"${EMPTY:+set}", "", ""},
+		{"${EMPTY+set}", "set", ""},
+		{"${MISSING+set}", "", ""},
+		{"${TAG:?tag is required}", "16", ""},
+		{"${EMPTY?}", "", ""},
+		{"${EMPTY:?}", "", "required variable EMPTY is not set"},
+		{"${MISSING:?set MISSING first}", "", "required variable MISSING is not set: set MISSING first"},
+		{"${TAG", "", "unterminated variable reference"},
+		{"${1TAG}", "", "invalid variable reference ${1TAG}"},
+		{"${TAG/x}", "", "invalid variable reference ${TAG/x}"},
+	}
+	for _, tt := range tests {
+		got, err := newInterpolator(env).expand(tt.in)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expand(%q) error = %v, want %q", tt.in, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("expand(%q) error = %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("expand(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestExpandWarnsOnUnset(t *testing.T) {
+	in := newInterpolator(map[string]string{"SET": "1"})
+	for _, s := range []string{"$SET", "$B_UNSET", "${A_UNSET}", "${C_UNSET:-x}"} {
+		if _, err := in.expand(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := []string{
+		"variable A_UNSET is not set, defaulting to a blank string",
+		"variable B_UNSET is not set, defaulting to a blank string",
+	}
+	if got := in.warnings(); !reflect.DeepEqual(got, want) {
+		t.Errorf("warnings() = %v, want %v", got, want)
+	}
+}
+
+func TestParseEnvFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    map[string]string
+		wantErr string
+	}{
+		{
+			name:    "values",
+			content: "# comment\n\nA=1\nexport B=two\nC = spaced \nD=\n",
+			want:    map[string]string{"A": "1", "B": "two", "C": "spaced", "D": ""},
+		},
+		{
+			name:    "quotes",
+			content: "A='single $x'\nB=\"line\\nbreak\"\nC=\"quoted \\\"word\\\"\"\nD=plain # trailing comment\nE=url#fragment\n",
+			want:    map[string]string{"A": "single $x", "B": "line\nbreak", "C": `quoted "word"`, "D": "plain", "E": "url#fragment"},
+		},
+		{
+			name:    "line numbers",
+			content: "A=1\nnot a pair\nB=2\n1BAD=x\n",
+			wantErr: "invalid compose document: line 2: .env: expected KEY=value; line 4: .env: expected KEY=value",
+		},
+		{
+			name:    "unterminated quote",
+			content: "A=1\nB=\"open\n",
+			wantErr: "invalid compose document: line 2: .env: unterminated quoted value",
+		},
+		{
+			name:    "errors do not echo values",
+			content: "password hunter2\n",
+			wantErr: "invalid compose document: line 1: .env: expected KEY=value",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseEnvFile([]byte(tt.content))
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("ParseEnvFile() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseEnvFile() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseEnvFile() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
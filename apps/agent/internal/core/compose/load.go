@@ -0,0 +1,277 @@
+package compose
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+	"github.com/goccy/go-yaml"
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/parser"
+)
+
+// Load interpolates every document, merges them in order the way
+// "docker compose -f a.yml -f b.yml" does, drops services outside the active
+// profiles and parses the result. It also returns the resolved document.
+func Load(documents [][]byte, opts Options) (*domain.ComposeProject, []byte, error) {
+	if len(documents) == 0 {
+		return nil, nil, &domain.ComposeValidationError{Issues: []domain.ComposeIssue{{Message: "compose document is empty"}}}
+	}
+
+	in := newInterpolator(opts.Env)
+	var merged map[string]any
+	var file *ast.File
+	for i, content := range documents {
+		raw, f, err := loadDocument(content, in)
+		if err != nil {
+			if len(documents) > 1 {
+				markDocument(err, i+1)
+			}
+			return nil, nil, err
+		}
+		merged = mergeMaps(merged, raw, nil)
+		file = f
+	}
+
+	inactive := applyProfiles(merged, activeProfiles(opts))
+	resolved, err := yaml.Marshal(merged)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(documents) > 1 {
+		// Issues found after merging point into the resolved document.
+		if file, err = parser.ParseBytes(resolved, 0); err != nil {
+			return nil, nil, syntaxError(err)
+		}
+	}
+
+	p := &composeParser{
+		file:       file,
+		project:    opts.Project,
+		workingDir: opts.WorkingDir,
+		env:        opts.Env,
+		serviceEnv: opts.ServiceEnv,
+		inactive:   inactive,
+		warnings:   in.warnings(),
+	}
+	project := p.parseProject(merged)
+	if len(p.issues) > 0 {
+		sort.SliceStable(p.issues, func(i, j int) bool { return p.issues[i].Line < p.issues[j].Line })
+		return nil, nil, &domain.ComposeValidationError{Issues: p.issues}
+	}
+	return project, resolved, nil
+}
+
+func loadDocument(content []byte, in *interpolator) (map[string]any, *ast.File, error) {
+	if strings.TrimSpace(string(content)) == "" {
+		return nil, nil, &domain.ComposeValidationError{Issues: []domain.ComposeIssue{{Message: "compose document is empty"}}}
+	}
+	file, err := parser.ParseBytes(content, 0)
+	if err != nil {
+		return nil, nil, syntaxError(err)
+	}
+	var raw map[string]any
+	if err := yaml.Unmarshal(content, &raw); err != nil {
+		return nil, nil, syntaxError(err)
+	}
+	if raw == nil {
+		raw = make(map[string]any)
+	}
+
+	p := &composeParser{file: file}
+	p.interpolate(in, nil, raw)
+	if len(p.issues) > 0 {
+		return nil, nil, &domain.ComposeValidationError{Issues: p.issues}
+	}
+	return raw, file, nil
+}
+
+func markDocument(err error, document int) {
+	if validation, ok := err.(*domain.ComposeValidationError); ok {
+		for i := range validation.Issues {
+			validation.Issues[i].Document = document
+		}
+	}
+}
+
+func activeProfiles(opts Options) map[string]bool {
+	profiles := opts.Profiles
+	if len(profiles) == 0 && opts.Env["COMPOSE_PROFILES"] != "" {
+		profiles = strings.Split(opts.Env["COMPOSE_PROFILES"], ",")
+	}
+	active := make(map[string]bool, len(profiles))
+	for _, profile := range profiles {
+		if profile = strings.TrimSpace(profile); profile != "" {
+			active[profile] = true
+		}
+	}
+	return active
+}
+
+// applyProfiles removes services whose profiles are all inactive and returns
+// their names so dependencies on them get a useful error.
+func applyProfiles(raw map[string]any, active map[string]bool) map[string]bool {
+	inactive := make(map[string]bool)
+	services, ok := raw["services"].(map[string]any)
+	if !ok {
+		return inactive
+	}
+	for name, def := range services {
+		service, ok := def.(map[string]any)
+		if !ok {
+			continue
+		}
+		profiles, ok := service["profiles"].([]any)
+		if !ok || len(profiles) == 0 || active["*"] {
+			continue
+		}
+		enabled := false
+		for _, profile := range profiles {
+			if s, ok := profile.(string); ok && active[s] {
+				enabled = true
+			}
+		}
+		if !enabled {
+			delete(services, name)
+			inactive[name] = true
+		}
+	}
+	return inactive
+}
+
+var (
+	appendedServiceKeys = map[string]bool{"ports": true, "expose": true, "cap_add": true, "dns": true, "tmpfs": true, "extra_hosts": true}
+	mappedServiceKeys   = map[string]bool{"environment": true, "labels": true, "depends_on": true}
+)
+
+func mergeMaps(base, override map[string]any, path []string) map[string]any {
+	if base == nil {
+		return override
+	}
+	for key, value := range override {
+		current, exists := base[key]
+		if !exists {
+			base[key] = value
+			continue
+		}
+		if len(path) == 2 && path[0] == "services" {
+			base[key] = mergeServiceKey(key, current, value)
+			continue
+		}
+		currentMap, ok1 := current.(map[string]any)
+		valueMap, ok2 := value.(map[string]any)
+		if ok1 && ok2 {
+			base[key] = mergeMaps(currentMap, valueMap, sub(path, key))
+		} else {
+			base[key] = value
+		}
+	}
+	return base
+}
+
+func mergeServiceKey(key string, current, value any) any {
+	switch {
+	case mappedServiceKeys[key]:
+		currentMap, ok1 := toMapping(key, current)
+		valueMap, ok2 := toMapping(key, value)
+		if ok1 && ok2 {
+			for k, v := range valueMap {
+				currentMap[k] = v
+			}
+			return currentMap
+		}
+	case appendedServiceKeys[key]:
+		currentList, ok1 := current.([]any)
+		valueList, ok2 := value.([]any)
+		if ok1 && ok2 {
+			return appendUnique(currentList, valueList)
+		}
+	case key == "volumes":
+		currentList, ok1 := current.([]any)
+		valueList, ok2 := value.([]any)
+		if ok1 && ok2 {
+			return mergeMounts(currentList, valueList)
+		}
+	}
+	currentMap, ok1 := current.(map[string]any)
+	valueMap, ok2 := value.(map[string]any)
+	if ok1 && ok2 {
+		return mergeMaps(currentMap, valueMap, []string{key})
+	}
+	return value
+}
+
+// toMapping turns the list forms of environment, labels and depends_on into
+// their mapping forms so entries from later files replace earlier ones.
+func toMapping(key string, value any) (map[string]any, bool) {
+	switch v := value.(type) {
+	case map[string]any:
+		return v, true
+	case []any:
+		m := make(map[string]any, len(v))
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, false
+			}
+			if key == "depends_on" {
+				m[s] = map[string]any{"condition": "service_started"}
+				continue
+			}
+			k, val, hasValue := strings.Cut(s, "=")
+			if hasValue {
+				m[k] = val
+			} else {
+				m[k] = nil
+			}
+		}
+		return m, true
+	default:
+		return nil, false
+	}
+}
+
+func appendUnique(base, extra []any) []any {
+	seen := make(map[string]bool, len(base))
+	for _, item := range base {
+		seen[fmt.Sprint(item)] = true
+	}
+	for _, item := range extra {
+		if !seen[fmt.Sprint(item)] {
+			seen[fmt.Sprint(item)] = true
+			base = append(base, item)
+		}
+	}
+	return base
+}
+
+// mergeMounts lets a later file replace a mount by its container target.
+func mergeMounts(base, extra []any) []any {
+	targets := make(map[string]int, len(base))
+	for i, item := range base {
+		targets[mountTarget(item)] = i
+	}
+	for _, item := range extra {
+		target := mountTarget(item)
+		if i, ok := targets[target]; ok && target != "" {
+			base[i] = item
+			continue
+		}
+		targets[target] = len(base)
+		base = append(base, item)
+	}
+	return base
+}
+
+func mountTarget(item any) string {
+	switch v := item.(type) {
+	case string:
+		return ParseMount(v).Target
+	case map[string]any:
+		target, _ := v["target"].(string)
+		return target
+	default:
+		return ""
+	}
+}
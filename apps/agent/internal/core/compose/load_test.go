@@ -0,0 +1,248 @@
+package compose
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+)
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		doc     string
+		wantErr string
+	}{
+		{
+			name:    "required variable",
+			doc:     "name: shop\nservices:\n  web:\n    image: \"nginx:${TAG:?set TAG}\"\n",
+			wantErr: "invalid compose document: line 4: services.web.image: required variable TAG is not set: set TAG",
+		},
+		{
+			name:    "invalid variable reference",
+			doc:     "name: shop\nservices:\n  web:\n    image: nginx\n    command: \"echo ${1X}\"\n",
+			wantErr: "invalid compose document: line 5: services.web.command: invalid variable reference ${1X}",
+		},
+		{
+			name:    "inactive profile dependency",
+			doc:     "name: shop\nservices:\n  web:\n    image: nginx\n    depends_on: [debug]\n  debug:\n    image: busybox\n    profiles: [debug]\n",
+			wantErr: `line 5: services.web.depends_on[0]: depends on service "debug" which is not enabled by the active profiles`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := Load([][]byte{[]byte(tt.doc)}, Options{})
+			var validation *domain.ComposeValidationError
+			if !errors.As(err, &validation) {
+				t.Fatalf("Load() error = %v, want a validation error", err)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Load() error = %q, want it to contain %q", err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadMerge(t *testing.T) {
+	base := `
+name: shop
+services:
+  web:
+    image: "nginx:${TAG:-1.27}"
+    environment:
+      - MODE=prod
+      - LOG=info
+    ports: ["8080:80"]
+    volumes: ["site:/usr/share/nginx/html"]
+    depends_on: [api]
+  api:
+    image: acme/api
+volumes:
+  site: {}
+`
+	override := `
+services:
+  web:
+    environment:
+      MODE: dev
+    ports: ["8080:80", "8443:443"]
+    volumes: ["/srv/site:/usr/share/nginx/html:ro"]
+    depends_on:
+      cache:
+        condition: service_started
+  cache:
+    image: redis
+  debug:
+    image: busybox
+    profiles: [debug]
+`
+	project, resolved, err := Load([][]byte{[]byte(base), []byte(override)}, Options{Env: map[string]string{"TAG": "1.28"}})
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if project.Name != "shop" {
+		t.Errorf("Name = %q, want shop", project.Name)
+	}
+
+	names := make([]string, 0, len(project.Services))
+	var web domain.ComposeService
+	for _, service := range project.Services {
+		names = append(names, service.Name)
+		if service.Name == "web" {
+			web = service
+		}
+	}
+	if want := []string{"api", "cache", "web"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("services = %v, want %v", names, want)
+	}
+	if web.Image != "nginx:1.28" {
+		t.Errorf("web image = %q, want nginx:1.28", web.Image)
+	}
+	if want := []string{"LOG=info", "MODE=dev"}; !reflect.DeepEqual(web.Environment, want) {
+		t.Errorf("web environment = %v, want %v", web.Environment, want)
+	}
+	wantPorts := []domain.PortSpec{
+		{HostPort: "8080", ContainerPort: "80", Protocol: "tcp"},
+		{HostPort: "8443", ContainerPort: "443", Protocol: "tcp"},
+	}
+	if !reflect.DeepEqual(web.Ports, wantPorts) {
+		t.Errorf("web ports = %+v, want %+v", web.Ports, wantPorts)
+	}
+	wantMounts := []domain.MountSpec{{Type: "bind", Source: "/srv/site", Target: "/usr/share/nginx/html", ReadOnly: true}}
+	if !reflect.DeepEqual(web.Volumes, wantMounts) {
+		t.Errorf("web volumes = %+v, want %+v", web.Volumes, wantMounts)
+	}
+	if want := []string{"api", "cache"}; !reflect.DeepEqual(web.DependsOn, want) {
+		t.Errorf("web depends_on = %v, want %v", web.DependsOn, want)
+	}
+	if strings.Contains(string(resolved), "debug") || !strings.Contains(string(resolved), "nginx:1.28") {
+		t.Errorf("resolved document is not interpolated and filtered:\n%s", resolved)
+	}
+
+	order, err := ServiceOrder(project)
+	if err != nil {
+		t.Fatalf("ServiceOrder() error = %v", err)
+	}
+	if want := []string{"api", "cache", "web"}; !reflect.DeepEqual(order, want) {
+		t.Errorf("ServiceOrder() = %v, want %v", order, want)
+	}
+}
+
+func TestLoadMarksDocument(t *testing.T) {
+	_, _, err := Load([][]byte{
+		[]byte("name: shop\nservices:\n  web:\n    image: nginx\n"),
+		[]byte("services:\n  web:\n    image: \"${TAG:?}\"\n"),
+	}, Options{})
+	if err == nil || err.Error() != "invalid compose document: document 2: line 3: services.web.image: required variable TAG is not set" {
+		t.Errorf("Load() error = %v", err)
+	}
+}
+
+func TestProfiles(t *testing.T) {
+	doc := []byte(`
+name: shop
+services:
+  web:
+    image: nginx
+  debug:
+    image: busybox
+    profiles: [debug]
+  metrics:
+    image: prom/prometheus
+    profiles: [metrics, ops]
+`)
+	tests := []struct {
+		name string
+		opts Options
+		want []string
+	}{
+		{"none", Options{}, []string{"web"}},
+		{"one", Options{Profiles: []string{"debug"}}, []string{"debug", "web"}},
+		{"any of a service's profiles", Options{Profiles: []string{"ops"}}, []string{"metrics", "web"}},
+		{"all", Options{Profiles: []string{"*"}}, []string{"debug", "metrics", "web"}},
+		{"from the environment", Options{Env: map[string]string{"COMPOSE_PROFILES": "debug, metrics"}}, []string{"debug", "metrics", "web"}},
+		{"explicit profiles win", Options{Profiles: []string{"ops"}, Env: map[string]string{"COMPOSE_PROFILES": "debug"}}, []string{"metrics", "web"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project, err := Parse(doc, tt.opts)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			got := make([]string, 0, len(project.Services))
+			for _, service := range project.Services {
+				got = append(got, service.Name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("services = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnvFile(t *testing.T) {
+	dir := t.TempDir()
+	outside := t.TempDir()
+	writeFile(t, filepath.Join(dir, ".env"), "A=from-file\nB=from-file\n")
+	writeFile(t, filepath.Join(dir, "bad.env"), "A=1\nsecret value\n")
+	writeFile(t, filepath.Join(outside, "secret.env"), "TOKEN=hunter2\n")
+	if err := os.Symlink(filepath.Join(outside, "secret.env"), filepath.Join(dir, "link.env")); err != nil {
+		t.Fatal(err)
+	}
+
+	service := "name: shop\nservices:\n  web:\n    image: nginx\n    environment: [B=explicit]\n    env_file: %s\n"
+	tests := []struct {
+		name    string
+		envFile string
+		want    []string
+		wantErr string
+	}{
+		{"relative", ".env", []string{"A=from-file", "B=explicit"}, ""},
+		{"optional missing", "[{path: missing.env, required: false}, .env]", []string{"A=from-file", "B=explicit"}, ""},
+		{"required missing", "missing.env", nil, `line 6: services.web.env_file[0]: cannot read env_file "missing.env"`},
+		{"bad line", "bad.env", nil, "services.web.env_file[0]: bad.env: invalid compose document: line 2: .env: expected KEY=value"},
+		{"parent directory", "../" + filepath.Base(outside) + "/secret.env", nil, "is outside the project directory"},
+		{"absolute path", filepath.Join(outside, "secret.env"), nil, "is outside the project directory"},
+		{"symlink out", "link.env", nil, "link.env is outside the project directory"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project, err := Parse([]byte(strings.Replace(service, "%s", tt.envFile, 1)), Options{WorkingDir: dir})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Parse() error = %v, want %q", err, tt.wantErr)
+				}
+				if strings.Contains(err.Error(), "hunter2") || strings.Contains(err.Error(), "secret value") {
+					t.Errorf("error leaks file contents: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if got := project.Services[0].Environment; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("environment = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnvFileInline(t *testing.T) {
+	doc := []byte("name: shop\nservices:\n  web:\n    image: nginx\n    env_file: /etc/passwd\n")
+	_, err := Parse(doc, Options{})
+	want := `invalid compose document: line 5: services.web.env_file[0]: env_file "/etc/passwd" requires a project directory`
+	if err == nil || err.Error() != want {
+		t.Errorf("Parse() error = %v, want %q", err, want)
+	}
+}
+
+func writeFile(t *testing.T, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(name, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
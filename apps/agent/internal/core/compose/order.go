@@ -0,0 +1,61 @@
+package compose
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+)
+
+// ServiceOrder returns service names so that every service comes after the
+// services it depends on. Ties are broken alphabetically to keep runs stable.
+func ServiceOrder(project *domain.ComposeProject) ([]string, error) {
+	pending := make(map[string]int, len(project.Services))
+	for _, service := range project.Services {
+		pending[service.Name] = 0
+	}
+	dependents := make(map[string][]string)
+	for _, service := range project.Services {
+		for _, dep := range service.DependsOn {
+			if _, ok := pending[dep]; !ok || dep == service.Name {
+				continue
+			}
+			pending[service.Name]++
+			dependents[dep] = append(dependents[dep], service.Name)
+		}
+	}
+
+	ready := make([]string, 0)
+	for name, count := range pending {
+		if count == 0 {
+			ready = append(ready, name)
+		}
+	}
+
+	order := make([]string, 0, len(pending))
+	for len(ready) > 0 {
+		sort.Strings(ready)
+		name := ready[0]
+		ready = ready[1:]
+		order = append(order, name)
+		for _, dependent := range dependents[name] {
+			pending[dependent]--
+			if pending[dependent] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+
+	if len(order) != len(pending) {
+		cycle := make([]string, 0)
+		for name, count := range pending {
+			if count > 0 {
+				cycle = append(cycle, name)
+			}
+		}
+		sort.Strings(cycle)
+		return nil, fmt.Errorf("dependency cycle between services: %s", strings.Join(cycle, ", "))
+	}
+	return order, nil
+}
@@ -0,0 +1,356 @@
+package compose
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+	"github.com/goccy/go-yaml"
+	"github.com/goccy/go-yaml/ast"
+)
+
+type Options struct {
+	Project  string
+	Profiles []string
+	Env      map[string]string
+	// WorkingDir is the project directory on the host. Without it, build
+	// contexts, env_file and relative bind mounts cannot be resolved.
+	WorkingDir string
+	// ServiceEnv holds the env_file variables of each service as read by an
+	// earlier load. Services listed here are not read from disk again.
+	ServiceEnv map[string][]string
+}
+
+var serviceNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+var ignoredServiceKeys = map[string]bool{
+	"deploy":       true,
+	"logging":      true,
+	"platform":     true,
+	"pull_policy":  true,
+	"ulimits":      true,
+	"sysctls":      true,
+	"security_opt": true,
+	"shm_size":     true,
+	"init":         true,
+	"cap_drop":     true,
+	"dns":          true,
+	"secrets":      true,
+	"configs":      true,
+}
+
+type composeParser struct {
+	file       *ast.File
+	project    string
+	workingDir string
+	env        map[string]string
+	serviceEnv map[string][]string
+	inactive   map[string]bool
+	issues     []domain.ComposeIssue
+	warnings   []string
+}
+
+func Parse(content []byte, opts Options) (*domain.ComposeProject, error) {
+	project, _, err := Load([][]byte{content}, opts)
+	return project, err
+}
+
+func syntaxError(err error) error {
+	issue := domain.ComposeIssue{Message: err.Error()}
+	if yamlErr, ok := err.(yaml.Error); ok {
+		issue.Message = yamlErr.GetMessage()
+		if tk := yamlErr.GetToken(); tk != nil && tk.Position != nil {
+			issue.Line = tk.Position.Line
+			issue.Column = tk.Position.Column
+		}
+	}
+	return &domain.ComposeValidationError{Issues: []domain.ComposeIssue{issue}}
+}
+
+func (p *composeParser) fail(path []string, format string, args ...any) {
+	issue := domain.ComposeIssue{Path: formatPath(path), Message: fmt.Sprintf(format, args...)}
+	if pos := locate(p.file, path); pos != nil {
+		issue.Line = pos.Line
+		issue.Column = pos.Column
+	}
+	p.issues = append(p.issues, issue)
+}
+
+func (p *composeParser) warn(path []string, format string, args ...any) {
+	p.warnings = append(p.warnings, fmt.Sprintf("%s: %s", formatPath(path), fmt.Sprintf(format, args...)))
+}
+
+func (p *composeParser) parseProject(raw map[string]any) *domain.ComposeProject {
+	if p.project == "" {
+		if name, ok := raw["name"].(string); ok {
+			p.project = strings.TrimSpace(name)
+		}
+	}
+	if p.project == "" {
+		p.fail([]string{"name"}, "project name is required")
+	}
+
+	project := &domain.ComposeProject{
+		Name:     p.project,
+		Networks: p.parseNetworks(raw["networks"]),
+		Volumes:  p.parseVolumes(raw["volumes"]),
+	}
+
+	rawServices, ok := raw["services"].(map[string]any)
+	if !ok || len(rawServices) == 0 {
+		p.fail([]string{"services"}, "at least one service must be defined")
+		return project
+	}
+
+	for _, name := range sortedKeys(rawServices) {
+		service := p.parseService(name, rawServices[name], project)
+		if service != nil {
+			project.Services = append(project.Services, *service)
+		}
+	}
+
+	for _, service := range project.Services {
+		for _, network := range service.Networks {
+			if _, ok := project.Networks[network.Name]; !ok && network.Name == "default" {
+				project.Networks["default"] = domain.ComposeNetwork{Name: p.project + "_default"}
+			}
+		}
+	}
+
+	p.validateProject(project)
+	project.Warnings = p.warnings
+	return project
+}
+
+func (p *composeParser) parseNetworks(raw any) map[string]domain.ComposeNetwork {
+	networks := make(map[string]domain.ComposeNetwork)
+	if raw == nil {
+		return networks
+	}
+	entries, ok := raw.(map[string]any)
+	if !ok {
+		p.fail([]string{"networks"}, "networks must be a mapping")
+		return networks
+	}
+	for _, key := range sortedKeys(entries) {
+		path := []string{"networks", key}
+		network := domain.ComposeNetwork{Name: p.project + "_" + key}
+		if entries[key] != nil {
+			def, ok := entries[key].(map[string]any)
+			if !ok {
+				p.fail(path, "network definition must be a mapping")
+				continue
+			}
+			network.Driver = p.stringValue(sub(path, "driver"), def["driver"])
+			network.External = p.boolValue(sub(path, "external"), def["external"])
+			network.Internal = p.boolValue(sub(path, "internal"), def["internal"])
+			network.Labels = p.mappingOrList(sub(path, "labels"), def["labels"])
+			if name := p.stringValue(sub(path, "name"), def["name"]); name != "" {
+				network.Name = name
+			} else if network.External {
+				network.Name = key
+			}
+		}
+		networks[key] = network
+	}
+	return networks
+}
+
+func (p *composeParser) parseVolumes(raw any) map[string]domain.ComposeVolume {
+	volumes := make(map[string]domain.ComposeVolume)
+	if raw == nil {
+		return volumes
+	}
+	entries, ok := raw.(map[string]any)
+	if !ok {
+		p.fail([]string{"volumes"}, "volumes must be a mapping")
+		return volumes
+	}
+	for _, key := range sortedKeys(entries) {
+		path := []string{"volumes", key}
+		volume := domain.ComposeVolume{Name: p.project + "_" + key}
+		if entries[key] != nil {
+			def, ok := entries[key].(map[string]any)
+			if !ok {
+				p.fail(path, "volume definition must be a mapping")
+				continue
+			}
+			volume.Driver = p.stringValue(sub(path, "driver"), def["driver"])
+			volume.External = p.boolValue(sub(path, "external"), def["external"])
+			volume.Labels = p.mappingOrList(sub(path, "labels"), def["labels"])
+			if name := p.stringValue(sub(path, "name"), def["name"]); name != "" {
+				volume.Name = name
+			} else if volume.External {
+				volume.Name = key
+			}
+		}
+		volumes[key] = volume
+	}
+	return volumes
+}
+
+func (p *composeParser) parseService(name string, raw any, project *domain.ComposeProject) *domain.ComposeService {
+	path := []string{"services", name}
+	if !serviceNameRegexp.MatchString(name) {
+		p.fail(path, "invalid service name %q", name)
+		return nil
+	}
+	def, ok := raw.(map[string]any)
+	if !ok {
+		p.fail(path, "service definition must be a mapping")
+		return nil
+	}
+
+	service := &domain.ComposeService{Name: name}
+	var fileEnv []string
+	for _, key := range sortedKeys(def) {
+		value := def[key]
+		fieldPath := sub(path, key)
+		switch key {
+		case "image":
+			service.Image = p.stringValue(fieldPath, value)
+		case "build":
+			if p.workingDir != "" {
+				service.Build = p.buildValue(fieldPath, value)
+			} else if _, hasImage := def["image"]; !hasImage {
+				p.fail(fieldPath, "build is not supported for inline compose documents, set image instead")
+			} else {
+				p.warn(fieldPath, "build is ignored, image %v will be used", def["image"])
+			}
+		case "container_name":
+			service.ContainerName = p.stringValue(fieldPath, value)
+		case "command":
+			service.Command = p.commandValue(fieldPath, value)
+		case "entrypoint":
+			service.Entrypoint = p.commandValue(fieldPath, value)
+		case "environment":
+			service.Environment = p.environmentValue(fieldPath, value)
+		case "env_file":
+			if recorded, ok := p.serviceEnv[name]; ok {
+				fileEnv = recorded
+			} else {
+				fileEnv = p.envFileValue(fieldPath, value)
+			}
+			if project.ServiceEnv == nil {
+				project.ServiceEnv = make(map[string][]string)
+			}
+			project.ServiceEnv[name] = fileEnv
+		case "labels":
+			service.Labels = p.mappingOrList(fieldPath, value)
+		case "ports":
+			service.Ports = p.portsValue(fieldPath, value)
+		case "expose":
+			service.Expose = p.stringList(fieldPath, value)
+		case "volumes":
+			service.Volumes = p.mountsValue(fieldPath, value, project)
+		case "networks":
+			service.Networks = p.serviceNetworksValue(fieldPath, value, project)
+		case "depends_on":
+			service.DependsOn = p.dependsOnValue(fieldPath, value)
+		case "restart":
+			service.Restart = p.restartValue(fieldPath, value)
+		case "working_dir":
+			service.WorkingDir = p.stringValue(fieldPath, value)
+		case "user":
+			service.User = p.stringValue(fieldPath, value)
+		case "hostname":
+			service.Hostname = p.stringValue(fieldPath, value)
+		case "privileged":
+			service.Privileged = p.boolValue(fieldPath, value)
+		case "tty":
+			service.Tty = p.boolValue(fieldPath, value)
+		case "stdin_open":
+			service.StdinOpen = p.boolValue(fieldPath, value)
+		case "stop_signal":
+			service.StopSignal = p.stringValue(fieldPath, value)
+		case "cap_add":
+			service.CapAdd = p.stringList(fieldPath, value)
+		case "extra_hosts":
+			service.ExtraHosts = p.extraHostsValue(fieldPath, value)
+		case "tmpfs":
+			service.Tmpfs = p.stringOrList(fieldPath, value)
+		case "healthcheck":
+			service.Healthcheck = p.healthcheckValue(fieldPath, value)
+		case "profiles":
+			p.stringList(fieldPath, value)
+		default:
+			if strings.HasPrefix(key, "x-") {
+				continue
+			}
+			if ignoredServiceKeys[key] {
+				p.warn(fieldPath, "%s is not supported and will be ignored", key)
+				continue
+			}
+			p.fail(fieldPath, "unknown service option %q", key)
+		}
+	}
+
+	service.Environment = mergeEnvironment(fileEnv, service.Environment)
+	if service.Build != nil && service.Image == "" {
+		service.Image = p.project + "-" + name
+	}
+	if service.Image == "" {
+		if _, hasBuild := def["build"]; !hasBuild {
+			p.fail(path, "service must define an image")
+		}
+	} else if strings.ContainsAny(service.Image, " \t\n") {
+		p.fail(sub(path, "image"), "image reference %q must not contain whitespace", service.Image)
+	}
+	if len(service.Networks) == 0 {
+		service.Networks = []domain.NetworkAttachment{{Name: "default"}}
+	}
+	return service
+}
+
+func (p *composeParser) validateProject(project *domain.ComposeProject) {
+	containerNames := make(map[string]string)
+	hostPorts := make(map[string]string)
+	services := make(map[string]bool, len(project.Services))
+	for _, service := range project.Services {
+		services[service.Name] = true
+	}
+
+	for _, service := range project.Services {
+		path := []string{"services", service.Name}
+		if service.ContainerName != "" {
+			if other, ok := containerNames[service.ContainerName]; ok {
+				p.fail(sub(path, "container_name"), "container name %q is already used by service %s", service.ContainerName, other)
+			}
+			containerNames[service.ContainerName] = service.Name
+		}
+		for i, port := range service.Ports {
+			if port.HostPort == "" {
+				continue
+			}
+			key := port.HostIP + ":" + port.HostPort + "/" + port.Protocol
+			if other, ok := hostPorts[key]; ok {
+				p.fail(sub(path, "ports", index(i)), "host port %s is already published by service %s", port.HostPort, other)
+			}
+			hostPorts[key] = service.Name
+		}
+		for i, dep := range service.DependsOn {
+			if dep == service.Name {
+				p.fail(sub(path, "depends_on", index(i)), "service cannot depend on itself")
+			} else if p.inactive[dep] {
+				p.fail(sub(path, "depends_on", index(i)), "depends on service %q which is not enabled by the active profiles", dep)
+			} else if !services[dep] {
+				p.fail(sub(path, "depends_on", index(i)), "depends on undefined service %q", dep)
+			}
+		}
+	}
+
+	if _, err := ServiceOrder(project); err != nil {
+		p.fail([]string{"services"}, "%s", err.Error())
+	}
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,202 @@
+package compose
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+)
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		doc     string
+		wantErr string
+	}{
+		{
+			name:    "empty",
+			doc:     "  \n",
+			wantErr: "invalid compose document: : compose document is empty",
+		},
+		{
+			name:    "no project name",
+			doc:     "services:\n  web:\n    image: nginx\n",
+			wantErr: "project name is required",
+		},
+		{
+			name:    "no services",
+			doc:     "name: shop\nservices: {}\n",
+			wantErr: "line 2: services: at least one service must be defined",
+		},
+		{
+			name:    "unknown option",
+			doc:     "name: shop\nservices:\n  web:\n    image: nginx\n    imagee: nginx\n",
+			wantErr: `invalid compose document: line 5: services.web.imagee: unknown service option "imagee"`,
+		},
+		{
+			name:    "issues sorted by line",
+			doc:     "name: shop\nservices:\n  web:\n    image: nginx\n    ports: [\"80:80\", \"80:8080\"]\n  db:\n    ports: [\"abc\"]\n",
+			wantErr: `invalid compose document: line 5: services.web.ports[1]: host port 80 is already published by service web; line 6: services.db: service must define an image; line 7: services.db.ports[0]: invalid container port "abc"`,
+		},
+		{
+			name:    "undefined dependency",
+			doc:     "name: shop\nservices:\n  web:\n    image: nginx\n    depends_on: [db]\n",
+			wantErr: `line 5: services.web.depends_on[0]: depends on undefined service "db"`,
+		},
+		{
+			name:    "dependency cycle",
+			doc:     "name: shop\nservices:\n  a:\n    image: x\n    depends_on: [b]\n  b:\n    image: x\n    depends_on: [a]\n",
+			wantErr: "line 2: services: dependency cycle between services: a, b",
+		},
+		{
+			name:    "undeclared volume",
+			doc:     "name: shop\nservices:\n  db:\n    image: postgres\n    volumes: [\"data:/var/lib/postgresql/data\"]\n",
+			wantErr: `line 5: services.db.volumes[0]: volume "data" is not declared in top-level volumes`,
+		},
+		{
+			name:    "relative bind mount inline",
+			doc:     "name: shop\nservices:\n  web:\n    image: nginx\n    volumes: [\"./site:/usr/share/nginx/html\"]\n",
+			wantErr: `relative bind mount "./site" requires a project directory`,
+		},
+		{
+			name:    "build inline",
+			doc:     "name: shop\nservices:\n  web:\n    build: .\n",
+			wantErr: "line 4: services.web.build: build is not supported for inline compose documents",
+		},
+		{
+			name:    "syntax error",
+			doc:     "name: shop\nservices:\n  web:\n    image: [nginx\n",
+			wantErr: "invalid compose document: line 4",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.doc), Options{})
+			var validation *domain.ComposeValidationError
+			if !errors.As(err, &validation) {
+				t.Fatalf("Parse() error = %v, want a validation error", err)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Parse() error = %q, want it to contain %q", err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEnvironment(t *testing.T) {
+	env := map[string]string{"API_KEY": "from-host", "EMPTY": ""}
+	tests := []struct {
+		name         string
+		environment  string
+		want         []string
+		wantWarnings []string
+	}{
+		{"list", "[A=1, B=two=2]", []string{"A=1", "B=two=2"}, nil},
+		{"mapping", "{B: 2, A: 1, ON: true}", []string{"A=1", "B=2", "ON=true"}, nil},
+		{"list pass-through", "[A=1, API_KEY, EMPTY]", []string{"A=1", "API_KEY=from-host", "EMPTY="}, nil},
+		{"mapping pass-through", "{A: 1, API_KEY: }", []string{"A=1", "API_KEY=from-host"}, nil},
+		{
+			name:         "unset pass-through",
+			environment:  "[A=1, MISSING]",
+			want:         []string{"A=1"},
+			wantWarnings: []string{"services.web.environment[1]: variable MISSING is not set and will not be passed to the container"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := "name: shop\nservices:\n  web:\n    image: nginx\n    environment: " + tt.environment + "\n"
+			project, err := Parse([]byte(doc), Options{Env: env})
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if got := project.Services[0].Environment; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("environment = %v, want %v", got, tt.want)
+			}
+			if len(project.Warnings)+len(tt.wantWarnings) > 0 && !reflect.DeepEqual(project.Warnings, tt.wantWarnings) {
+				t.Errorf("warnings = %v, want %v", project.Warnings, tt.wantWarnings)
+			}
+		})
+	}
+}
+
+func TestServiceOrder(t *testing.T) {
+	tests := []struct {
+		name     string
+		services map[string][]string
+		want     []string
+		wantErr  string
+	}{
+		{"independent", map[string][]string{"web": nil, "api": nil}, []string{"api", "web"}, ""},
+		{"chain", map[string][]string{"web": {"api"}, "api": {"db"}, "db": nil}, []string{"db", "api", "web"}, ""},
+		{"unknown and self dependencies are ignored", map[string][]string{"web": {"web", "gone"}}, []string{"web"}, ""},
+		{"cycle", map[string][]string{"a": {"b"}, "b": {"a"}, "c": nil}, nil, "dependency cycle between services: a, b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project := &domain.ComposeProject{}
+			for name, deps := range tt.services {
+				project.Services = append(project.Services, domain.ComposeService{Name: name, DependsOn: deps})
+			}
+			got, err := ServiceOrder(project)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("ServiceOrder() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ServiceOrder() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ServiceOrder() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecordedServiceEnv(t *testing.T) {
+	dir := t.TempDir()
+	envFile := filepath.Join(dir, "web.env")
+	if err := os.WriteFile(envFile, []byte("MODE=v1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	doc := []byte("name: shop\nservices:\n  web:\n    image: nginx\n    env_file: web.env\n  db:\n    image: postgres\n")
+
+	first, err := Parse(doc, Options{WorkingDir: dir})
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	want := map[string][]string{"web": {"MODE=v1"}}
+	if !reflect.DeepEqual(first.ServiceEnv, want) {
+		t.Fatalf("ServiceEnv = %v, want %v", first.ServiceEnv, want)
+	}
+
+	if err := os.WriteFile(envFile, []byte("MODE=v2\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		opts Options
+		want []string
+	}{
+		{"recorded", Options{WorkingDir: dir, ServiceEnv: first.ServiceEnv}, []string{"MODE=v1"}},
+		{"from disk", Options{WorkingDir: dir}, []string{"MODE=v2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project, err := Parse(doc, tt.opts)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			for _, service := range project.Services {
+				if service.Name == "web" && !reflect.DeepEqual(service.Environment, tt.want) {
+					t.Errorf("web environment = %v, want %v", service.Environment, tt.want)
+				}
+			}
+		})
+	}
+}
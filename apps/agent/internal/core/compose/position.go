@@ -0,0 +1,91 @@
+package compose
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/token"
+)
+
+// locate walks the document AST along path and returns the position of the
+// deepest node it could reach, so issues on synthesized values still point
+// at their closest parent in the source.
+func locate(file *ast.File, path []string) *token.Position {
+	if file == nil || len(file.Docs) == 0 || file.Docs[0] == nil {
+		return nil
+	}
+	node := file.Docs[0].Body
+	var pos *token.Position
+	for _, segment := range path {
+		next, keyPos := child(node, segment)
+		if next == nil {
+			break
+		}
+		if keyPos != nil {
+			pos = keyPos
+		} else if tk := next.GetToken(); tk != nil {
+			pos = tk.Position
+		}
+		node = next
+	}
+	return pos
+}
+
+func child(node ast.Node, segment string) (ast.Node, *token.Position) {
+	node = unwrap(node)
+	if strings.HasPrefix(segment, "[") {
+		index, err := strconv.Atoi(strings.Trim(segment, "[]"))
+		if err != nil {
+			return nil, nil
+		}
+		seq, ok := node.(*ast.SequenceNode)
+		if !ok || index < 0 || index >= len(seq.Values) {
+			return nil, nil
+		}
+		return seq.Values[index], nil
+	}
+
+	var values []*ast.MappingValueNode
+	switch n := node.(type) {
+	case *ast.MappingNode:
+		values = n.Values
+	case *ast.MappingValueNode:
+		values = []*ast.MappingValueNode{n}
+	default:
+		return nil, nil
+	}
+	for _, value := range values {
+		tk := value.Key.GetToken()
+		if tk != nil && tk.Value == segment {
+			return value.Value, tk.Position
+		}
+	}
+	return nil, nil
+}
+
+func unwrap(node ast.Node) ast.Node {
+	for {
+		switch n := node.(type) {
+		case *ast.AnchorNode:
+			node = n.Value
+		case *ast.TagNode:
+			node = n.Value
+		case *ast.AliasNode:
+			return n
+		default:
+			return node
+		}
+	}
+}
+
+func formatPath(path []string) string {
+	var b strings.Builder
+	for i, segment := range path {
+		if i > 0 && !strings.HasPrefix(segment, "[") {
+			b.WriteString(".")
+		}
+		b.WriteString(segment)
+	}
+	return b.String()
+}
@@ -0,0 +1,742 @@
+package compose
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+)
+
+func sub(path []string, segments ...string) []string {
+	out := make([]string, 0, len(path)+len(segments))
+	out = append(out, path...)
+	return append(out, segments...)
+}
+
+func index(i int) string {
+	return fmt.Sprintf("[%d]", i)
+}
+
+func scalarString(value any) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case uint64:
+		return strconv.FormatUint(v, 10), true
+	case int64:
+		return strconv.FormatInt(v, 10), true
+	case int:
+		return strconv.Itoa(v), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(v), true
+	default:
+		return "", false
+	}
+}
+
+func (p *composeParser) stringValue(path []string, value any) string {
+	if value == nil {
+		return ""
+	}
+	s, ok := scalarString(value)
+	if !ok {
+		p.fail(path, "must be a string")
+		return ""
+	}
+	return strings.TrimSpace(s)
+}
+
+func (p *composeParser) boolValue(path []string, value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case string:
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			p.fail(path, "must be a boolean")
+		}
+		return b
+	default:
+		p.fail(path, "must be a boolean")
+		return false
+	}
+}
+
+func (p *composeParser) intValue(path []string, value any) int {
+	s, ok := scalarString(value)
+	if !ok {
+		p.fail(path, "must be an integer")
+		return 0
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		p.fail(path, "must be an integer")
+	}
+	return n
+}
+
+func (p *composeParser) stringList(path []string, value any) []string {
+	if value == nil {
+		return nil
+	}
+	items, ok := value.([]any)
+	if !ok {
+		p.fail(path, "must be a list")
+		return nil
+	}
+	out := make([]string, 0, len(items))
+	for i, item := range items {
+		s, ok := scalarString(item)
+		if !ok {
+			p.fail(sub(path, index(i)), "must be a string")
+			continue
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+func (p *composeParser) stringOrList(path []string, value any) []string {
+	if s, ok := value.(string); ok {
+		return []string{s}
+	}
+	return p.stringList(path, value)
+}
+
+func (p *composeParser) commandValue(path []string, value any) []string {
+	if value == nil {
+		return nil
+	}
+	if s, ok := value.(string); ok {
+		args, err := splitCommand(s)
+		if err != nil {
+			p.fail(path, "%s", err.Error())
+		}
+		return args
+	}
+	return p.stringList(path, value)
+}
+
+func (p *composeParser) mappingOrList(path []string, value any) map[string]string {
+	if value == nil {
+		return nil
+	}
+	out := make(map[string]string)
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			if item == nil {
+				out[key] = ""
+				continue
+			}
+			s, ok := scalarString(item)
+			if !ok {
+				p.fail(sub(path, key), "must be a string")
+				continue
+			}
+			out[key] = s
+		}
+	case []any:
+		for i, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				p.fail(sub(path, index(i)), "must be a key=value string")
+				continue
+			}
+			key, val, _ := strings.Cut(s, "=")
+			out[key] = val
+		}
+	default:
+		p.fail(path, "must be a mapping or a list")
+	}
+	return out
+}
+
+func (p *composeParser) environmentValue(path []string, value any) []string {
+	if value == nil {
+		return nil
+	}
+	switch v := value.(type) {
+	case map[string]any:
+		env := make([]string, 0, len(v))
+		for _, key := range sortedKeys(v) {
+			if v[key] == nil {
+				if entry, ok := p.passThrough(sub(path, key), key); ok {
+					env = append(env, entry)
+				}
+				continue
+			}
+			s, ok := scalarString(v[key])
+			if !ok {
+				p.fail(sub(path, key), "must be a string")
+				continue
+			}
+			env = append(env, key+"="+s)
+		}
+		return env
+	case []any:
+		env := make([]string, 0, len(v))
+		for i, item := range v {
+			s, ok := item.(string)
+			if !ok || strings.HasPrefix(s, "=") {
+				p.fail(sub(path, index(i)), "must be a KEY=value string")
+				continue
+			}
+			if !strings.Contains(s, "=") {
+				if entry, ok := p.passThrough(sub(path, index(i)), s); ok {
+					env = append(env, entry)
+				}
+				continue
+			}
+			env = append(env, s)
+		}
+		return env
+	default:
+		p.fail(path, "must be a mapping or a list")
+		return nil
+	}
+}
+
+// passThrough resolves an environment entry given without a value from the
+// project environment, like docker compose does. Unset variables are left
+// out of the container.
+func (p *composeParser) passThrough(path []string, key string) (string, bool) {
+	value, ok := p.env[key]
+	if !ok {
+		p.warn(path, "variable %s is not set and will not be passed to the container", key)
+		return "", false
+	}
+	return key + "=" + value, true
+}
+
+func (p *composeParser) portsValue(path []string, value any) []domain.PortSpec {
+	if value == nil {
+		return nil
+	}
+	items, ok := value.([]any)
+	if !ok {
+		p.fail(path, "must be a list")
+		return nil
+	}
+	ports := make([]domain.PortSpec, 0, len(items))
+	for i, item := range items {
+		itemPath := sub(path, index(i))
+		if def, ok := item.(map[string]any); ok {
+			port := domain.PortSpec{
+				ContainerPort: p.stringValue(sub(itemPath, "target"), def["target"]),
+				HostPort:      p.stringValue(sub(itemPath, "published"), def["published"]),
+				HostIP:        p.stringValue(sub(itemPath, "host_ip"), def["host_ip"]),
+				Protocol:      p.stringValue(sub(itemPath, "protocol"), def["protocol"]),
+			}
+			if port.Protocol == "" {
+				port.Protocol = "tcp"
+			}
+			if err := validatePort(port); err != nil {
+				p.fail(itemPath, "%s", err.Error())
+				continue
+			}
+			ports = append(ports, port)
+			continue
+		}
+		s, ok := scalarString(item)
+		if !ok {
+			p.fail(itemPath, "must be a port string or mapping")
+			continue
+		}
+		port, err := ParsePort(s)
+		if err != nil {
+			p.fail(itemPath, "%s", err.Error())
+			continue
+		}
+		ports = append(ports, port)
+	}
+	return ports
+}
+
+// ParsePort parses the compose short port syntax, e.g. "127.0.0.1:8080:80/tcp".
+func ParsePort(spec string) (domain.PortSpec, error) {
+	port := domain.PortSpec{Protocol: "tcp"}
+	rest := spec
+	if base, proto, ok := strings.Cut(rest, "/"); ok {
+		rest = base
+		port.Protocol = proto
+	}
+
+	parts := strings.Split(rest, ":")
+	if strings.HasPrefix(rest, "[") {
+		end := strings.Index(rest, "]:")
+		if end < 0 {
+			return port, fmt.Errorf("invalid port %q", spec)
+		}
+		port.HostIP = rest[1:end]
+		parts = strings.Split(rest[end+2:], ":")
+		if len(parts) == 1 {
+			parts = append([]string{""}, parts...)
+		}
+	}
+
+	switch len(parts) {
+	case 1:
+		port.ContainerPort = parts[0]
+	case 2:
+		port.HostPort = parts[0]
+		port.ContainerPort = parts[1]
+	case 3:
+		port.HostIP = parts[0]
+		port.HostPort = parts[1]
+		port.ContainerPort = parts[2]
+	default:
+		return port, fmt.Errorf("invalid port %q", spec)
+	}
+	return port, validatePort(port)
+}
+
+func validatePort(port domain.PortSpec) error {
+	if port.ContainerPort == "" {
+		return fmt.Errorf("container port is required")
+	}
+	if strings.Contains(port.ContainerPort, "-") || strings.Contains(port.HostPort, "-") {
+		return fmt.Errorf("port ranges are not supported")
+	}
+	if n, err := strconv.Atoi(port.ContainerPort); err != nil || n < 1 || n > 65535 {
+		return fmt.Errorf("invalid container port %q", port.ContainerPort)
+	}
+	if port.HostPort != "" {
+		if n, err := strconv.Atoi(port.HostPort); err != nil || n < 0 || n > 65535 {
+			return fmt.Errorf("invalid host port %q", port.HostPort)
+		}
+	}
+	switch port.Protocol {
+	case "tcp", "udp", "sctp":
+	default:
+		return fmt.Errorf("unsupported protocol %q", port.Protocol)
+	}
+	return nil
+}
+
+func (p *composeParser) mountsValue(path []string, value any, project *domain.ComposeProject) []domain.MountSpec {
+	if value == nil {
+		return nil
+	}
+	items, ok := value.([]any)
+	if !ok {
+		p.fail(path, "must be a list")
+		return nil
+	}
+	mounts := make([]domain.MountSpec, 0, len(items))
+	for i, item := range items {
+		itemPath := sub(path, index(i))
+		var mount domain.MountSpec
+		switch v := item.(type) {
+		case string:
+			mount = ParseMount(v)
+		case map[string]any:
+			mount = domain.MountSpec{
+				Type:     p.stringValue(sub(itemPath, "type"), v["type"]),
+				Source:   p.stringValue(sub(itemPath, "source"), v["source"]),
+				Target:   p.stringValue(sub(itemPath, "target"), v["target"]),
+				ReadOnly: p.boolValue(sub(itemPath, "read_only"), v["read_only"]),
+			}
+			if mount.Type == "" {
+				mount.Type = "volume"
+			}
+		default:
+			p.fail(itemPath, "must be a volume string or mapping")
+			continue
+		}
+
+		if mount.Target == "" || !strings.HasPrefix(mount.Target, "/") {
+			p.fail(itemPath, "mount target must be an absolute container path")
+			continue
+		}
+		switch mount.Type {
+		case "bind":
+			if !filepath.IsAbs(mount.Source) {
+				if p.workingDir == "" || strings.HasPrefix(mount.Source, "~") {
+					p.fail(itemPath, "relative bind mount %q requires a project directory", mount.Source)
+					continue
+				}
+				mount.Source = filepath.Join(p.workingDir, mount.Source)
+			}
+		case "volume":
+			if mount.Source != "" {
+				if _, ok := project.Volumes[mount.Source]; !ok {
+					p.fail(itemPath, "volume %q is not declared in top-level volumes", mount.Source)
+					continue
+				}
+			}
+		case "tmpfs":
+		default:
+			p.fail(itemPath, "unsupported mount type %q", mount.Type)
+			continue
+		}
+		mounts = append(mounts, mount)
+	}
+	return mounts
+}
+
+// ParseMount parses the compose short volume syntax. Sources that look like
+// paths become bind mounts, anything else a named volume.
+func ParseMount(spec string) domain.MountSpec {
+	parts := strings.Split(spec, ":")
+	mount := domain.MountSpec{Type: "volume"}
+	switch len(parts) {
+	case 1:
+		mount.Target = parts[0]
+		return mount
+	default:
+		mount.Source = parts[0]
+		mount.Target = parts[1]
+		if len(parts) > 2 {
+			for _, opt := range strings.Split(parts[2], ",") {
+				if opt == "ro" {
+					mount.ReadOnly = true
+				}
+			}
+		}
+	}
+	if isPathLike(mount.Source) {
+		mount.Type = "bind"
+	}
+	return mount
+}
+
+func isPathLike(source string) bool {
+	return strings.HasPrefix(source, "/") || strings.HasPrefix(source, ".") || strings.HasPrefix(source, "~")
+}
+
+func (p *composeParser) serviceNetworksValue(path []string, value any, project *domain.ComposeProject) []domain.NetworkAttachment {
+	if value == nil {
+		return nil
+	}
+	var networks []domain.NetworkAttachment
+	switch v := value.(type) {
+	case []any:
+		for i, item := range v {
+			name, ok := item.(string)
+			if !ok {
+				p.fail(sub(path, index(i)), "must be a network name")
+				continue
+			}
+			networks = append(networks, domain.NetworkAttachment{Name: name})
+		}
+	case map[string]any:
+		for _, name := range sortedKeys(v) {
+			network := domain.NetworkAttachment{Name: name}
+			if def, ok := v[name].(map[string]any); ok {
+				network.Aliases = p.stringList(sub(path, name, "aliases"), def["aliases"])
+			} else if v[name] != nil {
+				p.fail(sub(path, name), "network attachment must be a mapping")
+			}
+			networks = append(networks, network)
+		}
+	default:
+		p.fail(path, "must be a list or a mapping")
+	}
+
+	for _, network := range networks {
+		if network.Name == "default" {
+			continue
+		}
+		if _, ok := project.Networks[network.Name]; !ok {
+			p.fail(sub(path, network.Name), "network %q is not declared in top-level networks", network.Name)
+		}
+	}
+	return networks
+}
+
+func (p *composeParser) dependsOnValue(path []string, value any) []string {
+	if value == nil {
+		return nil
+	}
+	switch v := value.(type) {
+	case []any:
+		return p.stringList(path, v)
+	case map[string]any:
+		deps := sortedKeys(v)
+		for _, dep := range deps {
+			def, ok := v[dep].(map[string]any)
+			if !ok {
+				continue
+			}
+			switch condition := p.stringValue(sub(path, dep, "condition"), def["condition"]); condition {
+			case "", "service_started", "service_healthy", "service_completed_successfully":
+			default:
+				p.fail(sub(path, dep, "condition"), "unsupported condition %q", condition)
+			}
+		}
+		return deps
+	default:
+		p.fail(path, "must be a list or a mapping")
+		return nil
+	}
+}
+
+func (p *composeParser) restartValue(path []string, value any) string {
+	policy := p.stringValue(path, value)
+	name, count, hasCount := strings.Cut(policy, ":")
+	switch name {
+	case "", "no", "always", "unless-stopped":
+		if hasCount {
+			p.fail(path, "only on-failure accepts a retry count")
+		}
+	case "on-failure":
+		if hasCount {
+			if _, err := strconv.Atoi(count); err != nil {
+				p.fail(path, "invalid retry count %q", count)
+			}
+		}
+	default:
+		p.fail(path, "unsupported restart policy %q", policy)
+	}
+	return policy
+}
+
+func (p *composeParser) extraHostsValue(path []string, value any) []string {
+	if def, ok := value.(map[string]any); ok {
+		hosts := make([]string, 0, len(def))
+		for _, host := range sortedKeys(def) {
+			ip := p.stringValue(sub(path, host), def[host])
+			hosts = append(hosts, host+":"+ip)
+		}
+		return hosts
+	}
+	return p.stringList(path, value)
+}
+
+func (p *composeParser) healthcheckValue(path []string, value any) *domain.HealthcheckSpec {
+	def, ok := value.(map[string]any)
+	if !ok {
+		p.fail(path, "must be a mapping")
+		return nil
+	}
+	check := &domain.HealthcheckSpec{
+		Interval:    p.durationValue(sub(path, "interval"), def["interval"]),
+		Timeout:     p.durationValue(sub(path, "timeout"), def["timeout"]),
+		StartPeriod: p.durationValue(sub(path, "start_period"), def["start_period"]),
+		Disable:     p.boolValue(sub(path, "disable"), def["disable"]),
+	}
+	if def["retries"] != nil {
+		check.Retries = p.intValue(sub(path, "retries"), def["retries"])
+	}
+	switch test := def["test"].(type) {
+	case nil:
+	case string:
+		check.Test = []string{"CMD-SHELL", test}
+	default:
+		check.Test = p.stringList(sub(path, "test"), test)
+		if len(check.Test) > 0 {
+			switch check.Test[0] {
+			case "NONE", "CMD", "CMD-SHELL":
+			default:
+				p.fail(sub(path, "test"), "test must start with NONE, CMD or CMD-SHELL")
+			}
+		}
+	}
+	return check
+}
+
+func (p *composeParser) durationValue(path []string, value any) string {
+	s := p.stringValue(path, value)
+	if s == "" {
+		return ""
+	}
+	if _, err := time.ParseDuration(s); err != nil {
+		p.fail(path, "invalid duration %q", s)
+	}
+	return s
+}
+
+func splitCommand(command string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	var quote rune
+	inArg := false
+	for _, r := range command {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+				continue
+			}
+			current.WriteRune(r)
+		case r == '"' || r == '\'':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote in command")
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
+
+func (p *composeParser) buildValue(path []string, value any) *domain.BuildSpec {
+	build := &domain.BuildSpec{}
+	switch v := value.(type) {
+	case string:
+		build.Context = v
+	case map[string]any:
+		build.Context = p.stringValue(sub(path, "context"), v["context"])
+		build.Dockerfile = p.stringValue(sub(path, "dockerfile"), v["dockerfile"])
+		build.Target = p.stringValue(sub(path, "target"), v["target"])
+		build.Args = p.mappingOrList(sub(path, "args"), v["args"])
+	default:
+		p.fail(path, "must be a context path or a mapping")
+		return nil
+	}
+	if build.Context == "" {
+		build.Context = "."
+	}
+	if strings.Contains(build.Context, "://") || strings.HasPrefix(build.Context, "git@") {
+		p.fail(path, "remote build context %q is not supported", build.Context)
+		return nil
+	}
+	if !filepath.IsAbs(build.Context) {
+		build.Context = filepath.Join(p.workingDir, build.Context)
+	}
+	return build
+}
+
+// envFileValue reads env_file entries relative to the project directory and
+// returns their variables as KEY=value pairs.
+func (p *composeParser) envFileValue(path []string, value any) []string {
+	var entries []any
+	switch v := value.(type) {
+	case string:
+		entries = []any{v}
+	case []any:
+		entries = v
+	default:
+		p.fail(path, "must be a path or a list of paths")
+		return nil
+	}
+
+	merged := make(map[string]string)
+	for i, entry := range entries {
+		entryPath := sub(path, index(i))
+		file, required := "", true
+		switch e := entry.(type) {
+		case string:
+			file = e
+		case map[string]any:
+			file = p.stringValue(sub(entryPath, "path"), e["path"])
+			if _, ok := e["required"]; ok {
+				required = p.boolValue(sub(entryPath, "required"), e["required"])
+			}
+		default:
+			p.fail(entryPath, "must be a path or a mapping")
+			continue
+		}
+		// Inline documents have no project directory, and nothing outside it
+		// may be read: the file's contents end up in the response.
+		if p.workingDir == "" {
+			p.fail(entryPath, "env_file %q requires a project directory", file)
+			continue
+		}
+		resolved, err := ProjectPath(p.workingDir, file)
+		if err != nil {
+			if !required && errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			p.fail(entryPath, "cannot read env_file %q: %s", file, err.Error())
+			continue
+		}
+		content, err := os.ReadFile(resolved)
+		if err != nil {
+			p.fail(entryPath, "cannot read env_file %q: %s", file, err.Error())
+			continue
+		}
+		env, err := ParseEnvFile(content)
+		if err != nil {
+			p.fail(entryPath, "%s: %s", file, err.Error())
+			continue
+		}
+		for key, val := range env {
+			merged[key] = val
+		}
+	}
+
+	keys := make([]string, 0, len(merged))
+	for key := range merged {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	vars := make([]string, 0, len(keys))
+	for _, key := range keys {
+		vars = append(vars, key+"="+merged[key])
+	}
+	return vars
+}
+
+// mergeEnvironment lets explicit environment entries win over env_file ones.
+func mergeEnvironment(fromFiles, explicit []string) []string {
+	if len(fromFiles) == 0 {
+		return explicit
+	}
+	set := make(map[string]bool, len(explicit))
+	for _, entry := range explicit {
+		key, _, _ := strings.Cut(entry, "=")
+		set[key] = true
+	}
+	env := make([]string, 0, len(fromFiles)+len(explicit))
+	for _, entry := range fromFiles {
+		key, _, _ := strings.Cut(entry, "=")
+		if !set[key] {
+			env = append(env, entry)
+		}
+	}
+	return append(env, explicit...)
+}
+
+// ProjectPath resolves name against the project directory dir, following
+// symlinks, and fails when the result is not inside dir.
+func ProjectPath(dir, name string) (string, error) {
+	path := name
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	// Check the path as written first so nothing outside dir is looked at.
+	if !insideDir(filepath.Clean(dir), filepath.Clean(path)) {
+		return "", fmt.Errorf("%s is outside the project directory", name)
+	}
+	root, err := filepath.EvalSymlinks(filepath.Clean(dir))
+	if err != nil {
+		return "", err
+	}
+	resolved, err := filepath.EvalSymlinks(filepath.Clean(path))
+	if err != nil {
+		return "", err
+	}
+	if !insideDir(root, resolved) {
+		return "", fmt.Errorf("%s is outside the project directory", name)
+	}
+	return resolved, nil
+}
+
+func insideDir(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,120 @@
+package compose
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+)
+
+func TestParsePort(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    domain.PortSpec
+		wantErr string
+	}{
+		{"80", domain.PortSpec{ContainerPort: "80", Protocol: "tcp"}, ""},
+		{"8080:80", domain.PortSpec{HostPort: "8080", ContainerPort: "80", Protocol: "tcp"}, ""},
+		{"127.0.0.1:8080:80/udp", domain.PortSpec{HostIP: "127.0.0.1", HostPort: "8080", ContainerPort: "80", Protocol: "udp"}, ""},
+		{"127.0.0.1::80", domain.PortSpec{HostIP: "127.0.0.1", ContainerPort: "80", Protocol: "tcp"}, ""},
+		{"[::1]:8080:80", domain.PortSpec{HostIP: "::1", HostPort: "8080", ContainerPort: "80", Protocol: "tcp"}, ""},
+		{"[::1]:80", domain.PortSpec{HostIP: "::1", ContainerPort: "80", Protocol: "tcp"}, ""},
+		{"8000-8010:80", domain.PortSpec{}, "port ranges are not supported"},
+		{"0", domain.PortSpec{}, `invalid container port "0"`},
+		{"70000:80", domain.PortSpec{}, `invalid host port "70000"`},
+		{"80/icmp", domain.PortSpec{}, `unsupported protocol "icmp"`},
+		{"a:b:c:d", domain.PortSpec{}, `invalid port "a:b:c:d"`},
+		{"[::1:80", domain.PortSpec{}, `invalid port "[::1:80"`},
+	}
+	for _, tt := range tests {
+		got, err := ParsePort(tt.spec)
+		if tt.wantErr != "" {
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("ParsePort(%q) error = %v, want %q", tt.spec, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParsePort(%q) error = %v", tt.spec, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParsePort(%q) = %+v, want %+v", tt.spec, got, tt.want)
+		}
+	}
+}
+
+func TestParseMount(t *testing.T) {
+	tests := []struct {
+		spec string
+		want domain.MountSpec
+	}{
+		{"/data", domain.MountSpec{Type: "volume", Target: "/data"}},
+		{"data:/data", domain.MountSpec{Type: "volume", Source: "data", Target: "/data"}},
+		{"data:/data:ro", domain.MountSpec{Type: "volume", Source: "data", Target: "/data", ReadOnly: true}},
+		{"./site:/srv:rw,z", domain.MountSpec{Type: "bind", Source: "./site", Target: "/srv"}},
+		{"/var/run/docker.sock:/var/run/docker.sock:z,ro", domain.MountSpec{Type: "bind", Source: "/var/run/docker.sock", Target: "/var/run/docker.sock", ReadOnly: true}},
+		{"~/cache:/cache", domain.MountSpec{Type: "bind", Source: "~/cache", Target: "/cache"}},
+	}
+	for _, tt := range tests {
+		if got := ParseMount(tt.spec); got != tt.want {
+			t.Errorf("ParseMount(%q) = %+v, want %+v", tt.spec, got, tt.want)
+		}
+	}
+}
+
+func TestProjectPath(t *testing.T) {
+	dir := t.TempDir()
+	outside := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "config"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{filepath.Join(dir, "config", "app.env"), filepath.Join(outside, "secret.env")} {
+		if err := os.WriteFile(name, nil, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Symlink(filepath.Join(dir, "config"), filepath.Join(dir, "conf")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(outside, filepath.Join(dir, "escape")); err != nil {
+		t.Fatal(err)
+	}
+	root, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		want    string
+		wantErr string
+	}{
+		{"config/app.env", filepath.Join(root, "config", "app.env"), ""},
+		{"./config/../config/app.env", filepath.Join(root, "config", "app.env"), ""},
+		{filepath.Join(dir, "config", "app.env"), filepath.Join(root, "config", "app.env"), ""},
+		{"conf/app.env", filepath.Join(root, "config", "app.env"), ""},
+		{"config/missing.env", "", "no such file or directory"},
+		{"../secret.env", "", "../secret.env is outside the project directory"},
+		{filepath.Join(outside, "secret.env"), "", "is outside the project directory"},
+		{"escape/secret.env", "", "escape/secret.env is outside the project directory"},
+	}
+	for _, tt := range tests {
+		got, err := ProjectPath(dir, tt.name)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ProjectPath(%q) error = %v, want %q", tt.name, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ProjectPath(%q) error = %v", tt.name, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ProjectPath(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
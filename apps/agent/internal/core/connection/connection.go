@@ -0,0 +1,226 @@
+package connection
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+)
+
+const Mask = "*****"
+
+const (
+	ScopeHost    = "host"
+	ScopeNetwork = "network"
+)
+
+// Input is what the container exposes. Ports maps a container port such as
+// "5432/tcp" to its published host port; Networks maps a network name to the
+// address other containers should dial on it.
+type Input struct {
+	ID       string
+	Name     string
+	Type     string
+	Env      map[string]string
+	Ports    map[string]string
+	Networks map[string]string
+}
+
+type Credentials struct {
+	Username string
+	Password string
+	Database string
+}
+
+type endpoint struct {
+	name   string
+	scheme string
+	port   string
+	// auth adds the credentials to the URL; path is appended after the host.
+	auth bool
+	path string
+}
+
+type kind struct {
+	credentials func(env map[string]string) Credentials
+	endpoints   []endpoint
+}
+
+var kinds = map[string]kind{
+	"postgres": {
+		credentials: func(env map[string]string) Credentials {
+			user := first(env, "POSTGRES_USER", "POSTGRESQL_USERNAME")
+			if user == "" {
+				user = "postgres"
+			}
+			db := first(env, "POSTGRES_DB", "POSTGRESQL_DATABASE")
+			if db == "" {
+				db = user
+			}
+			return Credentials{Username: user, Password: first(env, "POSTGRES_PASSWORD", "POSTGRESQL_PASSWORD"), Database: db}
+		},
+		endpoints: []endpoint{{name: "postgres", scheme: "postgres", port: "5432/tcp", auth: true, path: "/{db}"}},
+	},
+	"mysql": {
+		credentials: func(env map[string]string) Credentials {
+			db := first(env, "MYSQL_DATABASE", "MARIADB_DATABASE")
+			if user := first(env, "MYSQL_USER", "MARIADB_USER"); user != "" {
+				return Credentials{Username: user, Password: first(env, "MYSQL_PASSWORD", "MARIADB_PASSWORD"), Database: db}
+			}
+			return Credentials{Username: "root", Password: first(env, "MYSQL_ROOT_PASSWORD", "MARIADB_ROOT_PASSWORD"), Database: db}
+		},
+		endpoints: []endpoint{{name: "mysql", scheme: "mysql", port: "3306/tcp", auth: true, path: "/{db}"}},
+	},
+	"redis": {
+		credentials: func(env map[string]string) Credentials {
+			return Credentials{Password: first(env, "REDIS_PASSWORD", "REDIS_PASS")}
+		},
+		endpoints: []endpoint{{name: "redis", scheme: "redis", port: "6379/tcp", auth: true, path: "/0"}},
+	},
+	"mongo": {
+		credentials: func(env map[string]string) Credentials {
+			return Credentials{
+				Username: env["MONGO_INITDB_ROOT_USERNAME"],
+				Password: env["MONGO_INITDB_ROOT_PASSWORD"],
+				Database: env["MONGO_INITDB_DATABASE"],
+			}
+		},
+		endpoints: []endpoint{{name: "mongo", scheme: "mongodb", port: "27017/tcp", auth: true, path: "/{db}?authSource=admin"}},
+	},
+	"rabbitmq": {
+		credentials: func(env map[string]string) Credentials {
+			user, pass := env["RABBITMQ_DEFAULT_USER"], env["RABBITMQ_DEFAULT_PASS"]
+			if user == "" {
+				user, pass = "guest", "guest"
+			}
+			return Credentials{Username: user, Password: pass, Database: env["RABBITMQ_DEFAULT_VHOST"]}
+		},
+		endpoints: []endpoint{
+			{name: "amqp", scheme: "amqp", port: "5672/tcp", auth: true, path: "/{db}"},
+			{name: "management", scheme: "http", port: "15672/tcp"},
+		},
+	},
+	"minio": {
+		credentials: func(env map[string]string) Credentials {
+			return Credentials{Username: env["MINIO_ROOT_USER"], Password: env["MINIO_ROOT_PASSWORD"]}
+		},
+		endpoints: []endpoint{
+			{name: "api", scheme: "http", port: "9000/tcp"},
+			{name: "console", scheme: "http", port: "9001/tcp"},
+		},
+	},
+	"mailhog": {
+		credentials: func(env map[string]string) Credentials { return Credentials{} },
+		endpoints: []endpoint{
+			{name: "smtp", scheme: "smtp", port: "1025/tcp"},
+			{name: "ui", scheme: "http", port: "8025/tcp"},
+		},
+	},
+}
+
+// CredentialsFor reads the credentials a resource was configured with from
+// its environment, applying the image defaults.
+func CredentialsFor(resourceType string, env map[string]string) (Credentials, bool) {
+	k, ok := kinds[resourceType]
+	if !ok {
+		return Credentials{}, false
+	}
+	return k.credentials(env), true
+}
+
+func Supported(resourceType string) bool {
+	_, ok := kinds[resourceType]
+	return ok
+}
+
+// Build derives connection URLs for a known resource type. Secrets are
+// replaced with Mask unless reveal is set.
+func Build(in Input, reveal bool) (*domain.ConnectionInfo, error) {
+	k, ok := kinds[in.Type]
+	if !ok {
+		return nil, fmt.Errorf("no connection info for resource type %q", in.Type)
+	}
+	creds := k.credentials(in.Env)
+	password := creds.Password
+	if !reveal && password != "" {
+		password = Mask
+	}
+
+	info := &domain.ConnectionInfo{
+		ResourceID: in.ID,
+		Name:       in.Name,
+		Type:       in.Type,
+		Username:   creds.Username,
+		Password:   password,
+		Database:   creds.Database,
+		Revealed:   reveal,
+		Endpoints:  make([]domain.ConnectionEndpoint, 0),
+	}
+
+	networks := make([]string, 0, len(in.Networks))
+	for network := range in.Networks {
+		networks = append(networks, network)
+	}
+	sort.Strings(networks)
+
+	for _, e := range k.endpoints {
+		if hostPort := in.Ports[e.port]; hostPort != "" {
+			info.Endpoints = append(info.Endpoints, domain.ConnectionEndpoint{
+				Name:  e.name,
+				Scope: ScopeHost,
+				URL:   buildURL(e, "localhost", hostPort, creds.Username, password, creds.Database),
+			})
+		}
+		containerPort, _, _ := strings.Cut(e.port, "/")
+		for _, network := range networks {
+			if in.Networks[network] == "" {
+				continue
+			}
+			info.Endpoints = append(info.Endpoints, domain.ConnectionEndpoint{
+				Name:    e.name,
+				Scope:   ScopeNetwork,
+				Network: network,
+				URL:     buildURL(e, in.Networks[network], containerPort, creds.Username, password, creds.Database),
+			})
+		}
+	}
+	return info, nil
+}
+
+func buildURL(e endpoint, host, port, username, password, database string) string {
+	u := url.URL{Scheme: e.scheme, Host: host + ":" + port}
+	masked := password == Mask
+	if masked {
+		// Keep the mask readable instead of percent-encoding it.
+		password = "mask"
+	}
+	if e.auth {
+		switch {
+		case username != "" && password != "":
+			u.User = url.UserPassword(username, password)
+		case username != "":
+			u.User = url.User(username)
+		case password != "":
+			u.User = url.UserPassword("", password)
+		}
+	}
+	s := u.String()
+	if masked {
+		s = strings.Replace(s, ":mask@", ":"+Mask+"@", 1)
+	}
+	if e.path != "" {
+		s += strings.ReplaceAll(e.path, "{db}", url.PathEscape(database))
+	}
+	return s
+}
+
+func first(env map[string]string, keys ...string) string {
+	for _, key := range keys {
+		if value := env[key]; value != "" {
+			return value
+		}
+	}
+	return ""
+}
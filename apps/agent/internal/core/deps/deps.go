@@ -0,0 +1,161 @@
+package deps
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+)
+
+const (
+	ConditionStarted  = "started"
+	ConditionHealthy  = "healthy"
+	ConditionPortOpen = "port_open"
+)
+
+const DefaultTimeout = 60 * time.Second
+
+// Normalize validates a dependency and fills in the default condition.
+func Normalize(dep domain.ResourceDependency) (domain.ResourceDependency, error) {
+	dep.Resource = strings.TrimSpace(dep.Resource)
+	if dep.Resource == "" {
+		return dep, fmt.Errorf("dependency resource cannot be empty")
+	}
+	if dep.Condition == "" {
+		dep.Condition = ConditionStarted
+	}
+	switch dep.Condition {
+	case ConditionStarted, ConditionHealthy:
+		if dep.Port != "" {
+			return dep, fmt.Errorf("dependency %s: port only applies to port_open", dep.Resource)
+		}
+	case ConditionPortOpen:
+		if dep.Port != "" {
+			if n, err := strconv.Atoi(strings.TrimSuffix(dep.Port, "/tcp")); err != nil || n < 1 || n > 65535 {
+				return dep, fmt.Errorf("dependency %s: invalid port %q", dep.Resource, dep.Port)
+			}
+		}
+	default:
+		return dep, fmt.Errorf("dependency %s: unknown condition %q, use started, healthy or port_open", dep.Resource, dep.Condition)
+	}
+	if dep.Timeout != "" {
+		if d, err := time.ParseDuration(dep.Timeout); err != nil || d <= 0 {
+			return dep, fmt.Errorf("dependency %s: invalid timeout %q", dep.Resource, dep.Timeout)
+		}
+	}
+	return dep, nil
+}
+
+func Timeout(dep domain.ResourceDependency) time.Duration {
+	if d, err := time.ParseDuration(dep.Timeout); err == nil && d > 0 {
+		return d
+	}
+	return DefaultTimeout
+}
+
+// Encode stores dependencies in a container label.
+func Encode(deps []domain.ResourceDependency) string {
+	if len(deps) == 0 {
+		return ""
+	}
+	data, _ := json.Marshal(deps)
+	return string(data)
+}
+
+func Decode(label string) ([]domain.ResourceDependency, error) {
+	if strings.TrimSpace(label) == "" {
+		return nil, nil
+	}
+	var deps []domain.ResourceDependency
+	if err := json.Unmarshal([]byte(label), &deps); err != nil {
+		return nil, fmt.Errorf("invalid dependency label: %w", err)
+	}
+	return deps, nil
+}
+
+// Order returns the nodes of graph so every node comes after the nodes it
+// points to. Edges to nodes outside the graph are ignored. Ties are broken
+// alphabetically to keep runs stable.
+func Order(graph map[string][]string) ([]string, error) {
+	pending := make(map[string]int, len(graph))
+	for name := range graph {
+		pending[name] = 0
+	}
+	dependents := make(map[string][]string)
+	for name, edges := range graph {
+		for _, dep := range edges {
+			if _, ok := pending[dep]; !ok {
+				continue
+			}
+			pending[name]++
+			dependents[dep] = append(dependents[dep], name)
+		}
+	}
+
+	ready := make([]string, 0)
+	for name, count := range pending {
+		if count == 0 {
+			ready = append(ready, name)
+		}
+	}
+
+	order := make([]string, 0, len(pending))
+	for len(ready) > 0 {
+		sort.Strings(ready)
+		name := ready[0]
+		ready = ready[1:]
+		order = append(order, name)
+		for _, dependent := range dependents[name] {
+			pending[dependent]--
+			if pending[dependent] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+
+	if len(order) != len(pending) {
+		cycle := make([]string, 0)
+		for name, count := range pending {
+			if count > 0 {
+				cycle = append(cycle, name)
+			}
+		}
+		sort.Strings(cycle)
+		return nil, fmt.Errorf("dependency cycle between resources: %s", strings.Join(cycle, ", "))
+	}
+	return order, nil
+}
+
+// Closure is the subgraph reachable from root, root included.
+func Closure(graph map[string][]string, root string) map[string][]string {
+	sub := make(map[string][]string)
+	queue := []string{root}
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		if _, ok := sub[name]; ok {
+			continue
+		}
+		sub[name] = graph[name]
+		queue = append(queue, graph[name]...)
+	}
+	return sub
+}
+
+// Reverse turns "depends on" edges into "is needed by" edges.
+func Reverse(graph map[string][]string) map[string][]string {
+	reversed := make(map[string][]string, len(graph))
+	for name, edges := range graph {
+		if _, ok := reversed[name]; !ok {
+			reversed[name] = nil
+		}
+		for _, dep := range edges {
+			reversed[dep] = append(reversed[dep], name)
+		}
+	}
+	return reversed
+}
@@ -0,0 +1,141 @@
+package deps
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+)
+
+func TestOrder(t *testing.T) {
+	tests := []struct {
+		name    string
+		graph   map[string][]string
+		want    []string
+		wantErr string
+	}{
+		{"empty", map[string][]string{}, []string{}, ""},
+		{"independent nodes sort by name", map[string][]string{"b": nil, "a": nil, "c": nil}, []string{"a", "b", "c"}, ""},
+		{"chain", map[string][]string{"api": {"db"}, "db": {"net"}, "net": nil}, []string{"net", "db", "api"}, ""},
+		{"diamond", map[string][]string{"api": {"db", "cache"}, "db": nil, "cache": nil, "web": {"api"}}, []string{"cache", "db", "api", "web"}, ""},
+		{"edges outside the graph are ignored", map[string][]string{"api": {"external"}}, []string{"api"}, ""},
+		{"self loop", map[string][]string{"api": {"api"}}, nil, "dependency cycle between resources: api"},
+		{"cycle", map[string][]string{"a": {"b"}, "b": {"c"}, "c": {"a"}, "d": nil}, nil, "dependency cycle between resources: a, b, c"},
+		{"cycle reports dependents too", map[string][]string{"a": {"b"}, "b": {"a"}, "web": {"a"}}, nil, "dependency cycle between resources: a, b, web"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Order(tt.graph)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("Order() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Order() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Order() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClosure(t *testing.T) {
+	graph := map[string][]string{
+		"web":   {"api"},
+		"api":   {"db", "cache"},
+		"db":    nil,
+		"cache": nil,
+		"other": {"db"},
+		"a":     {"b"},
+		"b":     {"a"},
+	}
+	tests := []struct {
+		root string
+		want []string
+	}{
+		{"web", []string{"api", "cache", "db", "web"}},
+		{"api", []string{"api", "cache", "db"}},
+		{"db", []string{"db"}},
+		{"a", []string{"a", "b"}},
+		{"unknown", []string{"unknown"}},
+	}
+	for _, tt := range tests {
+		sub := Closure(graph, tt.root)
+		got := make([]string, 0, len(sub))
+		for name := range sub {
+			got = append(got, name)
+		}
+		sort.Strings(got)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Closure(%q) = %v, want %v", tt.root, got, tt.want)
+		}
+	}
+}
+
+func TestReverse(t *testing.T) {
+	got := Reverse(map[string][]string{"api": {"db"}, "web": {"api", "db"}})
+	for name := range got {
+		sort.Strings(got[name])
+	}
+	want := map[string][]string{"api": {"web"}, "db": {"api", "web"}, "web": nil}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Reverse() = %v, want %v", got, want)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name    string
+		dep     domain.ResourceDependency
+		want    domain.ResourceDependency
+		wantErr string
+	}{
+		{"default condition", domain.ResourceDependency{Resource: " db "}, domain.ResourceDependency{Resource: "db", Condition: ConditionStarted}, ""},
+		{"healthy", domain.ResourceDependency{Resource: "db", Condition: ConditionHealthy, Timeout: "30s"}, domain.ResourceDependency{Resource: "db", Condition: ConditionHealthy, Timeout: "30s"}, ""},
+		{"port open", domain.ResourceDependency{Resource: "db", Condition: ConditionPortOpen, Port: "5432/tcp"}, domain.ResourceDependency{Resource: "db", Condition: ConditionPortOpen, Port: "5432/tcp"}, ""},
+		{"empty resource", domain.ResourceDependency{}, domain.ResourceDependency{}, "cannot be empty"},
+		{"port without port_open", domain.ResourceDependency{Resource: "db", Port: "5432"}, domain.ResourceDependency{}, "port only applies"},
+		{"invalid port", domain.ResourceDependency{Resource: "db", Condition: ConditionPortOpen, Port: "70000"}, domain.ResourceDependency{}, "invalid port"},
+		{"unknown condition", domain.ResourceDependency{Resource: "db", Condition: "ready"}, domain.ResourceDependency{}, "unknown condition"},
+		{"invalid timeout", domain.ResourceDependency{Resource: "db", Timeout: "-1s"}, domain.ResourceDependency{}, "invalid timeout"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Normalize(tt.dep)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Normalize() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Normalize() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Normalize() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncodeDecode(t *testing.T) {
+	deps := []domain.ResourceDependency{{Resource: "db", Condition: ConditionHealthy}, {Resource: "cache", Condition: ConditionStarted}}
+	got, err := Decode(Encode(deps))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, deps) {
+		t.Errorf("Decode(Encode()) = %v, want %v", got, deps)
+	}
+	if Encode(nil) != "" {
+		t.Errorf("Encode(nil) = %q, want empty", Encode(nil))
+	}
+	if _, err := Decode("not json"); err == nil {
+		t.Error("Decode(invalid) succeeded, want an error")
+	}
+}
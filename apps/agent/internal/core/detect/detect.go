@@ -0,0 +1,109 @@
+package detect
+
+import (
+	"strings"
+	"sync"
+)
+
+const (
+	TypeCompute = "compute"
+	TypeCustom  = "custom"
+)
+
+// Subject is what detectors look at. Labels are the container labels, which
+// already include the labels baked into the image.
+type Subject struct {
+	Image   string
+	Labels  map[string]string
+	Ports   []string
+	Command string
+}
+
+type Detector interface {
+	Name() string
+	Detect(s Subject) (string, bool)
+}
+
+// Registry runs detectors in order and returns the first type reported.
+type Registry struct {
+	mu        sync.RWMutex
+	detectors []Detector
+	fallback  string
+}
+
+func NewRegistry(detectors ...Detector) *Registry {
+	return &Registry{detectors: detectors, fallback: TypeCompute}
+}
+
+// NewDefaultRegistry honours an explicit devcon.resource_type label first,
+// then image names, image labels, entrypoints and finally well-known ports.
+// Compose containers nothing recognises are reported as custom.
+func NewDefaultRegistry() *Registry {
+	detectors := []Detector{LabelDetector{Label: "devcon.resource_type"}}
+	for _, kind := range []func(Rule) bool{hasImages, hasLabels, hasCommand, hasPorts} {
+		for _, rule := range builtinRules {
+			if kind(rule) {
+				detectors = append(detectors, RuleDetector{Rule: rule})
+			}
+		}
+	}
+	detectors = append(detectors, LabelDetector{Label: "com.docker.compose.project", Type: TypeCustom})
+	return NewRegistry(detectors...)
+}
+
+// Register adds a detector at the end of the chain.
+func (r *Registry) Register(d Detector) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.detectors = append(r.detectors, d)
+}
+
+// Prepend adds detectors ahead of the built-in ones, after an explicit type
+// label, so user rules win over the defaults.
+func (r *Registry) Prepend(detectors ...Detector) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	head := 0
+	if len(r.detectors) > 0 {
+		if label, ok := r.detectors[0].(LabelDetector); ok && label.Type == "" {
+			head = 1
+		}
+	}
+	chain := make([]Detector, 0, len(r.detectors)+len(detectors))
+	chain = append(chain, r.detectors[:head]...)
+	chain = append(chain, detectors...)
+	chain = append(chain, r.detectors[head:]...)
+	r.detectors = chain
+}
+
+func (r *Registry) Detect(s Subject) string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, d := range r.detectors {
+		if t, ok := d.Detect(s); ok && t != "" {
+			return t
+		}
+	}
+	return r.fallback
+}
+
+// LabelDetector reports the label's value as the type, or Type when set.
+type LabelDetector struct {
+	Label string
+	Type  string
+}
+
+func (d LabelDetector) Name() string {
+	return "label:" + d.Label
+}
+
+func (d LabelDetector) Detect(s Subject) (string, bool) {
+	value := strings.TrimSpace(s.Labels[d.Label])
+	if value == "" {
+		return "", false
+	}
+	if d.Type != "" {
+		return d.Type, true
+	}
+	return value, true
+}
@@ -0,0 +1,135 @@
+package detect
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDefaultRegistry(t *testing.T) {
+	tests := []struct {
+		name    string
+		subject Subject
+		want    string
+	}{
+		{"official image", Subject{Image: "postgres:16-alpine"}, "postgres"},
+		{"registry and library prefix", Subject{Image: "docker.io/library/redis:7"}, "redis"},
+		{"registry with port", Subject{Image: "localhost:5000/mysql:8.4"}, "mysql"},
+		{"digest", Subject{Image: "mongo@sha256:0123"}, "mongo"},
+		{"namespaced image matches last segment", Subject{Image: "bitnami/postgresql:16"}, "postgres"},
+		{"path pattern", Subject{Image: "supabase/postgres:15"}, "postgres"},
+		{"image prefix only", Subject{Image: "valkey/valkey:8"}, "redis"},
+		{"explicit label wins", Subject{Image: "postgres:16", Labels: map[string]string{"devcon.resource_type": "warehouse"}}, "warehouse"},
+		{"image title label", Subject{Image: "acme/db:1", Labels: map[string]string{"org.opencontainers.image.title": "PostgreSQL"}}, "postgres"},
+		{"command", Subject{Image: "acme/cache:1", Command: "/usr/local/bin/redis-server --port 7000"}, "redis"},
+		{"command needs a word boundary", Subject{Image: "acme/app:1", Command: "postgres-exporter"}, TypeCompute},
+		{"well-known port", Subject{Image: "acme/db:1", Ports: []string{"5432/tcp"}}, "postgres"},
+		{"udp port does not match", Subject{Image: "acme/db:1", Ports: []string{"5432/udp"}}, TypeCompute},
+		{"image beats port", Subject{Image: "redis:7", Ports: []string{"5432/tcp"}}, "redis"},
+		{"compose service", Subject{Image: "acme/app:1", Labels: map[string]string{"com.docker.compose.project": "shop"}}, TypeCustom},
+		{"unknown", Subject{Image: "nginx:1.27", Ports: []string{"80/tcp"}}, TypeCompute},
+		{"empty", Subject{}, TypeCompute},
+	}
+	registry := NewDefaultRegistry()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := registry.Detect(tt.subject); got != tt.want {
+				t.Errorf("Detect() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRuleDetector(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    Rule
+		subject Subject
+		want    bool
+	}{
+		{"no criteria", Rule{Type: "x"}, Subject{Image: "x"}, false},
+		{"image glob", Rule{Type: "x", Images: []string{"clickhouse*"}}, Subject{Image: "clickhouse/clickhouse-server:24"}, true},
+		{"image is case insensitive", Rule{Type: "x", Images: []string{"ClickHouse*"}}, Subject{Image: "clickhouse-server"}, true},
+		{"label present", Rule{Type: "x", Labels: map[string]string{"team": ""}}, Subject{Labels: map[string]string{"team": "data"}}, true},
+		{"label missing", Rule{Type: "x", Labels: map[string]string{"team": ""}}, Subject{}, false},
+		{"label regexp", Rule{Type: "x", Labels: map[string]string{"team": "^data$"}}, Subject{Labels: map[string]string{"team": "database"}}, false},
+		{"port without protocol means tcp", Rule{Type: "x", Ports: []string{"8123"}}, Subject{Ports: []string{"9000/tcp", "8123/tcp"}}, true},
+		{"any command", Rule{Type: "x", Command: []string{"^a", "^b"}}, Subject{Command: "b --flag"}, true},
+		{"every criterion must match", Rule{Type: "x", Images: []string{"acme/*"}, Ports: []string{"80"}}, Subject{Image: "acme/web", Ports: []string{"443/tcp"}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			typ, ok := RuleDetector{Rule: tt.rule}.Detect(tt.subject)
+			if ok != tt.want {
+				t.Fatalf("Detect() ok = %v, want %v", ok, tt.want)
+			}
+			if ok && typ != tt.rule.Type {
+				t.Errorf("Detect() = %q, want %q", typ, tt.rule.Type)
+			}
+		})
+	}
+}
+
+func TestPrependRules(t *testing.T) {
+	registry := NewDefaultRegistry()
+	registry.Prepend(RuleDetectors([]Rule{{Type: "timeseries", Images: []string{"timescaledb*"}}})...)
+
+	tests := []struct {
+		subject Subject
+		want    string
+	}{
+		{Subject{Image: "timescale/timescaledb:latest-pg16"}, "timeseries"},
+		{Subject{Image: "timescale/timescaledb:latest-pg16", Labels: map[string]string{"devcon.resource_type": "postgres"}}, "postgres"},
+		{Subject{Image: "postgres:16"}, "postgres"},
+	}
+	for _, tt := range tests {
+		if got := registry.Detect(tt.subject); got != tt.want {
+			t.Errorf("Detect(%+v) = %q, want %q", tt.subject, got, tt.want)
+		}
+	}
+}
+
+func TestLoadRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    int
+		wantErr string
+	}{
+		{"yaml", "rules:\n  - type: clickhouse\n    images: [clickhouse*]\n    ports: [\"8123\"]\n", 1, ""},
+		{"json", `{"rules":[{"type":"a","command":["^a"]},{"type":"b","labels":{"team":"b"}}]}`, 2, ""},
+		{"missing type", "rules:\n  - images: [x]\n", 0, "rule 1: type is required"},
+		{"no criteria", "rules:\n  - type: x\n", 0, "rule 1: at least one of"},
+		{"bad image pattern", "rules:\n  - type: x\n    images: [\"[\"]\n", 0, "invalid image pattern"},
+		{"bad label pattern", "rules:\n  - type: x\n    labels: {team: \"(\"}\n", 0, "invalid label pattern"},
+		{"bad command pattern", "rules:\n  - type: ok\n    images: [ok]\n  - type: x\n    command: [\"(\"]\n", 0, "rule 2: invalid command pattern"},
+		{"not yaml", "rules: [", 0, "detection rules"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "rules.yaml")
+			if err := os.WriteFile(file, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			rules, err := LoadRules(file)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("LoadRules() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadRules() error = %v", err)
+			}
+			if len(rules) != tt.want {
+				t.Errorf("LoadRules() returned %d rules, want %d", len(rules), tt.want)
+			}
+		})
+	}
+
+	rules, err := LoadRules(filepath.Join(t.TempDir(), "missing.yaml"))
+	if err != nil || rules != nil {
+		t.Errorf("LoadRules(missing) = %v, %v, want no rules", rules, err)
+	}
+}
@@ -0,0 +1,224 @@
+package detect
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/goccy/go-yaml"
+)
+
+// Rule matches when every criterion it sets matches; within a criterion any
+// entry may match. Images are glob patterns on the repository without tag
+// or registry, and a pattern without "/" is matched against the last path
+// segment. Label values and Command are regular expressions.
+type Rule struct {
+	Type    string            `json:"type"`
+	Images  []string          `json:"images,omitempty"`
+	Labels  map[string]string `json:"labels,omitempty"`
+	Ports   []string          `json:"ports,omitempty"`
+	Command []string          `json:"command,omitempty"`
+}
+
+type RuleDetector struct {
+	Rule Rule
+}
+
+func (d RuleDetector) Name() string {
+	return "rule:" + d.Rule.Type
+}
+
+func (d RuleDetector) Detect(s Subject) (string, bool) {
+	r := d.Rule
+	if !hasImages(r) && !hasLabels(r) && !hasPorts(r) && !hasCommand(r) {
+		return "", false
+	}
+	if hasImages(r) && !matchImage(r.Images, s.Image) {
+		return "", false
+	}
+	if hasLabels(r) && !matchLabels(r.Labels, s.Labels) {
+		return "", false
+	}
+	if hasPorts(r) && !matchPorts(r.Ports, s.Ports) {
+		return "", false
+	}
+	if hasCommand(r) && !matchAny(r.Command, s.Command) {
+		return "", false
+	}
+	return r.Type, true
+}
+
+func hasImages(r Rule) bool  { return len(r.Images) > 0 }
+func hasLabels(r Rule) bool  { return len(r.Labels) > 0 }
+func hasPorts(r Rule) bool   { return len(r.Ports) > 0 }
+func hasCommand(r Rule) bool { return len(r.Command) > 0 }
+
+var builtinRules = []Rule{
+	{Type: "postgres", Images: []string{"postgres*", "postgis", "timescaledb*", "pgvector", "supabase/postgres", "cockroachdb/cockroach"}},
+	{Type: "mysql", Images: []string{"mysql*", "mariadb*", "percona*"}},
+	{Type: "redis", Images: []string{"redis*", "valkey*", "keydb*", "dragonfly*"}},
+	{Type: "mongo", Images: []string{"mongo", "mongodb*"}},
+	{Type: "rabbitmq", Images: []string{"rabbitmq*"}},
+	{Type: "minio", Images: []string{"minio"}},
+	{Type: "mailhog", Images: []string{"mailhog", "mailpit"}},
+
+	{Type: "postgres", Labels: map[string]string{"org.opencontainers.image.title": "(?i)postgres"}},
+	{Type: "mysql", Labels: map[string]string{"org.opencontainers.image.title": "(?i)mysql|mariadb"}},
+	{Type: "redis", Labels: map[string]string{"org.opencontainers.image.title": "(?i)redis|valkey"}},
+
+	{Type: "postgres", Command: []string{`(^|[\s/])postgres(\s|$)`}},
+	{Type: "mysql", Command: []string{`(^|[\s/])(mysqld|mariadbd)(\s|$)`}},
+	{Type: "redis", Command: []string{`(^|[\s/])(redis-server|valkey-server)(\s|$)`}},
+	{Type: "mongo", Command: []string{`(^|[\s/])mongod(\s|$)`}},
+	{Type: "rabbitmq", Command: []string{`rabbitmq-server`}},
+	{Type: "minio", Command: []string{`(^|[\s/])minio server`}},
+
+	{Type: "postgres", Ports: []string{"5432/tcp"}},
+	{Type: "mysql", Ports: []string{"3306/tcp"}},
+	{Type: "redis", Ports: []string{"6379/tcp"}},
+	{Type: "mongo", Ports: []string{"27017/tcp"}},
+	{Type: "rabbitmq", Ports: []string{"5672/tcp"}},
+}
+
+type rulesFile struct {
+	Rules []Rule `json:"rules"`
+}
+
+// LoadRules reads a YAML or JSON rules file. A missing file yields no rules.
+func LoadRules(file string) ([]Rule, error) {
+	content, err := os.ReadFile(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var parsed rulesFile
+	if err := yaml.Unmarshal(content, &parsed); err != nil {
+		return nil, fmt.Errorf("detection rules %s: %w", file, err)
+	}
+	for i, rule := range parsed.Rules {
+		if err := validateRule(rule); err != nil {
+			return nil, fmt.Errorf("detection rules %s: rule %d: %w", file, i+1, err)
+		}
+	}
+	return parsed.Rules, nil
+}
+
+func validateRule(r Rule) error {
+	if strings.TrimSpace(r.Type) == "" {
+		return fmt.Errorf("type is required")
+	}
+	if !hasImages(r) && !hasLabels(r) && !hasPorts(r) && !hasCommand(r) {
+		return fmt.Errorf("at least one of images, labels, ports or command is required")
+	}
+	for _, pattern := range r.Images {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid image pattern %q", pattern)
+		}
+	}
+	for _, expr := range r.Labels {
+		if _, err := regexp.Compile(expr); err != nil {
+			return fmt.Errorf("invalid label pattern %q: %w", expr, err)
+		}
+	}
+	for _, expr := range r.Command {
+		if _, err := regexp.Compile(expr); err != nil {
+			return fmt.Errorf("invalid command pattern %q: %w", expr, err)
+		}
+	}
+	return nil
+}
+
+// RuleDetectors wraps rules so they can be added to a registry.
+func RuleDetectors(rules []Rule) []Detector {
+	detectors := make([]Detector, 0, len(rules))
+	for _, rule := range rules {
+		detectors = append(detectors, RuleDetector{Rule: rule})
+	}
+	return detectors
+}
+
+// repository strips registry host, tag and digest, and docker.io's library/
+// prefix: "docker.io/library/postgres:16" becomes "postgres".
+func repository(image string) string {
+	image = strings.ToLower(strings.TrimSpace(image))
+	if i := strings.Index(image, "@"); i >= 0 {
+		image = image[:i]
+	}
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		image = image[:i]
+	}
+	if first, rest, ok := strings.Cut(image, "/"); ok && (strings.ContainsAny(first, ".:") || first == "localhost") {
+		image = rest
+	}
+	return strings.TrimPrefix(image, "library/")
+}
+
+func matchImage(patterns []string, image string) bool {
+	repo := repository(image)
+	if repo == "" {
+		return false
+	}
+	last := path.Base(repo)
+	for _, pattern := range patterns {
+		target := repo
+		if !strings.Contains(pattern, "/") {
+			target = last
+		}
+		if ok, _ := path.Match(strings.ToLower(pattern), target); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func matchLabels(patterns map[string]string, labels map[string]string) bool {
+	for key, expr := range patterns {
+		value, ok := labels[key]
+		if !ok {
+			return false
+		}
+		if expr != "" && !matchAny([]string{expr}, value) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchPorts(want, have []string) bool {
+	for _, w := range want {
+		if !strings.Contains(w, "/") {
+			w += "/tcp"
+		}
+		for _, h := range have {
+			if h == w {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+var compiledPatterns sync.Map
+
+func matchAny(exprs []string, value string) bool {
+	for _, expr := range exprs {
+		cached, ok := compiledPatterns.Load(expr)
+		if !ok {
+			re, err := regexp.Compile(expr)
+			if err != nil {
+				continue
+			}
+			cached, _ = compiledPatterns.LoadOrStore(expr, re)
+		}
+		if cached.(*regexp.Regexp).MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"context"
+	"io"
+)
+
+type BackupRepository interface {
+	CreateBackup(ctx context.Context, backup *Backup, content io.Reader) error
+	ListBackups(ctx context.Context) ([]Backup, error)
+	GetBackup(ctx context.Context, id string) (*Backup, error)
+	OpenBackup(ctx context.Context, id string) (io.ReadCloser, error)
+	DeleteBackup(ctx context.Context, id string) error
+	ListBackupPolicies(ctx context.Context) ([]BackupPolicy, error)
+	GetBackupPolicy(ctx context.Context, resource string) (*BackupPolicy, error)
+	SaveBackupPolicy(ctx context.Context, policy *BackupPolicy) error
+	DeleteBackupPolicy(ctx context.Context, resource string) error
+}
+
+// Backup is one logical dump. Backups are keyed by resource name rather than
+// container ID so they outlive the container they were taken from.
+type Backup struct {
+	ID           string `json:"id"`
+	Resource     string `json:"resource"`
+	ResourceType string `json:"resource_type"`
+	Format       string `json:"format"`
+	Database     string `json:"database,omitempty"`
+	File         string `json:"file"`
+	SizeBytes    int64  `json:"size_bytes"`
+	Trigger      string `json:"trigger"`
+	CreatedAt    int64  `json:"created_at"`
+}
+
+// BackupRetention prunes a resource's backups after each new one. Zero
+// values disable a rule; the newest backup is always kept.
+type BackupRetention struct {
+	KeepLast   int `json:"keep_last,omitempty"`
+	MaxAgeDays int `json:"max_age_days,omitempty"`
+}
+
+type BackupPolicy struct {
+	Resource   string          `json:"resource"`
+	Schedule   string          `json:"schedule"`
+	Database   string          `json:"database,omitempty"`
+	Retention  BackupRetention `json:"retention"`
+	Disabled   bool            `json:"disabled,omitempty"`
+	LastRunAt  int64           `json:"last_run_at,omitempty"`
+	LastBackup string          `json:"last_backup,omitempty"`
+	LastError  string          `json:"last_error,omitempty"`
+}
+
+type BackupRequest struct {
+	Database string `json:"database"`
+}
+
+// RestoreRequest restores a backup into Target, or into the resource it was
+// taken from when Target is empty.
+type RestoreRequest struct {
+	Target   string `json:"target"`
+	Database string `json:"database"`
+}
+
+type RestoreResult struct {
+	Backup   string `json:"backup"`
+	Target   string `json:"target"`
+	Database string `json:"database,omitempty"`
+}
@@ -0,0 +1,39 @@
+package domain
+
+const (
+	BulkStart   = "start"
+	BulkStop    = "stop"
+	BulkRestart = "restart"
+	BulkDelete  = "delete"
+)
+
+// BulkRequest selects resources for a bulk action. IDs holds container IDs
+// or names; Type and Selector narrow the selection down, or pick from every
+// resource when IDs is empty. Selector is a comma separated list of label
+// requirements: "key=value", "key!=value", "key" or "!key". Concurrency
+// bounds how many resources are acted on at once.
+type BulkRequest struct {
+	IDs         []string `json:"ids"`
+	Type        string   `json:"type"`
+	Selector    string   `json:"selector"`
+	Concurrency int      `json:"concurrency"`
+	DryRun      bool     `json:"dry_run"`
+}
+
+// BulkResult is the outcome for one resource. Error is set when the action
+// failed or the resource could not be found.
+type BulkResult struct {
+	ID         string `json:"id,omitempty"`
+	Name       string `json:"name"`
+	OK         bool   `json:"ok"`
+	Error      string `json:"error,omitempty"`
+	DurationMs int64  `json:"duration_ms"`
+}
+
+type BulkReport struct {
+	Action    string       `json:"action"`
+	DryRun    bool         `json:"dry_run"`
+	Results   []BulkResult `json:"results"`
+	Succeeded int          `json:"succeeded"`
+	Failed    int          `json:"failed"`
+}
@@ -0,0 +1,127 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+type ComposeProject struct {
+	Name     string                    `json:"name"`
+	Services []ComposeService          `json:"services"`
+	Networks map[string]ComposeNetwork `json:"networks"`
+	Volumes  map[string]ComposeVolume  `json:"volumes"`
+	Warnings []string                  `json:"warnings,omitempty"`
+	// ServiceEnv is the env_file variables read for each service.
+	ServiceEnv map[string][]string `json:"-"`
+}
+
+type ComposeService struct {
+	Name          string              `json:"name"`
+	Image         string              `json:"image"`
+	Build         *BuildSpec          `json:"build,omitempty"`
+	ContainerName string              `json:"container_name,omitempty"`
+	Command       []string            `json:"command,omitempty"`
+	Entrypoint    []string            `json:"entrypoint,omitempty"`
+	Environment   []string            `json:"environment,omitempty"`
+	Labels        map[string]string   `json:"labels,omitempty"`
+	Ports         []PortSpec          `json:"ports,omitempty"`
+	Expose        []string            `json:"expose,omitempty"`
+	Volumes       []MountSpec         `json:"volumes,omitempty"`
+	Networks      []NetworkAttachment `json:"networks,omitempty"`
+	DependsOn     []string            `json:"depends_on,omitempty"`
+	Restart       string              `json:"restart,omitempty"`
+	WorkingDir    string              `json:"working_dir,omitempty"`
+	User          string              `json:"user,omitempty"`
+	Hostname      string              `json:"hostname,omitempty"`
+	Privileged    bool                `json:"privileged,omitempty"`
+	Tty           bool                `json:"tty,omitempty"`
+	StdinOpen     bool                `json:"stdin_open,omitempty"`
+	StopSignal    string              `json:"stop_signal,omitempty"`
+	CapAdd        []string            `json:"cap_add,omitempty"`
+	ExtraHosts    []string            `json:"extra_hosts,omitempty"`
+	Tmpfs         []string            `json:"tmpfs,omitempty"`
+	Healthcheck   *HealthcheckSpec    `json:"healthcheck,omitempty"`
+}
+
+type ComposeNetwork struct {
+	Name     string            `json:"name"`
+	Driver   string            `json:"driver,omitempty"`
+	External bool              `json:"external,omitempty"`
+	Internal bool              `json:"internal,omitempty"`
+	Labels   map[string]string `json:"labels,omitempty"`
+}
+
+type ComposeVolume struct {
+	Name     string            `json:"name"`
+	Driver   string            `json:"driver,omitempty"`
+	External bool              `json:"external,omitempty"`
+	Labels   map[string]string `json:"labels,omitempty"`
+}
+
+type ComposeIssue struct {
+	Document int    `json:"document,omitempty"`
+	Path     string `json:"path"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+	Message  string `json:"message"`
+}
+
+type ComposeValidationError struct {
+	Issues []ComposeIssue `json:"issues"`
+}
+
+func (e *ComposeValidationError) Error() string {
+	messages := make([]string, 0, len(e.Issues))
+	for _, issue := range e.Issues {
+		prefix := issue.Path
+		if issue.Line > 0 {
+			prefix = fmt.Sprintf("line %d: %s", issue.Line, issue.Path)
+		}
+		if issue.Document > 0 {
+			prefix = fmt.Sprintf("document %d: %s", issue.Document, prefix)
+		}
+		messages = append(messages, fmt.Sprintf("%s: %s", prefix, issue.Message))
+	}
+	return "invalid compose document: " + strings.Join(messages, "; ")
+}
+
+type ComposePreview struct {
+	Project  string                  `json:"project"`
+	Services []ComposeServicePreview `json:"services"`
+	Networks []string                `json:"networks"`
+	Volumes  []string                `json:"volumes"`
+	Warnings []string                `json:"warnings,omitempty"`
+}
+
+type ComposeServicePreview struct {
+	Name          string   `json:"name"`
+	ContainerName string   `json:"container_name"`
+	Image         string   `json:"image"`
+	Ports         []string `json:"ports"`
+	DependsOn     []string `json:"depends_on,omitempty"`
+}
+
+type ServiceScaleResult struct {
+	Project  string   `json:"project"`
+	Service  string   `json:"service"`
+	Replicas int      `json:"replicas"`
+	Created  []string `json:"created,omitempty"`
+	Started  []string `json:"started,omitempty"`
+	Stopped  []string `json:"stopped,omitempty"`
+	Removed  []string `json:"removed,omitempty"`
+}
+
+type StackDownOptions struct {
+	Volumes bool `json:"volumes"`
+	Images  bool `json:"images"`
+	DryRun  bool `json:"dry_run"`
+}
+
+type StackDownReport struct {
+	Project    string   `json:"project"`
+	DryRun     bool     `json:"dry_run"`
+	Containers []string `json:"containers"`
+	Networks   []string `json:"networks"`
+	Volumes    []string `json:"volumes"`
+	Images     []string `json:"images"`
+}
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"io"
 
 	dockerclient "github.com/moby/moby/client"
 )
@@ -17,6 +18,119 @@ type ContainerRepository interface {
 	InsepectContainer(ctx context.Context, ID string) (dockerclient.ContainerInspectResult, error)
 	GetContainerLogs(ctx context.Context, ID string, tail int) (string, error)
 	EnsureImage(ctx context.Context, image string) error
+	CreateContainerFromSpec(ctx context.Context, spec *ContainerSpec) (*dockerclient.ContainerCreateResult, error)
+	ListNetworks(ctx context.Context) (dockerclient.NetworkListResult, error)
+	CreateNetwork(ctx context.Context, spec *NetworkSpec) (string, error)
+	ListVolumes(ctx context.Context) (dockerclient.VolumeListResult, error)
+	CreateVolume(ctx context.Context, spec *VolumeSpec) error
+	CreateContainerFrom(ctx context.Context, source dockerclient.ContainerInspectResult, overrides *ContainerOverrides) (*dockerclient.ContainerCreateResult, error)
+	DeleteContainerWithVolumes(ctx context.Context, id string) error
+	RemoveNetwork(ctx context.Context, id string) error
+	RemoveVolume(ctx context.Context, name string) error
+	ListImages(ctx context.Context) (dockerclient.ImageListResult, error)
+	RemoveImage(ctx context.Context, id string) error
+	BuildImage(ctx context.Context, spec BuildSpec, tag string, labels map[string]string) (string, error)
+	ExecContainer(ctx context.Context, id string, spec ExecSpec) (*ExecResult, error)
+	CommitContainer(ctx context.Context, id string, reference string) (string, error)
+	PauseContainer(ctx context.Context, id string) error
+	UnpauseContainer(ctx context.Context, id string) error
+	CopyContainerPath(ctx context.Context, sourceID, targetID, path string) error
+	ContainerEvents(ctx context.Context) (<-chan ContainerEvent, <-chan error)
+	ContainerStats(ctx context.Context, id string) (*ContainerStats, error)
+	DiskUsage(ctx context.Context) (dockerclient.DiskUsageResult, error)
+	RenameContainer(ctx context.Context, id string, name string) error
+}
+
+type ContainerSpec struct {
+	Name          string
+	Image         string
+	Command       []string
+	Entrypoint    []string
+	Env           []string
+	Labels        map[string]string
+	Ports         []PortSpec
+	Expose        []string
+	Mounts        []MountSpec
+	Networks      []NetworkAttachment
+	RestartPolicy string
+	WorkingDir    string
+	User          string
+	Hostname      string
+	StopSignal    string
+	Privileged    bool
+	Tty           bool
+	OpenStdin     bool
+	CapAdd        []string
+	ExtraHosts    []string
+	Tmpfs         []string
+	Healthcheck   *HealthcheckSpec
+}
+
+type BuildSpec struct {
+	Context    string            `json:"context"`
+	Dockerfile string            `json:"dockerfile,omitempty"`
+	Target     string            `json:"target,omitempty"`
+	Args       map[string]string `json:"args,omitempty"`
+	// ImageID is filled in after a build so the config hash changes whenever
+	// the built image does.
+	ImageID string `json:"image_id,omitempty"`
+}
+
+type PortSpec struct {
+	HostIP        string `json:"host_ip,omitempty"`
+	HostPort      string `json:"host_port,omitempty"`
+	ContainerPort string `json:"container_port"`
+	Protocol      string `json:"protocol"`
+}
+
+type MountSpec struct {
+	Type     string `json:"type"`
+	Source   string `json:"source,omitempty"`
+	Target   string `json:"target"`
+	ReadOnly bool   `json:"read_only,omitempty"`
+}
+
+type NetworkAttachment struct {
+	Name    string   `json:"name"`
+	Aliases []string `json:"aliases,omitempty"`
+}
+
+type HealthcheckSpec struct {
+	Test        []string `json:"test,omitempty"`
+	Interval    string   `json:"interval,omitempty"`
+	Timeout     string   `json:"timeout,omitempty"`
+	StartPeriod string   `json:"start_period,omitempty"`
+	Retries     int      `json:"retries,omitempty"`
+	Disable     bool     `json:"disable,omitempty"`
+}
+
+// ContainerOverrides adjusts a copied container configuration. An empty
+// HostPorts value lets docker pick a free port; Volumes renames named
+// volumes in binds and mounts.
+type ContainerOverrides struct {
+	Name         string
+	Image        string
+	Labels       map[string]string
+	RemoveLabels []string
+	HostPorts    map[string]string
+	Volumes      map[string]string
+	ResetAliases bool
+	// KeepAnonymousVolumes mounts the source's anonymous volumes into the new
+	// container instead of creating fresh ones.
+	KeepAnonymousVolumes bool
+}
+
+type NetworkSpec struct {
+	Name     string
+	Driver   string
+	Internal bool
+	Labels   map[string]string
+}
+
+type VolumeSpec struct {
+	Name   string
+	Driver string
+	Labels map[string]string
 }
 
 type ContainerCfg struct {
@@ -27,7 +141,61 @@ type ContainerCfg struct {
 	HostPort      string   `json:"hostPort"`
 	Env           []string `json:"env"`
 	Compose       string   `json:"compose"`
+
+	ComposeOverrides []string `json:"composeOverrides"`
+	Profiles         []string `json:"profiles"`
+	EnvFile          string   `json:"envFile"`
+
+	DependsOn []ResourceDependency `json:"dependsOn"`
+	Readiness *ReadinessProbe      `json:"readiness"`
+	Labels    map[string]string    `json:"-"`
+
+	TTL         string `json:"ttl"`
+	TTLAction   string `json:"ttlAction"`
+	IdleTimeout string `json:"idleTimeout"`
+
+	Group string `json:"group"`
 }
+
+// ResourceDependency makes a resource wait for another one on start.
+// Condition is "started", "healthy" or "port_open"; Port picks the container
+// port checked by port_open and Timeout is a Go duration.
+type ResourceDependency struct {
+	Resource  string `json:"resource"`
+	Condition string `json:"condition,omitempty"`
+	Port      string `json:"port,omitempty"`
+	Timeout   string `json:"timeout,omitempty"`
+}
+
+// ReadinessProbe decides when a started resource is usable. Type is "port",
+// "http", "exec", "log" or "health". Port and Path address the port and http
+// probes, Status is the expected http status (any 2xx when zero), Command is
+// run by exec and must exit 0, and Pattern is a regexp matched against log
+// lines written since the start. Timeout and Interval are Go durations.
+type ReadinessProbe struct {
+	Type     string   `json:"type"`
+	Port     string   `json:"port,omitempty"`
+	Path     string   `json:"path,omitempty"`
+	Status   int      `json:"status,omitempty"`
+	Command  []string `json:"command,omitempty"`
+	Pattern  string   `json:"pattern,omitempty"`
+	Timeout  string   `json:"timeout,omitempty"`
+	Interval string   `json:"interval,omitempty"`
+}
+
+// Readiness reports a wait for a resource to become ready. StartMs is the
+// time taken to start it and its dependencies, ReadyMs the time the probe
+// took to pass after that.
+type Readiness struct {
+	Ready    bool   `json:"ready"`
+	Probe    string `json:"probe"`
+	Attempts int    `json:"attempts"`
+	StartMs  int64  `json:"start_ms"`
+	ReadyMs  int64  `json:"ready_ms"`
+	TotalMs  int64  `json:"total_ms"`
+	Error    string `json:"error,omitempty"`
+}
+
 type Container struct {
 	ID     string
 	Image  string
@@ -42,6 +210,7 @@ type DevconStatus struct {
 	HostPort       string
 	ContainerPort  string
 	AlreadyExisted bool
+	Readiness      *Readiness `json:",omitempty"`
 }
 
 type Resource struct {
@@ -53,20 +222,99 @@ type Resource struct {
 	CreatedAt     int64    `json:"created_at"`
 	HostPorts     []string `json:"host_ports"`
 	ContainerPort []string `json:"container_ports"`
+	Stack         string   `json:"stack,omitempty"`
+	Service       string   `json:"service,omitempty"`
+	Group         string   `json:"group,omitempty"`
+
+	Timers   *ResourceTimers `json:"timers,omitempty"`
+	Trashed  bool            `json:"trashed,omitempty"`
+	Services []Resource      `json:"services,omitempty"`
+}
+
+type StackRef struct {
+	Project string `json:"project"`
+	Service string `json:"service"`
+	Replica int    `json:"replica"`
 }
 
 type ResourceDetails struct {
-	ID             string            `json:"id"`
-	Name           string            `json:"name"`
-	Image          string            `json:"image"`
-	Type           string            `json:"type"`
-	Status         string            `json:"status"`
-	CreatedAt      int64             `json:"created_at"`
-	HostPorts      []string          `json:"host_ports"`
-	ContainerPorts []string          `json:"container_ports"`
-	Command        []string          `json:"command"`
-	Env            []string          `json:"env"`
-	Labels         map[string]string `json:"labels"`
-	Networks       []string          `json:"networks"`
-	Mounts         []string          `json:"mounts"`
+	ID             string               `json:"id"`
+	Name           string               `json:"name"`
+	Image          string               `json:"image"`
+	Type           string               `json:"type"`
+	Status         string               `json:"status"`
+	CreatedAt      int64                `json:"created_at"`
+	HostPorts      []string             `json:"host_ports"`
+	ContainerPorts []string             `json:"container_ports"`
+	Command        []string             `json:"command"`
+	Env            []string             `json:"env"`
+	Labels         map[string]string    `json:"labels"`
+	Networks       []string             `json:"networks"`
+	Mounts         []string             `json:"mounts"`
+	Stack          *StackRef            `json:"stack,omitempty"`
+	ClonedFrom     string               `json:"cloned_from,omitempty"`
+	DependsOn      []ResourceDependency `json:"depends_on,omitempty"`
+	Readiness      *ReadinessProbe      `json:"readiness,omitempty"`
+	Timers         *ResourceTimers      `json:"timers,omitempty"`
+	Drift          *ResourceDrift       `json:"drift,omitempty"`
+	Protected      bool                 `json:"protected,omitempty"`
+	Trash          *TrashEntry          `json:"trash,omitempty"`
+	Group          string               `json:"group,omitempty"`
+}
+
+type ConnectionInfo struct {
+	ResourceID string               `json:"resource_id"`
+	Name       string               `json:"name"`
+	Type       string               `json:"type"`
+	Username   string               `json:"username,omitempty"`
+	Password   string               `json:"password,omitempty"`
+	Database   string               `json:"database,omitempty"`
+	Revealed   bool                 `json:"revealed"`
+	Endpoints  []ConnectionEndpoint `json:"endpoints"`
+}
+
+// ConnectionEndpoint is one URL. Scope is "host" for URLs usable from the
+// machine running docker, or "network" for URLs other containers on Network
+// can use.
+type ConnectionEndpoint struct {
+	Name    string `json:"name"`
+	Scope   string `json:"scope"`
+	Network string `json:"network,omitempty"`
+	URL     string `json:"url"`
+}
+
+// ExecSpec describes a command run inside a container. Stdout, when set,
+// receives the output as it arrives instead of it being buffered.
+type ExecSpec struct {
+	Cmd    []string
+	Env    []string
+	User   string
+	Stdin  io.Reader
+	Stdout io.Writer
+}
+
+type ExecResult struct {
+	ExitCode int
+	Stdout   []byte
+	Stderr   []byte
+}
+
+// CloneRequest copies a resource under a new name. CopyData copies the
+// source's volumes, or commits its filesystem when it has none.
+type CloneRequest struct {
+	Name     string `json:"name" binding:"required"`
+	CopyData bool   `json:"copy_data"`
+	NoStart  bool   `json:"no_start"`
+}
+
+// CloneResult reports the new resource. Data is "none", "volumes" or
+// "commit"; Ports maps container ports to the host ports docker assigned.
+type CloneResult struct {
+	Resource *DevconStatus     `json:"resource"`
+	Source   string            `json:"source"`
+	Data     string            `json:"data"`
+	Image    string            `json:"image,omitempty"`
+	Ports    map[string]string `json:"ports"`
+	Volumes  map[string]string `json:"volumes"`
+	Warnings []string          `json:"warnings,omitempty"`
 }
@@ -0,0 +1,70 @@
+package domain
+
+import "context"
+
+type GCRepository interface {
+	GetGCConfig(ctx context.Context) (*GCConfig, error)
+	SaveGCConfig(ctx context.Context, config *GCConfig) error
+	GetArtifactUsage(ctx context.Context) (map[string]int64, error)
+	SaveArtifactUsage(ctx context.Context, usage map[string]int64) error
+}
+
+const (
+	GCContainer = "container"
+	GCImage     = "image"
+	GCNetwork   = "network"
+	GCVolume    = "volume"
+)
+
+// GCConfig controls garbage collection. MaxAge is a Go duration an artifact
+// must have been unused for. Schedule is a cron expression; collection only
+// runs on its own when it is set. Named volumes are only collected when
+// Volumes is true. Protected lists resource, stack, workspace, volume,
+// network and image names that are never collected.
+type GCConfig struct {
+	MaxAge    string    `json:"max_age,omitempty"`
+	Schedule  string    `json:"schedule,omitempty"`
+	Volumes   bool      `json:"volumes,omitempty"`
+	Protected []string  `json:"protected"`
+	LastRun   *GCReport `json:"last_run,omitempty"`
+}
+
+// GCConfigRequest changes the collection settings. Empty values leave a
+// setting unchanged; "off" clears the schedule.
+type GCConfigRequest struct {
+	MaxAge   string `json:"max_age"`
+	Schedule string `json:"schedule"`
+	Volumes  *bool  `json:"volumes"`
+}
+
+// GCRequest overrides the configured settings for one collection.
+type GCRequest struct {
+	MaxAge  string `json:"max_age"`
+	Volumes *bool  `json:"volumes"`
+	DryRun  bool   `json:"dry_run"`
+}
+
+// GCCandidate is one artifact that is collected, or would be in a dry run.
+// Volumes lists the anonymous volumes removed along with a container.
+type GCCandidate struct {
+	Kind        string   `json:"kind"`
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	SizeBytes   int64    `json:"size_bytes"`
+	UnusedSince int64    `json:"unused_since"`
+	Volumes     []string `json:"volumes,omitempty"`
+	Removed     bool     `json:"removed,omitempty"`
+	Error       string   `json:"error,omitempty"`
+}
+
+type GCReport struct {
+	DryRun           bool          `json:"dry_run"`
+	MaxAge           string        `json:"max_age"`
+	Trigger          string        `json:"trigger"`
+	StartedAt        int64         `json:"started_at"`
+	FinishedAt       int64         `json:"finished_at"`
+	Candidates       []GCCandidate `json:"candidates"`
+	ReclaimableBytes int64         `json:"reclaimable_bytes"`
+	ReclaimedBytes   int64         `json:"reclaimed_bytes"`
+	Errors           int           `json:"errors"`
+}
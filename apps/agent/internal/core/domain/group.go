@@ -0,0 +1,25 @@
+package domain
+
+// Group is a named set of resources created on their own rather than from a
+// compose file. Membership is kept in a container label, so a group exists
+// as long as one of its members does.
+type Group struct {
+	Name    string     `json:"name"`
+	Status  string     `json:"status"`
+	Running int        `json:"running"`
+	Total   int        `json:"total"`
+	Members []Resource `json:"members"`
+}
+
+// GroupMembersRequest names the resources, by container ID or name, to add
+// to or remove from a group.
+type GroupMembersRequest struct {
+	Resources []string `json:"resources" binding:"required"`
+}
+
+// GroupMembersResult lists the resources whose membership changed. Resources
+// already in the requested state are not listed.
+type GroupMembersResult struct {
+	Group   string   `json:"group"`
+	Changed []string `json:"changed"`
+}
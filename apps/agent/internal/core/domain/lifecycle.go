@@ -0,0 +1,73 @@
+package domain
+
+import "context"
+
+type LifecycleRepository interface {
+	ListLifecycles(ctx context.Context) ([]Lifecycle, error)
+	GetLifecycle(ctx context.Context, resource string) (*Lifecycle, error)
+	SaveLifecycle(ctx context.Context, lifecycle *Lifecycle) error
+	DeleteLifecycle(ctx context.Context, resource string) error
+}
+
+const (
+	ExpiryStop   = "stop"
+	ExpiryDelete = "delete"
+)
+
+// Lifecycle holds the timers of a resource. ExpiresAt is when ExpiryAction
+// runs. A resource with IdleTimeout (seconds) is stopped once it has been
+// running that long without activity; LastActiveAt can be pushed into the
+// future to hold the idle timer off.
+type Lifecycle struct {
+	Resource     string `json:"resource"`
+	ExpiresAt    int64  `json:"expires_at,omitempty"`
+	ExpiryAction string `json:"expiry_action,omitempty"`
+	IdleTimeout  int64  `json:"idle_timeout,omitempty"`
+	LastActiveAt int64  `json:"last_active_at,omitempty"`
+	ExpiryWarned bool   `json:"expiry_warned,omitempty"`
+	IdleWarned   bool   `json:"idle_warned,omitempty"`
+	UpdatedAt    int64  `json:"updated_at"`
+}
+
+// LifecycleRequest sets the timers of a resource. TTL and IdleTimeout are Go
+// durations counted from now; "0" clears a timer and an empty value leaves
+// it unchanged. TTLAction is "stop" or "delete" (the default).
+type LifecycleRequest struct {
+	TTL         string `json:"ttl"`
+	TTLAction   string `json:"ttl_action"`
+	IdleTimeout string `json:"idle_timeout"`
+}
+
+// ExtendRequest pushes the expiry and the idle stop of a resource back by a
+// Go duration.
+type ExtendRequest struct {
+	By string `json:"by" binding:"required"`
+}
+
+// ResourceTimers is the lifecycle shown with a resource. IdleStopAt is when
+// the resource stops if it stays idle.
+type ResourceTimers struct {
+	ExpiresAt    int64  `json:"expires_at,omitempty"`
+	ExpiryAction string `json:"expiry_action,omitempty"`
+	IdleTimeout  int64  `json:"idle_timeout,omitempty"`
+	IdleStopAt   int64  `json:"idle_stop_at,omitempty"`
+}
+
+// ContainerStats is one usage sample. CPUTotal and SystemCPU are cumulative
+// nanoseconds and NetworkBytes the received and sent bytes of all interfaces.
+type ContainerStats struct {
+	CPUTotal     uint64
+	SystemCPU    uint64
+	OnlineCPUs   uint32
+	NetworkBytes uint64
+}
+
+// ResourceEvent is a notice about a resource, such as a timer about to fire.
+// Seq increases with every event the agent publishes.
+type ResourceEvent struct {
+	Seq      int64  `json:"seq"`
+	Time     int64  `json:"time"`
+	Kind     string `json:"kind"`
+	Resource string `json:"resource,omitempty"`
+	Message  string `json:"message"`
+}
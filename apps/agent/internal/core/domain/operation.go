@@ -0,0 +1,36 @@
+package domain
+
+const (
+	OperationRunning   = "running"
+	OperationSucceeded = "succeeded"
+	OperationFailed    = "failed"
+	OperationCanceled  = "canceled"
+)
+
+const (
+	PhaseQueued   = "queued"
+	PhasePulling  = "pulling"
+	PhaseCreating = "creating"
+	PhaseStarting = "starting"
+	PhaseWaiting  = "waiting"
+	PhaseDone     = "done"
+)
+
+// Operation is a mutating call running in the background. Progress is a
+// percentage derived from the phase. Result holds what the call would have
+// returned synchronously: everything once it succeeded, or what was done
+// before it failed, such as the partial report of a stack down.
+type Operation struct {
+	ID         string `json:"id"`
+	Kind       string `json:"kind"`
+	Target     string `json:"target"`
+	Status     string `json:"status"`
+	Phase      string `json:"phase"`
+	Progress   int    `json:"progress"`
+	Message    string `json:"message,omitempty"`
+	Result     any    `json:"result,omitempty"`
+	Error      string `json:"error,omitempty"`
+	CreatedAt  int64  `json:"created_at"`
+	UpdatedAt  int64  `json:"updated_at"`
+	FinishedAt int64  `json:"finished_at,omitempty"`
+}
@@ -0,0 +1,62 @@
+package domain
+
+type PostgresDatabase struct {
+	Name      string `json:"name"`
+	Owner     string `json:"owner"`
+	Encoding  string `json:"encoding"`
+	SizeBytes int64  `json:"size_bytes"`
+}
+
+type PostgresRole struct {
+	Name       string `json:"name"`
+	Superuser  bool   `json:"superuser"`
+	CreateDB   bool   `json:"create_db"`
+	CreateRole bool   `json:"create_role"`
+	Login      bool   `json:"login"`
+}
+
+type PostgresRoleRequest struct {
+	Name       string `json:"name"`
+	Password   string `json:"password"`
+	Login      bool   `json:"login"`
+	Superuser  bool   `json:"superuser"`
+	CreateDB   bool   `json:"create_db"`
+	CreateRole bool   `json:"create_role"`
+}
+
+type PostgresDatabaseRequest struct {
+	Name  string `json:"name"`
+	Owner string `json:"owner"`
+}
+
+type PostgresQueryRequest struct {
+	Database string `json:"database"`
+	SQL      string `json:"sql"`
+}
+
+type PostgresConnection struct {
+	PID          int     `json:"pid"`
+	Database     *string `json:"database"`
+	User         *string `json:"user"`
+	Application  string  `json:"application"`
+	ClientAddr   *string `json:"client_addr"`
+	State        *string `json:"state"`
+	BackendStart string  `json:"backend_start"`
+	QueryStart   *string `json:"query_start"`
+	Query        string  `json:"query"`
+}
+
+type PostgresTableSize struct {
+	Schema     string `json:"schema"`
+	Table      string `json:"table"`
+	Rows       int64  `json:"rows"`
+	TableBytes int64  `json:"table_bytes"`
+	IndexBytes int64  `json:"index_bytes"`
+	TotalBytes int64  `json:"total_bytes"`
+}
+
+// QueryResult is a tabular result; NULL values are nil.
+type QueryResult struct {
+	Columns []string    `json:"columns"`
+	Rows    [][]*string `json:"rows"`
+}
@@ -0,0 +1,63 @@
+package domain
+
+import "context"
+
+type DesiredStateRepository interface {
+	ListDesiredStates(ctx context.Context) ([]DesiredState, error)
+	GetDesiredState(ctx context.Context, resource string) (*DesiredState, error)
+	SaveDesiredState(ctx context.Context, state *DesiredState) error
+	DeleteDesiredState(ctx context.Context, resource string) error
+}
+
+const (
+	DriftPolicyAutoFix = "auto_fix"
+	DriftPolicyAlert   = "alert"
+	DriftPolicyIgnore  = "ignore"
+)
+
+const (
+	DesiredRunning = "running"
+	DesiredStopped = "stopped"
+)
+
+// DesiredState is what devcon was last asked to do with a resource.
+// ConfigHash fingerprints the container configuration devcon created, so a
+// container replaced behind devcon's back shows up as drift.
+type DesiredState struct {
+	Resource      string           `json:"resource"`
+	ContainerID   string           `json:"container_id"`
+	State         string           `json:"state"`
+	ConfigHash    string           `json:"config_hash"`
+	Policy        string           `json:"policy"`
+	UpdatedAt     int64            `json:"updated_at"`
+	LastReconcile *ReconcileResult `json:"last_reconcile,omitempty"`
+}
+
+// ReconcileResult records one comparison with docker. Action is "none",
+// "fixed", "alerted", "ignored" or "failed".
+type ReconcileResult struct {
+	Resource  string   `json:"resource"`
+	CheckedAt int64    `json:"checked_at"`
+	Drift     []string `json:"drift,omitempty"`
+	Action    string   `json:"action"`
+	Error     string   `json:"error,omitempty"`
+}
+
+type ResourceDrift struct {
+	Policy        string           `json:"policy"`
+	DesiredState  string           `json:"desired_state"`
+	Drifted       bool             `json:"drifted"`
+	Drift         []string         `json:"drift,omitempty"`
+	LastReconcile *ReconcileResult `json:"last_reconcile,omitempty"`
+}
+
+type DriftPolicyRequest struct {
+	Policy string `json:"policy" binding:"required"`
+}
+
+type ContainerEvent struct {
+	ID     string
+	Name   string
+	Action string
+	Time   int64
+}
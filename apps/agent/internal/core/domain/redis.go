@@ -0,0 +1,59 @@
+package domain
+
+type RedisInfoSection struct {
+	Name   string            `json:"name"`
+	Fields map[string]string `json:"fields"`
+}
+
+type RedisDBStats struct {
+	DB      int   `json:"db"`
+	Keys    int64 `json:"keys"`
+	Expires int64 `json:"expires"`
+	AvgTTL  int64 `json:"avg_ttl_ms"`
+}
+
+type RedisKeyspace struct {
+	Databases   []RedisDBStats `json:"databases"`
+	Hits        int64          `json:"hits"`
+	Misses      int64          `json:"misses"`
+	ExpiredKeys int64          `json:"expired_keys"`
+	EvictedKeys int64          `json:"evicted_keys"`
+}
+
+type RedisKey struct {
+	Key       string `json:"key"`
+	Type      string `json:"type"`
+	TTLMillis int64  `json:"ttl_ms"`
+	SizeBytes int64  `json:"size_bytes"`
+}
+
+// RedisScanResult holds one page of keys; a Cursor of "0" means the scan is
+// complete.
+type RedisScanResult struct {
+	Cursor string     `json:"cursor"`
+	Keys   []RedisKey `json:"keys"`
+}
+
+type RedisScanRequest struct {
+	DB      int
+	Pattern string
+	Cursor  string
+	Count   int
+}
+
+// RedisValue is a key's content. Value is a string for strings, a list of
+// strings for lists and sets, a field map for hashes, member/score pairs for
+// sorted sets and id/fields entries for streams.
+type RedisValue struct {
+	Key       string `json:"key"`
+	Type      string `json:"type"`
+	TTLMillis int64  `json:"ttl_ms"`
+	Length    int64  `json:"length"`
+	Value     any    `json:"value"`
+	Truncated bool   `json:"truncated"`
+}
+
+type RedisDeleteRequest struct {
+	DB   int      `json:"db"`
+	Keys []string `json:"keys"`
+}
@@ -0,0 +1,63 @@
+package domain
+
+import "context"
+
+type ScheduleRepository interface {
+	ListSchedules(ctx context.Context) ([]Schedule, error)
+	GetSchedule(ctx context.Context, name string) (*Schedule, error)
+	SaveSchedule(ctx context.Context, schedule *Schedule) error
+	DeleteSchedule(ctx context.Context, name string) error
+	AddScheduleRun(ctx context.Context, run ScheduleRun, keep int) error
+	ListScheduleRuns(ctx context.Context, name string) ([]ScheduleRun, error)
+}
+
+const (
+	ScheduleTargetResource = "resource"
+	ScheduleTargetStack    = "stack"
+)
+
+const (
+	ScheduleStart   = "start"
+	ScheduleStop    = "stop"
+	ScheduleRestart = "restart"
+)
+
+// Schedule runs Action on a resource or stack whenever Cron fires. Cron is a
+// standard five-field expression or a descriptor such as @daily, evaluated in
+// TimeZone (the agent's local zone when empty).
+type Schedule struct {
+	Name       string       `json:"name"`
+	Cron       string       `json:"cron"`
+	TimeZone   string       `json:"time_zone,omitempty"`
+	Action     string       `json:"action"`
+	TargetKind string       `json:"target_kind"`
+	Target     string       `json:"target"`
+	Disabled   bool         `json:"disabled,omitempty"`
+	CreatedAt  int64        `json:"created_at"`
+	UpdatedAt  int64        `json:"updated_at"`
+	LastRun    *ScheduleRun `json:"last_run,omitempty"`
+	NextRunAt  int64        `json:"next_run_at,omitempty"`
+}
+
+type ScheduleRequest struct {
+	Name       string `json:"name"`
+	Cron       string `json:"cron" binding:"required"`
+	TimeZone   string `json:"time_zone"`
+	Action     string `json:"action" binding:"required"`
+	TargetKind string `json:"target_kind"`
+	Target     string `json:"target" binding:"required"`
+	Disabled   bool   `json:"disabled"`
+}
+
+// ScheduleRun is one execution of a schedule. Outcome is "success" or
+// "failed"; Trigger is "schedule" or "manual".
+type ScheduleRun struct {
+	Schedule   string `json:"schedule"`
+	Action     string `json:"action"`
+	Target     string `json:"target"`
+	Trigger    string `json:"trigger"`
+	StartedAt  int64  `json:"started_at"`
+	FinishedAt int64  `json:"finished_at"`
+	Outcome    string `json:"outcome"`
+	Error      string `json:"error,omitempty"`
+}
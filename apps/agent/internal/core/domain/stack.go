@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"context"
+	"maps"
+	"slices"
+)
+
+type StackRepository interface {
+	GetStack(ctx context.Context, project string) (*StackDefinition, error)
+	ListStacks(ctx context.Context) ([]StackDefinition, error)
+	SaveStack(ctx context.Context, stack *StackDefinition) error
+}
+
+// StackDefinition is the version history of a stack. Workspace names the
+// workspace spec that manages the stack, if any.
+type StackDefinition struct {
+	Project   string         `json:"project"`
+	Name      string         `json:"name"`
+	Workspace string         `json:"workspace,omitempty"`
+	Versions  []StackVersion `json:"versions"`
+}
+
+type StackVersion struct {
+	Version int `json:"version"`
+	StackSource
+	CreatedAt int64  `json:"created_at"`
+	Note      string `json:"note,omitempty"`
+}
+
+// StackSource is everything needed to resolve a stack: the base compose
+// document, override documents merged after it, active profiles and the
+// contents of an env file used for variable substitution. Stacks imported
+// from a host directory also remember where their files came from, and the
+// env_file variables each service was given, so a version resolves the same
+// way however the files change later.
+type StackSource struct {
+	Compose   string   `json:"compose"`
+	Overrides []string `json:"overrides,omitempty"`
+	Profiles  []string `json:"profiles,omitempty"`
+	EnvFile   string   `json:"env_file,omitempty"`
+
+	Dir         string   `json:"dir,omitempty"`
+	Files       []string `json:"files,omitempty"`
+	EnvFileName string   `json:"env_file_name,omitempty"`
+
+	ServiceEnv map[string][]string `json:"service_env,omitempty"`
+}
+
+func (s StackSource) Equal(other StackSource) bool {
+	return s.Compose == other.Compose &&
+		s.EnvFile == other.EnvFile &&
+		s.Dir == other.Dir &&
+		s.EnvFileName == other.EnvFileName &&
+		slices.Equal(s.Files, other.Files) &&
+		slices.Equal(s.Overrides, other.Overrides) &&
+		slices.Equal(s.Profiles, other.Profiles) &&
+		maps.EqualFunc(s.ServiceEnv, other.ServiceEnv, slices.Equal)
+}
+
+type ResolvedStack struct {
+	Project  *ComposeProject `json:"project"`
+	Config   string          `json:"config"`
+	Warnings []string        `json:"warnings,omitempty"`
+}
+
+func (s *StackDefinition) Current() *StackVersion {
+	if len(s.Versions) == 0 {
+		return nil
+	}
+	return &s.Versions[len(s.Versions)-1]
+}
+
+func (s *StackDefinition) Previous() *StackVersion {
+	if len(s.Versions) < 2 {
+		return nil
+	}
+	return &s.Versions[len(s.Versions)-2]
+}
+
+func (s *StackDefinition) Version(version int) *StackVersion {
+	for i := range s.Versions {
+		if s.Versions[i].Version == version {
+			return &s.Versions[i]
+		}
+	}
+	return nil
+}
+
+type StackApplyResult struct {
+	Project   string   `json:"project"`
+	Version   int      `json:"version"`
+	Created   []string `json:"created"`
+	Recreated []string `json:"recreated"`
+	Removed   []string `json:"removed"`
+	Unchanged []string `json:"unchanged"`
+}
+
+type StackImport struct {
+	Name     string   `json:"name"`
+	Path     string   `json:"path" binding:"required"`
+	Files    []string `json:"files"`
+	Profiles []string `json:"profiles"`
+	EnvFile  string   `json:"env_file"`
+}
@@ -0,0 +1,64 @@
+package domain
+
+import "context"
+
+type TemplateRepository interface {
+	ListTemplates(ctx context.Context) ([]Template, error)
+}
+
+type Template struct {
+	Name        string           `json:"name"`
+	Description string           `json:"description,omitempty"`
+	Type        string           `json:"type"`
+	Image       string           `json:"image"`
+	Command     []string         `json:"command,omitempty"`
+	Ports       []TemplatePort   `json:"ports"`
+	Env         []TemplateEnv    `json:"env"`
+	Volumes     []TemplateVolume `json:"volumes"`
+	Healthcheck *HealthcheckSpec `json:"healthcheck,omitempty"`
+	Readiness   *ReadinessProbe  `json:"readiness,omitempty"`
+	Builtin     bool             `json:"builtin"`
+}
+
+type TemplatePort struct {
+	Name          string `json:"name"`
+	ContainerPort string `json:"container_port"`
+	HostPort      string `json:"host_port,omitempty"`
+}
+
+// TemplateEnv describes one variable. Generate is the length of a random
+// secret produced when the caller does not provide a value.
+type TemplateEnv struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Default     string `json:"default,omitempty"`
+	Generate    int    `json:"generate,omitempty"`
+	Secret      bool   `json:"secret,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+}
+
+type TemplateVolume struct {
+	Name   string `json:"name"`
+	Target string `json:"target"`
+}
+
+// TemplateRequest creates a resource from a template. HostPorts is keyed by
+// the template port name or its container port.
+type TemplateRequest struct {
+	Name      string            `json:"name" binding:"required"`
+	Tag       string            `json:"tag"`
+	HostPorts map[string]string `json:"host_ports"`
+	Env       map[string]string `json:"env"`
+
+	TTL         string `json:"ttl"`
+	TTLAction   string `json:"ttl_action"`
+	IdleTimeout string `json:"idle_timeout"`
+}
+
+type TemplateInstance struct {
+	Template string            `json:"template"`
+	Resource *DevconStatus     `json:"resource"`
+	Ports    map[string]string `json:"ports"`
+	Env      map[string]string `json:"env"`
+	Volumes  []string          `json:"volumes"`
+}
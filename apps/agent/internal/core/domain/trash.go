@@ -0,0 +1,33 @@
+package domain
+
+import "context"
+
+type TrashRepository interface {
+	ListTrash(ctx context.Context) ([]TrashEntry, error)
+	GetTrash(ctx context.Context, resource string) (*TrashEntry, error)
+	SaveTrash(ctx context.Context, entry *TrashEntry) error
+	DeleteTrash(ctx context.Context, resource string) error
+}
+
+// TrashEntry is a deleted resource whose stopped container is kept until
+// PurgeAt so it can be restored. Desired and Lifecycle are what devcon
+// tracked for it and are put back on restore.
+type TrashEntry struct {
+	Resource    string        `json:"resource"`
+	ContainerID string        `json:"container_id"`
+	Image       string        `json:"image"`
+	Type        string        `json:"type"`
+	Volumes     []string      `json:"volumes,omitempty"`
+	WasRunning  bool          `json:"was_running"`
+	DeletedAt   int64         `json:"deleted_at"`
+	PurgeAt     int64         `json:"purge_at"`
+	Desired     *DesiredState `json:"desired,omitempty"`
+	Lifecycle   *Lifecycle    `json:"lifecycle,omitempty"`
+}
+
+// DeleteRequest skips the trash when Purge is set or Confirm is the name of
+// the resource. Without either, a delete moves the resource to the trash.
+type DeleteRequest struct {
+	Purge   bool   `json:"purge" form:"purge"`
+	Confirm string `json:"confirm" form:"confirm"`
+}
@@ -0,0 +1,81 @@
+package domain
+
+// WorkspaceSpec is the declarative description of an environment, usually
+// kept in a devcon.yaml next to the code that uses it.
+type WorkspaceSpec struct {
+	Name      string              `json:"name"`
+	Resources []WorkspaceResource `json:"resources,omitempty"`
+	Stacks    []WorkspaceStack    `json:"stacks,omitempty"`
+	Volumes   []WorkspaceVolume   `json:"volumes,omitempty"`
+	Networks  []WorkspaceNetwork  `json:"networks,omitempty"`
+}
+
+// WorkspaceResource is a single container. Ports and volumes use the compose
+// short syntax, e.g. "5432:5432" and "pgdata:/var/lib/postgresql/data".
+type WorkspaceResource struct {
+	Name     string            `json:"name"`
+	Image    string            `json:"image"`
+	Type     string            `json:"type,omitempty"`
+	Ports    []string          `json:"ports,omitempty"`
+	Env      []string          `json:"env,omitempty"`
+	Command  []string          `json:"command,omitempty"`
+	Volumes  []string          `json:"volumes,omitempty"`
+	Networks []string          `json:"networks,omitempty"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Restart  string            `json:"restart,omitempty"`
+
+	DependsOn []ResourceDependency `json:"depends_on,omitempty"`
+}
+
+// WorkspaceStack is a compose stack given inline or read from a directory
+// relative to the spec file. Files and EnvFile name files in that directory.
+type WorkspaceStack struct {
+	Name      string   `json:"name"`
+	Compose   string   `json:"compose,omitempty"`
+	Overrides []string `json:"overrides,omitempty"`
+	Path      string   `json:"path,omitempty"`
+	Files     []string `json:"files,omitempty"`
+	Profiles  []string `json:"profiles,omitempty"`
+	EnvFile   string   `json:"env_file,omitempty"`
+}
+
+type WorkspaceVolume struct {
+	Name   string            `json:"name"`
+	Driver string            `json:"driver,omitempty"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+type WorkspaceNetwork struct {
+	Name     string            `json:"name"`
+	Driver   string            `json:"driver,omitempty"`
+	Internal bool              `json:"internal,omitempty"`
+	Labels   map[string]string `json:"labels,omitempty"`
+}
+
+// WorkspaceRequest carries the raw spec. Dir is the directory relative stack
+// paths and bind mounts are resolved against; Prune deletes what the
+// workspace created earlier but the spec no longer lists; Adopt lets the
+// workspace replace same-named resources it did not create.
+type WorkspaceRequest struct {
+	Spec  string `json:"spec" binding:"required"`
+	Dir   string `json:"dir"`
+	Prune bool   `json:"prune"`
+	Adopt bool   `json:"adopt"`
+}
+
+// WorkspaceChange is one step of a plan. Kind is "network", "volume",
+// "stack" or "resource"; Action is "create", "update", "delete",
+// "unchanged" or "orphaned" for things that would be deleted with prune.
+type WorkspaceChange struct {
+	Kind   string `json:"kind"`
+	Name   string `json:"name"`
+	Action string `json:"action"`
+	Reason string `json:"reason,omitempty"`
+}
+
+type WorkspacePlan struct {
+	Workspace string            `json:"workspace"`
+	Prune     bool              `json:"prune"`
+	Applied   bool              `json:"applied"`
+	Changes   []WorkspaceChange `json:"changes"`
+}
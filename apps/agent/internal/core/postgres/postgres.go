@@ -0,0 +1,181 @@
+package postgres
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+)
+
+// Unaligned psql output uses ASCII separators so values containing commas,
+// tabs or newlines survive the round trip.
+const (
+	fieldSeparator  = "\x1f"
+	recordSeparator = "\x1e"
+	nullMarker      = "\x1d"
+)
+
+const (
+	ListDatabasesSQL = `SELECT datname AS name, pg_get_userbyid(datdba) AS owner,
+	pg_encoding_to_char(encoding) AS encoding, pg_database_size(datname) AS size_bytes
+	FROM pg_database WHERE NOT datistemplate ORDER BY datname`
+
+	ListRolesSQL = `SELECT rolname AS name, rolsuper AS superuser, rolcreatedb AS create_db,
+	rolcreaterole AS create_role, rolcanlogin AS login
+	FROM pg_roles WHERE rolname !~ '^pg_' ORDER BY rolname`
+
+	ConnectionsSQL = `SELECT pid, datname AS database, usename AS "user", application_name AS application,
+	client_addr::text AS client_addr, state, backend_start::text AS backend_start,
+	query_start::text AS query_start, query
+	FROM pg_stat_activity WHERE backend_type = 'client backend' AND pid <> pg_backend_pid()
+	ORDER BY backend_start`
+
+	TableSizesSQL = `SELECT n.nspname AS schema, c.relname AS "table", c.reltuples::bigint AS rows,
+	pg_relation_size(c.oid) AS table_bytes, pg_indexes_size(c.oid) AS index_bytes,
+	pg_total_relation_size(c.oid) AS total_bytes
+	FROM pg_class c JOIN pg_namespace n ON n.oid = c.relnamespace
+	WHERE c.relkind IN ('r', 'p', 'm') AND n.nspname NOT IN ('pg_catalog', 'information_schema')
+	AND n.nspname !~ '^pg_toast'
+	ORDER BY pg_total_relation_size(c.oid) DESC`
+)
+
+// QuoteIdent quotes a database or role name for use in DDL.
+func QuoteIdent(name string) (string, error) {
+	if strings.TrimSpace(name) == "" {
+		return "", fmt.Errorf("name cannot be empty")
+	}
+	if len(name) > 63 {
+		return "", fmt.Errorf("name %q is longer than 63 bytes", name)
+	}
+	if strings.ContainsRune(name, 0) {
+		return "", fmt.Errorf("name %q contains a NUL byte", name)
+	}
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`, nil
+}
+
+func QuoteLiteral(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+func CreateDatabaseSQL(req domain.PostgresDatabaseRequest) (string, error) {
+	name, err := QuoteIdent(req.Name)
+	if err != nil {
+		return "", err
+	}
+	stmt := "CREATE DATABASE " + name
+	if req.Owner != "" {
+		owner, err := QuoteIdent(req.Owner)
+		if err != nil {
+			return "", err
+		}
+		stmt += " OWNER " + owner
+	}
+	return stmt, nil
+}
+
+// DropDatabaseSQL drops a database; force terminates its connections first
+// (PostgreSQL 13+).
+func DropDatabaseSQL(name string, force bool) (string, error) {
+	quoted, err := QuoteIdent(name)
+	if err != nil {
+		return "", err
+	}
+	stmt := "DROP DATABASE " + quoted
+	if force {
+		stmt += " WITH (FORCE)"
+	}
+	return stmt, nil
+}
+
+func CreateRoleSQL(req domain.PostgresRoleRequest) (string, error) {
+	name, err := QuoteIdent(req.Name)
+	if err != nil {
+		return "", err
+	}
+	options := []string{flag(req.Login, "LOGIN"), flag(req.Superuser, "SUPERUSER"), flag(req.CreateDB, "CREATEDB"), flag(req.CreateRole, "CREATEROLE")}
+	if req.Password != "" {
+		options = append(options, "PASSWORD "+QuoteLiteral(req.Password))
+	}
+	return "CREATE ROLE " + name + " WITH " + strings.Join(options, " "), nil
+}
+
+func DropRoleSQL(name string) (string, error) {
+	quoted, err := QuoteIdent(name)
+	if err != nil {
+		return "", err
+	}
+	return "DROP ROLE " + quoted, nil
+}
+
+func flag(on bool, option string) string {
+	if on {
+		return option
+	}
+	return "NO" + option
+}
+
+// JSONSQL wraps a query so psql prints its rows as one JSON array.
+func JSONSQL(query string) string {
+	return "SELECT coalesce(json_agg(t), '[]'::json) FROM (" + query + ") t"
+}
+
+// Command builds a psql invocation. Tabular output keeps the header row;
+// otherwise only tuples are printed, which suits JSONSQL queries.
+func Command(user, database, sql string, tabular bool) []string {
+	cmd := []string{"psql", "-X", "-q", "-v", "ON_ERROR_STOP=1", "-U", user}
+	if database != "" {
+		cmd = append(cmd, "-d", database)
+	}
+	cmd = append(cmd, "-A")
+	if tabular {
+		cmd = append(cmd, "-F", fieldSeparator, "-R", recordSeparator, "-P", "null="+nullMarker, "-P", "footer=off")
+	} else {
+		cmd = append(cmd, "-t")
+	}
+	return append(cmd, "-c", sql)
+}
+
+func DecodeJSON(output []byte, v any) error {
+	out := strings.TrimSpace(string(output))
+	if out == "" {
+		out = "[]"
+	}
+	if err := json.Unmarshal([]byte(out), v); err != nil {
+		return fmt.Errorf("unexpected psql output: %w", err)
+	}
+	return nil
+}
+
+// ParseTable reads output produced by a tabular Command.
+func ParseTable(output []byte) domain.QueryResult {
+	result := domain.QueryResult{Columns: make([]string, 0), Rows: make([][]*string, 0)}
+	out := strings.TrimSuffix(strings.TrimSuffix(string(output), "\n"), recordSeparator)
+	if out == "" {
+		return result
+	}
+	records := strings.Split(out, recordSeparator)
+	result.Columns = strings.Split(records[0], fieldSeparator)
+	for _, record := range records[1:] {
+		fields := strings.Split(record, fieldSeparator)
+		row := make([]*string, len(fields))
+		for i, field := range fields {
+			if field != nullMarker {
+				value := field
+				row[i] = &value
+			}
+		}
+		result.Rows = append(result.Rows, row)
+	}
+	return result
+}
+
+// Error turns psql's stderr into an error message.
+func Error(exitCode int, stderr []byte) error {
+	message := strings.TrimSpace(string(stderr))
+	message = strings.TrimPrefix(message, "psql:")
+	if message == "" {
+		message = fmt.Sprintf("psql exited with code %d", exitCode)
+	}
+	return fmt.Errorf("%s", strings.TrimSpace(message))
+}
@@ -0,0 +1,123 @@
+package readiness
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+)
+
+const (
+	ProbePort    = "port"
+	ProbeHTTP    = "http"
+	ProbeExec    = "exec"
+	ProbeLog     = "log"
+	ProbeHealth  = "health"
+	ProbeRunning = "running"
+)
+
+const (
+	DefaultTimeout  = 60 * time.Second
+	DefaultInterval = time.Second
+)
+
+// Normalize validates a probe and fills in its defaults.
+func Normalize(probe domain.ReadinessProbe) (domain.ReadinessProbe, error) {
+	probe.Type = strings.TrimSpace(probe.Type)
+	probe.Port = strings.TrimSuffix(strings.TrimSpace(probe.Port), "/tcp")
+	if probe.Port != "" {
+		if n, err := strconv.Atoi(probe.Port); err != nil || n < 1 || n > 65535 {
+			return probe, fmt.Errorf("readiness: invalid port %q", probe.Port)
+		}
+	}
+	switch probe.Type {
+	case ProbePort, ProbeHealth, ProbeRunning:
+	case ProbeHTTP:
+		if probe.Path == "" {
+			probe.Path = "/"
+		}
+		if !strings.HasPrefix(probe.Path, "/") {
+			return probe, fmt.Errorf("readiness: http path %q must start with /", probe.Path)
+		}
+		if probe.Status != 0 && (probe.Status < 100 || probe.Status > 599) {
+			return probe, fmt.Errorf("readiness: invalid http status %d", probe.Status)
+		}
+	case ProbeExec:
+		if len(probe.Command) == 0 {
+			return probe, fmt.Errorf("readiness: exec probe needs a command")
+		}
+	case ProbeLog:
+		if probe.Pattern == "" {
+			return probe, fmt.Errorf("readiness: log probe needs a pattern")
+		}
+		if _, err := regexp.Compile(probe.Pattern); err != nil {
+			return probe, fmt.Errorf("readiness: invalid pattern: %w", err)
+		}
+	default:
+		return probe, fmt.Errorf("readiness: unknown probe type %q, use port, http, exec, log or health", probe.Type)
+	}
+	for _, value := range []string{probe.Timeout, probe.Interval} {
+		if value == "" {
+			continue
+		}
+		if d, err := time.ParseDuration(value); err != nil || d <= 0 {
+			return probe, fmt.Errorf("readiness: invalid duration %q", value)
+		}
+	}
+	return probe, nil
+}
+
+func Timeout(probe domain.ReadinessProbe) time.Duration {
+	if d, err := time.ParseDuration(probe.Timeout); err == nil && d > 0 {
+		return d
+	}
+	return DefaultTimeout
+}
+
+func Interval(probe domain.ReadinessProbe) time.Duration {
+	if d, err := time.ParseDuration(probe.Interval); err == nil && d > 0 {
+		return d
+	}
+	return DefaultInterval
+}
+
+// Describe is the short form of a probe shown in readiness reports.
+func Describe(probe domain.ReadinessProbe) string {
+	switch probe.Type {
+	case ProbePort:
+		if probe.Port != "" {
+			return "port " + probe.Port
+		}
+	case ProbeHTTP:
+		if probe.Port != "" {
+			return "http :" + probe.Port + probe.Path
+		}
+		return "http " + probe.Path
+	case ProbeExec:
+		return "exec " + strings.Join(probe.Command, " ")
+	case ProbeLog:
+		return "log /" + probe.Pattern + "/"
+	}
+	return probe.Type
+}
+
+// Encode stores a probe in a container label.
+func Encode(probe domain.ReadinessProbe) string {
+	data, _ := json.Marshal(probe)
+	return string(data)
+}
+
+func Decode(label string) (*domain.ReadinessProbe, error) {
+	if strings.TrimSpace(label) == "" {
+		return nil, nil
+	}
+	var probe domain.ReadinessProbe
+	if err := json.Unmarshal([]byte(label), &probe); err != nil {
+		return nil, fmt.Errorf("invalid readiness label: %w", err)
+	}
+	return &probe, nil
+}
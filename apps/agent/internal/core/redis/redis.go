@@ -0,0 +1,277 @@
+package redis
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+)
+
+const (
+	DefaultScanCount = 100
+	MaxScanCount     = 1000
+	// MaxValueItems caps elements returned for collections, MaxValueBytes the
+	// bytes returned for strings.
+	MaxValueItems = 500
+	MaxValueBytes = 64 * 1024
+)
+
+// The scripts run server side and return one JSON document, so key names
+// and values never have to be parsed out of redis-cli's line output. Arrays
+// are assembled by hand because cjson encodes an empty table as an object.
+const scanScript = `
+local cursor = ARGV[1]
+local limit = tonumber(ARGV[3])
+local entries = {}
+local rounds = 0
+repeat
+  local page = redis.call('SCAN', cursor, 'MATCH', ARGV[2], 'COUNT', limit)
+  cursor = page[1]
+  rounds = rounds + 1
+  for _, key in ipairs(page[2]) do
+    local size = redis.pcall('MEMORY', 'USAGE', key)
+    if type(size) ~= 'number' then size = 0 end
+    entries[#entries + 1] = cjson.encode({key = key, type = redis.call('TYPE', key)['ok'], ttl_ms = redis.call('PTTL', key), size_bytes = size})
+  end
+until cursor == '0' or #entries >= limit or rounds >= 100
+return '{"cursor":' .. cjson.encode(cursor) .. ',"keys":[' .. table.concat(entries, ',') .. ']}'
+`
+
+const valueScript = `
+local key = KEYS[1]
+local limit = tonumber(ARGV[1])
+local maxBytes = tonumber(ARGV[2])
+local kind = redis.call('TYPE', key)['ok']
+if kind == 'none' then return '' end
+local function strings(items)
+  local parts = {}
+  for i, v in ipairs(items) do parts[i] = cjson.encode(v) end
+  return '[' .. table.concat(parts, ',') .. ']'
+end
+local function pairsObject(items)
+  local parts = {}
+  for i = 1, #items, 2 do parts[#parts + 1] = cjson.encode(items[i]) .. ':' .. cjson.encode(items[i + 1]) end
+  return '{' .. table.concat(parts, ',') .. '}'
+end
+local length, value, shown = 0, 'null', 0
+if kind == 'string' then
+  length = redis.call('STRLEN', key)
+  value = cjson.encode(redis.call('GETRANGE', key, 0, maxBytes - 1))
+  shown = math.min(length, maxBytes)
+elseif kind == 'list' then
+  length = redis.call('LLEN', key)
+  local items = redis.call('LRANGE', key, 0, limit - 1)
+  value, shown = strings(items), #items
+elseif kind == 'set' then
+  length = redis.call('SCARD', key)
+  local items = redis.call('SRANDMEMBER', key, limit)
+  table.sort(items)
+  value, shown = strings(items), #items
+elseif kind == 'zset' then
+  length = redis.call('ZCARD', key)
+  local items = redis.call('ZRANGE', key, 0, limit - 1, 'WITHSCORES')
+  local parts = {}
+  for i = 1, #items, 2 do parts[#parts + 1] = cjson.encode({member = items[i], score = items[i + 1]}) end
+  value, shown = '[' .. table.concat(parts, ',') .. ']', #parts
+elseif kind == 'hash' then
+  length = redis.call('HLEN', key)
+  local fields, cursor = {}, '0'
+  repeat
+    local page = redis.call('HSCAN', key, cursor, 'COUNT', limit)
+    cursor = page[1]
+    for _, v in ipairs(page[2]) do fields[#fields + 1] = v end
+  until cursor == '0' or #fields >= limit * 2
+  value, shown = pairsObject(fields), #fields / 2
+elseif kind == 'stream' then
+  length = redis.call('XLEN', key)
+  local items = redis.call('XRANGE', key, '-', '+', 'COUNT', limit)
+  local parts = {}
+  for i, entry in ipairs(items) do parts[i] = '{"id":' .. cjson.encode(entry[1]) .. ',"fields":' .. pairsObject(entry[2]) .. '}' end
+  value, shown = '[' .. table.concat(parts, ',') .. ']', #items
+end
+return '{"key":' .. cjson.encode(key) .. ',"type":' .. cjson.encode(kind) .. ',"ttl_ms":' .. redis.call('PTTL', key) ..
+  ',"length":' .. length .. ',"truncated":' .. tostring(shown < length) .. ',"value":' .. value .. '}'
+`
+
+var sectionRegexp = regexp.MustCompile(`^[a-zA-Z_]+$`)
+
+// Command builds a redis-cli invocation against the given logical database.
+// The password travels in REDISCLI_AUTH rather than on the command line.
+func Command(db int, args ...string) []string {
+	return append([]string{"redis-cli", "-n", strconv.Itoa(db)}, args...)
+}
+
+func InfoCommand(db int, section string) ([]string, error) {
+	if section == "" {
+		return Command(db, "INFO"), nil
+	}
+	if !sectionRegexp.MatchString(section) {
+		return nil, fmt.Errorf("invalid info section %q", section)
+	}
+	return Command(db, "INFO", section), nil
+}
+
+func ScanCommand(req domain.RedisScanRequest) []string {
+	pattern := req.Pattern
+	if pattern == "" {
+		pattern = "*"
+	}
+	cursor := req.Cursor
+	if cursor == "" {
+		cursor = "0"
+	}
+	count := req.Count
+	if count <= 0 {
+		count = DefaultScanCount
+	}
+	if count > MaxScanCount {
+		count = MaxScanCount
+	}
+	return Command(req.DB, "EVAL", scanScript, "0", cursor, pattern, strconv.Itoa(count))
+}
+
+func ValueCommand(db int, key string) []string {
+	return Command(db, "EVAL", valueScript, "1", key, strconv.Itoa(MaxValueItems), strconv.Itoa(MaxValueBytes))
+}
+
+func ParseInfo(output []byte) ([]domain.RedisInfoSection, error) {
+	text := strings.TrimSpace(string(output))
+	if !strings.HasPrefix(text, "#") {
+		return nil, replyError(text)
+	}
+	sections := make([]domain.RedisInfoSection, 0)
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "":
+		case strings.HasPrefix(line, "#"):
+			name := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(line, "#")))
+			sections = append(sections, domain.RedisInfoSection{Name: name, Fields: make(map[string]string)})
+		case len(sections) > 0:
+			if key, value, ok := strings.Cut(line, ":"); ok {
+				sections[len(sections)-1].Fields[key] = value
+			}
+		}
+	}
+	return sections, nil
+}
+
+// Keyspace summarises the keyspace and stats sections of INFO.
+func Keyspace(sections []domain.RedisInfoSection) domain.RedisKeyspace {
+	keyspace := domain.RedisKeyspace{Databases: make([]domain.RedisDBStats, 0)}
+	for _, section := range sections {
+		switch section.Name {
+		case "stats":
+			keyspace.Hits = parseInt(section.Fields["keyspace_hits"])
+			keyspace.Misses = parseInt(section.Fields["keyspace_misses"])
+			keyspace.ExpiredKeys = parseInt(section.Fields["expired_keys"])
+			keyspace.EvictedKeys = parseInt(section.Fields["evicted_keys"])
+		case "keyspace":
+			for name, value := range section.Fields {
+				db, err := strconv.Atoi(strings.TrimPrefix(name, "db"))
+				if err != nil {
+					continue
+				}
+				stats := domain.RedisDBStats{DB: db}
+				for _, pair := range strings.Split(value, ",") {
+					key, v, _ := strings.Cut(pair, "=")
+					switch key {
+					case "keys":
+						stats.Keys = parseInt(v)
+					case "expires":
+						stats.Expires = parseInt(v)
+					case "avg_ttl":
+						stats.AvgTTL = parseInt(v)
+					}
+				}
+				keyspace.Databases = append(keyspace.Databases, stats)
+			}
+		}
+	}
+	sort.Slice(keyspace.Databases, func(i, j int) bool { return keyspace.Databases[i].DB < keyspace.Databases[j].DB })
+	return keyspace
+}
+
+func ParseScan(output []byte) (*domain.RedisScanResult, error) {
+	var result domain.RedisScanResult
+	if err := decodeJSON(output, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ParseValue returns nil when the key does not exist.
+func ParseValue(output []byte) (*domain.RedisValue, error) {
+	if strings.TrimSpace(string(output)) == "" {
+		return nil, nil
+	}
+	var value domain.RedisValue
+	if err := decodeJSON(output, &value); err != nil {
+		return nil, err
+	}
+	return &value, nil
+}
+
+func ParseInteger(output []byte) (int64, error) {
+	text := strings.TrimSpace(string(output))
+	n, err := strconv.ParseInt(strings.TrimPrefix(text, "(integer) "), 10, 64)
+	if err != nil {
+		return 0, replyError(text)
+	}
+	return n, nil
+}
+
+// ParseConfig reads the name/value line pairs CONFIG GET prints.
+func ParseConfig(output []byte) (map[string]string, error) {
+	text := strings.TrimSpace(string(output))
+	lines := strings.Split(text, "\n")
+	if text == "" || len(lines)%2 != 0 {
+		return nil, replyError(text)
+	}
+	config := make(map[string]string, len(lines)/2)
+	for i := 0; i < len(lines); i += 2 {
+		config[strings.TrimSpace(lines[i])] = strings.TrimSpace(lines[i+1])
+	}
+	return config, nil
+}
+
+func ParseOK(output []byte) error {
+	text := strings.TrimSpace(string(output))
+	if text != "OK" {
+		return replyError(text)
+	}
+	return nil
+}
+
+func decodeJSON(output []byte, v any) error {
+	text := strings.TrimSpace(string(output))
+	if !strings.HasPrefix(text, "{") {
+		return replyError(text)
+	}
+	if err := json.Unmarshal([]byte(text), v); err != nil {
+		return fmt.Errorf("unexpected redis-cli output: %w", err)
+	}
+	return nil
+}
+
+// replyError reports redis-cli output that is not the expected reply; in raw
+// mode server errors arrive on stdout.
+func replyError(text string) error {
+	text = strings.TrimSpace(strings.TrimPrefix(text, "(error)"))
+	if text == "" {
+		text = "empty reply from redis-cli"
+	}
+	if line, _, ok := strings.Cut(text, "\n"); ok {
+		text = line
+	}
+	return fmt.Errorf("redis: %s", text)
+}
+
+func parseInt(s string) int64 {
+	n, _ := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+	return n
+}
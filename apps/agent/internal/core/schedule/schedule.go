@@ -0,0 +1,88 @@
+package schedule
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+	"github.com/robfig/cron/v3"
+)
+
+const (
+	OutcomeSuccess = "success"
+	OutcomeFailed  = "failed"
+)
+
+const (
+	TriggerSchedule = "schedule"
+	TriggerManual   = "manual"
+)
+
+// HistoryLimit is how many runs are kept per schedule.
+const HistoryLimit = 100
+
+var namePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+// Validate checks a schedule request and fills in the default target kind.
+func Validate(req *domain.ScheduleRequest) error {
+	req.Name = strings.TrimSpace(req.Name)
+	if !namePattern.MatchString(req.Name) {
+		return fmt.Errorf("invalid schedule name %q", req.Name)
+	}
+	switch req.Action {
+	case domain.ScheduleStart, domain.ScheduleStop, domain.ScheduleRestart:
+	default:
+		return fmt.Errorf("invalid action %q: use start, stop or restart", req.Action)
+	}
+	if req.TargetKind == "" {
+		req.TargetKind = domain.ScheduleTargetResource
+	}
+	if req.TargetKind != domain.ScheduleTargetResource && req.TargetKind != domain.ScheduleTargetStack {
+		return fmt.Errorf("invalid target kind %q: use resource or stack", req.TargetKind)
+	}
+	if strings.TrimSpace(req.Target) == "" {
+		return fmt.Errorf("target cannot be empty")
+	}
+	_, err := Parse(req.Cron, req.TimeZone)
+	return err
+}
+
+// Spec is the expression handed to cron, with the time zone prefixed.
+func Spec(expr, timeZone string) string {
+	expr = strings.TrimSpace(expr)
+	if timeZone == "" {
+		return expr
+	}
+	return "CRON_TZ=" + timeZone + " " + expr
+}
+
+func Parse(expr, timeZone string) (cron.Schedule, error) {
+	if strings.HasPrefix(strings.TrimSpace(expr), "CRON_TZ=") || strings.HasPrefix(strings.TrimSpace(expr), "TZ=") {
+		return nil, fmt.Errorf("set the time zone with time_zone, not in the cron expression")
+	}
+	if timeZone != "" {
+		if _, err := time.LoadLocation(timeZone); err != nil {
+			return nil, fmt.Errorf("invalid time zone %q", timeZone)
+		}
+	}
+	parsed, err := cron.ParseStandard(Spec(expr, timeZone))
+	if err != nil {
+		return nil, fmt.Errorf("invalid cron expression %q: %w", expr, err)
+	}
+	return parsed, nil
+}
+
+// Next is when the schedule fires next after now, or zero when it is
+// disabled or invalid.
+func Next(s domain.Schedule, now time.Time) int64 {
+	if s.Disabled {
+		return 0
+	}
+	parsed, err := Parse(s.Cron, s.TimeZone)
+	if err != nil {
+		return 0
+	}
+	return parsed.Next(now).Unix()
+}
@@ -0,0 +1,110 @@
+package schedule
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		expr     string
+		timeZone string
+		wantErr  string
+	}{
+		{"standard", "0 9 * * 1-5", "", ""},
+		{"descriptor", "@hourly", "", ""},
+		{"every", "@every 90m", "", ""},
+		{"time zone", "30 18 * * *", "Europe/Berlin", ""},
+		{"utc", "0 0 1 * *", "UTC", ""},
+		{"padded", "  0 9 * * *  ", "", ""},
+		{"too few fields", "0 9 * *", "", "invalid cron expression"},
+		{"seconds field", "0 0 9 * * *", "", "invalid cron expression"},
+		{"out of range", "0 25 * * *", "", "invalid cron expression"},
+		{"empty", "", "", "invalid cron expression"},
+		{"unknown time zone", "0 9 * * *", "Mars/Olympus", `invalid time zone "Mars/Olympus"`},
+		{"inline CRON_TZ", "CRON_TZ=UTC 0 9 * * *", "", "not in the cron expression"},
+		{"inline TZ", "TZ=UTC 0 9 * * *", "", "not in the cron expression"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.expr, tt.timeZone)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Parse() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Parse() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNext(t *testing.T) {
+	now := time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		schedule domain.Schedule
+		want     time.Time
+	}{
+		{"utc", domain.Schedule{Cron: "0 9 * * *"}, time.Date(2026, 1, 15, 9, 0, 0, 0, time.UTC)},
+		{"time zone", domain.Schedule{Cron: "0 9 * * *", TimeZone: "Europe/Berlin"}, time.Date(2026, 1, 15, 8, 0, 0, 0, time.UTC)},
+		{"disabled", domain.Schedule{Cron: "0 9 * * *", Disabled: true}, time.Time{}},
+		{"invalid", domain.Schedule{Cron: "not cron"}, time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := int64(0)
+			if !tt.want.IsZero() {
+				want = tt.want.Unix()
+			}
+			if got := Next(tt.schedule, now); got != want {
+				t.Errorf("Next() = %d, want %d", got, want)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	valid := domain.ScheduleRequest{Name: "nightly", Cron: "0 2 * * *", Action: domain.ScheduleStop, Target: "api"}
+	tests := []struct {
+		name           string
+		edit           func(*domain.ScheduleRequest)
+		wantErr        string
+		wantTargetKind string
+	}{
+		{"defaults to resource", func(*domain.ScheduleRequest) {}, "", domain.ScheduleTargetResource},
+		{"stack", func(r *domain.ScheduleRequest) { r.TargetKind = domain.ScheduleTargetStack }, "", domain.ScheduleTargetStack},
+		{"trimmed name", func(r *domain.ScheduleRequest) { r.Name = " nightly " }, "", domain.ScheduleTargetResource},
+		{"bad name", func(r *domain.ScheduleRequest) { r.Name = "-nightly" }, "invalid schedule name", ""},
+		{"bad action", func(r *domain.ScheduleRequest) { r.Action = "delete" }, "invalid action", ""},
+		{"bad target kind", func(r *domain.ScheduleRequest) { r.TargetKind = "group" }, "invalid target kind", ""},
+		{"empty target", func(r *domain.ScheduleRequest) { r.Target = " " }, "target cannot be empty", ""},
+		{"bad cron", func(r *domain.ScheduleRequest) { r.Cron = "0 2 * *" }, "invalid cron expression", ""},
+		{"bad time zone", func(r *domain.ScheduleRequest) { r.TimeZone = "Nowhere" }, "invalid time zone", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := valid
+			tt.edit(&req)
+			err := Validate(&req)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Validate() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+			if req.TargetKind != tt.wantTargetKind {
+				t.Errorf("TargetKind = %q, want %q", req.TargetKind, tt.wantTargetKind)
+			}
+		})
+	}
+}
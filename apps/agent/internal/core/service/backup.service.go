@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/backup"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+)
+
+type BackupService struct {
+	repo domain.BackupRepository
+}
+
+func NewBackupService(repo domain.BackupRepository) *BackupService {
+	return &BackupService{repo: repo}
+}
+
+func (s *BackupService) CreateBackup(ctx context.Context, b *domain.Backup, content io.Reader) error {
+	return s.repo.CreateBackup(ctx, b, content)
+}
+
+// ListBackups returns backups newest first, optionally for one resource.
+func (s *BackupService) ListBackups(ctx context.Context, resource string) ([]domain.Backup, error) {
+	backups, err := s.repo.ListBackups(ctx)
+	if err != nil || resource == "" {
+		return backups, err
+	}
+	filtered := make([]domain.Backup, 0, len(backups))
+	for _, b := range backups {
+		if b.Resource == resource {
+			filtered = append(filtered, b)
+		}
+	}
+	return filtered, nil
+}
+
+func (s *BackupService) GetBackup(ctx context.Context, id string) (*domain.Backup, error) {
+	return s.repo.GetBackup(ctx, id)
+}
+
+func (s *BackupService) OpenBackup(ctx context.Context, id string) (io.ReadCloser, error) {
+	return s.repo.OpenBackup(ctx, id)
+}
+
+func (s *BackupService) DeleteBackup(ctx context.Context, id string) error {
+	return s.repo.DeleteBackup(ctx, id)
+}
+
+// ApplyRetention deletes the resource's backups the retention rules expire
+// and returns their IDs.
+func (s *BackupService) ApplyRetention(ctx context.Context, resource string, retention domain.BackupRetention) ([]string, error) {
+	backups, err := s.ListBackups(ctx, resource)
+	if err != nil {
+		return nil, err
+	}
+	removed := make([]string, 0)
+	for _, b := range backup.Expired(backups, retention, time.Now()) {
+		if err := s.repo.DeleteBackup(ctx, b.ID); err != nil {
+			return removed, err
+		}
+		removed = append(removed, b.ID)
+	}
+	return removed, nil
+}
+
+func (s *BackupService) ListPolicies(ctx context.Context) ([]domain.BackupPolicy, error) {
+	return s.repo.ListBackupPolicies(ctx)
+}
+
+func (s *BackupService) GetPolicy(ctx context.Context, resource string) (*domain.BackupPolicy, error) {
+	return s.repo.GetBackupPolicy(ctx, resource)
+}
+
+func (s *BackupService) SavePolicy(ctx context.Context, policy *domain.BackupPolicy) error {
+	return s.repo.SaveBackupPolicy(ctx, policy)
+}
+
+func (s *BackupService) DeletePolicy(ctx context.Context, resource string) error {
+	return s.repo.DeleteBackupPolicy(ctx, resource)
+}
@@ -43,10 +43,6 @@ func (c *ContainerService) DeleteContainer(ctx context.Context, id string) error
 }
 
 func (c *ContainerService) CreateContainer(ctx context.Context, cfg *domain.ContainerCfg) (*dockerclient.ContainerCreateResult, error) {
-	if err := c.repo.EnsureImage(ctx, cfg.Image); err != nil {
-		return nil, err
-	}
-
 	res, err := c.repo.CreateContainer(ctx, cfg)
 	if err != nil {
 		return nil, err
@@ -55,6 +51,21 @@ func (c *ContainerService) CreateContainer(ctx context.Context, cfg *domain.Cont
 	return res, nil
 }
 
+func (c *ContainerService) CreateContainerFromSpec(ctx context.Context, spec *domain.ContainerSpec) (*dockerclient.ContainerCreateResult, error) {
+	return c.repo.CreateContainerFromSpec(ctx, spec)
+}
+
+func (c *ContainerService) CreateContainerFrom(ctx context.Context, source dockerclient.ContainerInspectResult, overrides *domain.ContainerOverrides) (*dockerclient.ContainerCreateResult, error) {
+	image := overrides.Image
+	if image == "" && source.Container.Config != nil {
+		image = source.Container.Config.Image
+	}
+	if err := c.repo.EnsureImage(ctx, image); err != nil {
+		return nil, err
+	}
+	return c.repo.CreateContainerFrom(ctx, source, overrides)
+}
+
 func (c *ContainerService) InsepectContainer(ctx context.Context, ID string) (dockerclient.ContainerInspectResult, error) {
 	container, err := c.repo.InsepectContainer(ctx, ID)
 	if err != nil {
@@ -122,6 +133,22 @@ func (c *ContainerService) FindContainersByComposeProject(ctx context.Context, p
 	return matches, nil
 }
 
+func (c *ContainerService) FindContainersByComposeService(ctx context.Context, project string, service string) ([]container.Summary, error) {
+	containers, err := c.FindContainersByComposeProject(ctx, project)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := make([]container.Summary, 0)
+	for _, cont := range containers {
+		if cont.Labels["com.docker.compose.service"] == service {
+			matches = append(matches, cont)
+		}
+	}
+
+	return matches, nil
+}
+
 func (c *ContainerService) StartComposeProject(ctx context.Context, project string) error {
 	containers, err := c.FindContainersByComposeProject(ctx, project)
 	if err != nil {
@@ -180,6 +207,92 @@ func (c *ContainerService) DeleteComposeProject(ctx context.Context, project str
 	return nil
 }
 
+func (c *ContainerService) ListNetworks(ctx context.Context) (dockerclient.NetworkListResult, error) {
+	return c.repo.ListNetworks(ctx)
+}
+
+func (c *ContainerService) NetworkExists(ctx context.Context, name string) (bool, error) {
+	networks, err := c.repo.ListNetworks(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, n := range networks.Items {
+		if n.Name == name || n.ID == name {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (c *ContainerService) CreateNetwork(ctx context.Context, spec *domain.NetworkSpec) (string, error) {
+	return c.repo.CreateNetwork(ctx, spec)
+}
+
+func (c *ContainerService) ListVolumes(ctx context.Context) (dockerclient.VolumeListResult, error) {
+	return c.repo.ListVolumes(ctx)
+}
+
+func (c *ContainerService) VolumeExists(ctx context.Context, name string) (bool, error) {
+	volumes, err := c.repo.ListVolumes(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, v := range volumes.Items {
+		if v.Name == name {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (c *ContainerService) CreateVolume(ctx context.Context, spec *domain.VolumeSpec) error {
+	return c.repo.CreateVolume(ctx, spec)
+}
+
+func (c *ContainerService) DeleteContainerWithVolumes(ctx context.Context, id string) error {
+	return c.repo.DeleteContainerWithVolumes(ctx, id)
+}
+
+func (c *ContainerService) RemoveNetwork(ctx context.Context, id string) error {
+	return c.repo.RemoveNetwork(ctx, id)
+}
+
+func (c *ContainerService) RemoveVolume(ctx context.Context, name string) error {
+	return c.repo.RemoveVolume(ctx, name)
+}
+
+func (c *ContainerService) ListImages(ctx context.Context) (dockerclient.ImageListResult, error) {
+	return c.repo.ListImages(ctx)
+}
+
+func (c *ContainerService) RemoveImage(ctx context.Context, id string) error {
+	return c.repo.RemoveImage(ctx, id)
+}
+
+func (c *ContainerService) BuildImage(ctx context.Context, spec domain.BuildSpec, tag string, labels map[string]string) (string, error) {
+	return c.repo.BuildImage(ctx, spec, tag, labels)
+}
+
+func (c *ContainerService) CommitContainer(ctx context.Context, id string, reference string) (string, error) {
+	return c.repo.CommitContainer(ctx, id, reference)
+}
+
+func (c *ContainerService) PauseContainer(ctx context.Context, id string) error {
+	return c.repo.PauseContainer(ctx, id)
+}
+
+func (c *ContainerService) UnpauseContainer(ctx context.Context, id string) error {
+	return c.repo.UnpauseContainer(ctx, id)
+}
+
+func (c *ContainerService) CopyContainerPath(ctx context.Context, sourceID, targetID, path string) error {
+	return c.repo.CopyContainerPath(ctx, sourceID, targetID, path)
+}
+
+func (c *ContainerService) ExecContainer(ctx context.Context, id string, spec domain.ExecSpec) (*domain.ExecResult, error) {
+	return c.repo.ExecContainer(ctx, id, spec)
+}
+
 func (s *ContainerService) StartDevconIfNotRunning(ctx context.Context, cfg *domain.ContainerCfg) (string, error) {
 	container, err := s.IsContainerRunning(ctx, cfg.Image)
 	if err != nil {
@@ -194,3 +307,23 @@ func (s *ContainerService) StartDevconIfNotRunning(ctx context.Context, cfg *dom
 	}
 	return res.ID, nil
 }
+
+func (c *ContainerService) ContainerEvents(ctx context.Context) (<-chan domain.ContainerEvent, <-chan error) {
+	return c.repo.ContainerEvents(ctx)
+}
+
+func (c *ContainerService) ContainerStats(ctx context.Context, id string) (*domain.ContainerStats, error) {
+	return c.repo.ContainerStats(ctx, id)
+}
+
+func (c *ContainerService) DiskUsage(ctx context.Context) (dockerclient.DiskUsageResult, error) {
+	return c.repo.DiskUsage(ctx)
+}
+
+func (c *ContainerService) EnsureImage(ctx context.Context, image string) error {
+	return c.repo.EnsureImage(ctx, image)
+}
+
+func (c *ContainerService) RenameContainer(ctx context.Context, id string, name string) error {
+	return c.repo.RenameContainer(ctx, id, name)
+}
@@ -0,0 +1,31 @@
+package service
+
+import (
+	"context"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+)
+
+type GCService struct {
+	repo domain.GCRepository
+}
+
+func NewGCService(repo domain.GCRepository) *GCService {
+	return &GCService{repo: repo}
+}
+
+func (s *GCService) GetConfig(ctx context.Context) (*domain.GCConfig, error) {
+	return s.repo.GetGCConfig(ctx)
+}
+
+func (s *GCService) SaveConfig(ctx context.Context, config *domain.GCConfig) error {
+	return s.repo.SaveGCConfig(ctx, config)
+}
+
+func (s *GCService) GetUsage(ctx context.Context) (map[string]int64, error) {
+	return s.repo.GetArtifactUsage(ctx)
+}
+
+func (s *GCService) SaveUsage(ctx context.Context, usage map[string]int64) error {
+	return s.repo.SaveArtifactUsage(ctx, usage)
+}
@@ -0,0 +1,31 @@
+package service
+
+import (
+	"context"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+)
+
+type LifecycleService struct {
+	repo domain.LifecycleRepository
+}
+
+func NewLifecycleService(repo domain.LifecycleRepository) *LifecycleService {
+	return &LifecycleService{repo: repo}
+}
+
+func (s *LifecycleService) ListLifecycles(ctx context.Context) ([]domain.Lifecycle, error) {
+	return s.repo.ListLifecycles(ctx)
+}
+
+func (s *LifecycleService) GetLifecycle(ctx context.Context, resource string) (*domain.Lifecycle, error) {
+	return s.repo.GetLifecycle(ctx, resource)
+}
+
+func (s *LifecycleService) SaveLifecycle(ctx context.Context, lifecycle *domain.Lifecycle) error {
+	return s.repo.SaveLifecycle(ctx, lifecycle)
+}
+
+func (s *LifecycleService) DeleteLifecycle(ctx context.Context, resource string) error {
+	return s.repo.DeleteLifecycle(ctx, resource)
+}
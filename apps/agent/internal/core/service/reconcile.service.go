@@ -0,0 +1,31 @@
+package service
+
+import (
+	"context"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+)
+
+type ReconcileService struct {
+	repo domain.DesiredStateRepository
+}
+
+func NewReconcileService(repo domain.DesiredStateRepository) *ReconcileService {
+	return &ReconcileService{repo: repo}
+}
+
+func (s *ReconcileService) ListDesiredStates(ctx context.Context) ([]domain.DesiredState, error) {
+	return s.repo.ListDesiredStates(ctx)
+}
+
+func (s *ReconcileService) GetDesiredState(ctx context.Context, resource string) (*domain.DesiredState, error) {
+	return s.repo.GetDesiredState(ctx, resource)
+}
+
+func (s *ReconcileService) SaveDesiredState(ctx context.Context, state *domain.DesiredState) error {
+	return s.repo.SaveDesiredState(ctx, state)
+}
+
+func (s *ReconcileService) DeleteDesiredState(ctx context.Context, resource string) error {
+	return s.repo.DeleteDesiredState(ctx, resource)
+}
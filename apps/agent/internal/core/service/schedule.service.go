@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+)
+
+type ScheduleService struct {
+	repo domain.ScheduleRepository
+}
+
+func NewScheduleService(repo domain.ScheduleRepository) *ScheduleService {
+	return &ScheduleService{repo: repo}
+}
+
+func (s *ScheduleService) ListSchedules(ctx context.Context) ([]domain.Schedule, error) {
+	return s.repo.ListSchedules(ctx)
+}
+
+func (s *ScheduleService) GetSchedule(ctx context.Context, name string) (*domain.Schedule, error) {
+	return s.repo.GetSchedule(ctx, name)
+}
+
+func (s *ScheduleService) SaveSchedule(ctx context.Context, schedule *domain.Schedule) error {
+	return s.repo.SaveSchedule(ctx, schedule)
+}
+
+func (s *ScheduleService) DeleteSchedule(ctx context.Context, name string) error {
+	return s.repo.DeleteSchedule(ctx, name)
+}
+
+func (s *ScheduleService) AddRun(ctx context.Context, run domain.ScheduleRun, keep int) error {
+	return s.repo.AddScheduleRun(ctx, run, keep)
+}
+
+func (s *ScheduleService) ListRuns(ctx context.Context, name string) ([]domain.ScheduleRun, error) {
+	return s.repo.ListScheduleRuns(ctx, name)
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+)
+
+const maxStackVersions = 20
+
+type StackService struct {
+	repo domain.StackRepository
+}
+
+func NewStackService(repo domain.StackRepository) *StackService {
+	return &StackService{repo: repo}
+}
+
+func (s *StackService) GetStack(ctx context.Context, project string) (*domain.StackDefinition, error) {
+	return s.repo.GetStack(ctx, project)
+}
+
+func (s *StackService) ListStacks(ctx context.Context) ([]domain.StackDefinition, error) {
+	return s.repo.ListStacks(ctx)
+}
+
+func (s *StackService) RecordVersion(ctx context.Context, project, name string, source domain.StackSource, note string) (*domain.StackVersion, error) {
+	stack, err := s.repo.GetStack(ctx, project)
+	if err != nil {
+		return nil, err
+	}
+	if stack == nil {
+		stack = &domain.StackDefinition{Project: project, Name: name}
+	}
+	next := 1
+	if current := stack.Current(); current != nil {
+		next = current.Version + 1
+	}
+	stack.Versions = append(stack.Versions, domain.StackVersion{
+		Version:     next,
+		StackSource: source,
+		CreatedAt:   time.Now().Unix(),
+		Note:        note,
+	})
+	if len(stack.Versions) > maxStackVersions {
+		stack.Versions = stack.Versions[len(stack.Versions)-maxStackVersions:]
+	}
+	if err := s.repo.SaveStack(ctx, stack); err != nil {
+		return nil, err
+	}
+	return stack.Current(), nil
+}
+
+func (s *StackService) SetWorkspace(ctx context.Context, project, workspace string) error {
+	stack, err := s.repo.GetStack(ctx, project)
+	if err != nil {
+		return err
+	}
+	if stack == nil || stack.Workspace == workspace {
+		return nil
+	}
+	stack.Workspace = workspace
+	return s.repo.SaveStack(ctx, stack)
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/template"
+)
+
+type TemplateService struct {
+	repo domain.TemplateRepository
+}
+
+func NewTemplateService(repo domain.TemplateRepository) *TemplateService {
+	return &TemplateService{repo: repo}
+}
+
+// ListTemplates returns the built-in catalog merged with custom templates; a
+// custom template replaces a built-in one with the same name.
+func (s *TemplateService) ListTemplates(ctx context.Context) ([]domain.Template, error) {
+	custom, err := s.repo.ListTemplates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]domain.Template)
+	for _, t := range template.Builtins() {
+		t.Builtin = true
+		byName[t.Name] = t
+	}
+	for _, t := range custom {
+		if err := template.Validate(t); err != nil {
+			return nil, err
+		}
+		byName[t.Name] = t
+	}
+
+	templates := make([]domain.Template, 0, len(byName))
+	for _, t := range byName {
+		templates = append(templates, t)
+	}
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+	return templates, nil
+}
+
+func (s *TemplateService) GetTemplate(ctx context.Context, name string) (*domain.Template, error) {
+	templates, err := s.ListTemplates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range templates {
+		if t.Name == name {
+			return &t, nil
+		}
+	}
+	return nil, fmt.Errorf("template %s not found", name)
+}
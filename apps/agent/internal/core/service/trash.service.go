@@ -0,0 +1,31 @@
+package service
+
+import (
+	"context"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+)
+
+type TrashService struct {
+	repo domain.TrashRepository
+}
+
+func NewTrashService(repo domain.TrashRepository) *TrashService {
+	return &TrashService{repo: repo}
+}
+
+func (s *TrashService) ListTrash(ctx context.Context) ([]domain.TrashEntry, error) {
+	return s.repo.ListTrash(ctx)
+}
+
+func (s *TrashService) GetTrash(ctx context.Context, resource string) (*domain.TrashEntry, error) {
+	return s.repo.GetTrash(ctx, resource)
+}
+
+func (s *TrashService) SaveTrash(ctx context.Context, entry *domain.TrashEntry) error {
+	return s.repo.SaveTrash(ctx, entry)
+}
+
+func (s *TrashService) DeleteTrash(ctx context.Context, resource string) error {
+	return s.repo.DeleteTrash(ctx, resource)
+}
@@ -0,0 +1,145 @@
+package template
+
+import "github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+
+func Builtins() []domain.Template {
+	return []domain.Template{
+		{
+			Name:        "postgres",
+			Description: "PostgreSQL database",
+			Type:        "postgres",
+			Image:       "postgres:16-alpine",
+			Ports:       []domain.TemplatePort{{Name: "postgres", ContainerPort: "5432", HostPort: "5432"}},
+			Env: []domain.TemplateEnv{
+				{Name: "POSTGRES_USER", Default: "devcon"},
+				{Name: "POSTGRES_PASSWORD", Generate: 24, Secret: true, Required: true},
+				{Name: "POSTGRES_DB", Default: "app"},
+			},
+			Volumes: []domain.TemplateVolume{{Name: "data", Target: "/var/lib/postgresql/data"}},
+			Healthcheck: &domain.HealthcheckSpec{
+				Test:     []string{"CMD-SHELL", `pg_isready -U "$POSTGRES_USER" -d "$POSTGRES_DB"`},
+				Interval: "5s",
+				Timeout:  "3s",
+				Retries:  10,
+			},
+		},
+		{
+			Name:        "mysql",
+			Description: "MySQL database",
+			Type:        "mysql",
+			Image:       "mysql:8.4",
+			Ports:       []domain.TemplatePort{{Name: "mysql", ContainerPort: "3306", HostPort: "3306"}},
+			Env: []domain.TemplateEnv{
+				{Name: "MYSQL_ROOT_PASSWORD", Generate: 24, Secret: true, Required: true},
+				{Name: "MYSQL_DATABASE", Default: "app"},
+				{Name: "MYSQL_USER", Default: "devcon"},
+				{Name: "MYSQL_PASSWORD", Generate: 24, Secret: true, Required: true},
+			},
+			Volumes: []domain.TemplateVolume{{Name: "data", Target: "/var/lib/mysql"}},
+			Healthcheck: &domain.HealthcheckSpec{
+				Test:        []string{"CMD-SHELL", `mysqladmin ping -h 127.0.0.1 -uroot -p"$MYSQL_ROOT_PASSWORD" --silent`},
+				Interval:    "5s",
+				Timeout:     "3s",
+				StartPeriod: "20s",
+				Retries:     10,
+			},
+		},
+		{
+			Name:        "redis",
+			Description: "Redis key-value store with append-only persistence",
+			Type:        "redis",
+			Image:       "redis:7-alpine",
+			Command:     []string{"sh", "-c", `exec redis-server --appendonly yes --requirepass "$REDIS_PASSWORD"`},
+			Ports:       []domain.TemplatePort{{Name: "redis", ContainerPort: "6379", HostPort: "6379"}},
+			Env: []domain.TemplateEnv{
+				{Name: "REDIS_PASSWORD", Generate: 24, Secret: true, Required: true},
+			},
+			Volumes: []domain.TemplateVolume{{Name: "data", Target: "/data"}},
+			Healthcheck: &domain.HealthcheckSpec{
+				Test:     []string{"CMD-SHELL", `redis-cli -a "$REDIS_PASSWORD" --no-auth-warning ping | grep PONG`},
+				Interval: "5s",
+				Timeout:  "3s",
+				Retries:  10,
+			},
+		},
+		{
+			Name:        "mongo",
+			Description: "MongoDB document database",
+			Type:        "mongo",
+			Image:       "mongo:7",
+			Ports:       []domain.TemplatePort{{Name: "mongo", ContainerPort: "27017", HostPort: "27017"}},
+			Env: []domain.TemplateEnv{
+				{Name: "MONGO_INITDB_ROOT_USERNAME", Default: "devcon"},
+				{Name: "MONGO_INITDB_ROOT_PASSWORD", Generate: 24, Secret: true, Required: true},
+			},
+			Volumes: []domain.TemplateVolume{{Name: "data", Target: "/data/db"}},
+			Healthcheck: &domain.HealthcheckSpec{
+				Test:        []string{"CMD", "mongosh", "--quiet", "--eval", "db.adminCommand('ping').ok"},
+				Interval:    "10s",
+				Timeout:     "5s",
+				StartPeriod: "20s",
+				Retries:     10,
+			},
+		},
+		{
+			Name:        "rabbitmq",
+			Description: "RabbitMQ broker with the management UI",
+			Type:        "rabbitmq",
+			Image:       "rabbitmq:3-management-alpine",
+			Ports: []domain.TemplatePort{
+				{Name: "amqp", ContainerPort: "5672", HostPort: "5672"},
+				{Name: "management", ContainerPort: "15672", HostPort: "15672"},
+			},
+			Env: []domain.TemplateEnv{
+				{Name: "RABBITMQ_DEFAULT_USER", Default: "devcon"},
+				{Name: "RABBITMQ_DEFAULT_PASS", Generate: 24, Secret: true, Required: true},
+			},
+			Volumes: []domain.TemplateVolume{{Name: "data", Target: "/var/lib/rabbitmq"}},
+			Healthcheck: &domain.HealthcheckSpec{
+				Test:        []string{"CMD", "rabbitmq-diagnostics", "-q", "ping"},
+				Interval:    "10s",
+				Timeout:     "5s",
+				StartPeriod: "20s",
+				Retries:     10,
+			},
+		},
+		{
+			Name:        "minio",
+			Description: "MinIO S3-compatible object storage",
+			Type:        "minio",
+			Image:       "minio/minio:latest",
+			Command:     []string{"server", "/data", "--console-address", ":9001"},
+			Ports: []domain.TemplatePort{
+				{Name: "api", ContainerPort: "9000", HostPort: "9000"},
+				{Name: "console", ContainerPort: "9001", HostPort: "9001"},
+			},
+			Env: []domain.TemplateEnv{
+				{Name: "MINIO_ROOT_USER", Default: "devcon"},
+				{Name: "MINIO_ROOT_PASSWORD", Generate: 24, Secret: true, Required: true},
+			},
+			Volumes: []domain.TemplateVolume{{Name: "data", Target: "/data"}},
+			Healthcheck: &domain.HealthcheckSpec{
+				Test:     []string{"CMD", "mc", "ready", "local"},
+				Interval: "5s",
+				Timeout:  "3s",
+				Retries:  10,
+			},
+		},
+		{
+			Name:        "mailhog",
+			Description: "MailHog SMTP catcher with a web inbox",
+			Type:        "mailhog",
+			Image:       "mailhog/mailhog:latest",
+			Ports: []domain.TemplatePort{
+				{Name: "smtp", ContainerPort: "1025", HostPort: "1025"},
+				{Name: "ui", ContainerPort: "8025", HostPort: "8025"},
+			},
+			Healthcheck: &domain.HealthcheckSpec{
+				Test:     []string{"CMD-SHELL", "wget -q --spider http://127.0.0.1:8025 || exit 1"},
+				Interval: "5s",
+				Timeout:  "3s",
+				Retries:  10,
+			},
+		},
+	}
+}
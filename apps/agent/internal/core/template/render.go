@@ -0,0 +1,204 @@
+package template
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/readiness"
+)
+
+var (
+	nameRegexp     = regexp.MustCompile(`^[a-z0-9][a-z0-9_.-]*$`)
+	resourceRegexp = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+	envRegexp      = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+const secretAlphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+func Validate(t domain.Template) error {
+	if !nameRegexp.MatchString(t.Name) {
+		return fmt.Errorf("template name %q must be lowercase letters, digits, '.', '_' or '-'", t.Name)
+	}
+	if strings.TrimSpace(t.Image) == "" {
+		return fmt.Errorf("template %s: image is required", t.Name)
+	}
+	seen := make(map[string]bool)
+	for _, port := range t.Ports {
+		if port.Name == "" {
+			return fmt.Errorf("template %s: every port needs a name", t.Name)
+		}
+		if seen[port.Name] {
+			return fmt.Errorf("template %s: duplicate port name %s", t.Name, port.Name)
+		}
+		seen[port.Name] = true
+		if _, _, err := splitPort(port.ContainerPort); err != nil {
+			return fmt.Errorf("template %s: port %s: %w", t.Name, port.Name, err)
+		}
+	}
+	for _, env := range t.Env {
+		if !envRegexp.MatchString(env.Name) {
+			return fmt.Errorf("template %s: invalid env name %q", t.Name, env.Name)
+		}
+		if env.Generate < 0 || env.Generate > 256 {
+			return fmt.Errorf("template %s: %s: generate must be between 0 and 256", t.Name, env.Name)
+		}
+	}
+	for _, volume := range t.Volumes {
+		if !nameRegexp.MatchString(volume.Name) {
+			return fmt.Errorf("template %s: invalid volume name %q", t.Name, volume.Name)
+		}
+		if !strings.HasPrefix(volume.Target, "/") {
+			return fmt.Errorf("template %s: volume %s target must be an absolute path", t.Name, volume.Name)
+		}
+	}
+	if t.Readiness != nil {
+		if _, err := readiness.Normalize(*t.Readiness); err != nil {
+			return fmt.Errorf("template %s: %w", t.Name, err)
+		}
+	}
+	return nil
+}
+
+// Render turns a template and request into a container spec. The returned
+// instance carries the resolved ports, env (including generated secrets) and
+// volume names; its Resource is filled in once the container exists.
+func Render(t domain.Template, req domain.TemplateRequest) (*domain.ContainerSpec, *domain.TemplateInstance, error) {
+	name := strings.TrimSpace(req.Name)
+	if !resourceRegexp.MatchString(name) {
+		return nil, nil, fmt.Errorf("invalid resource name %q", name)
+	}
+
+	image := t.Image
+	if req.Tag != "" {
+		if strings.ContainsAny(req.Tag, ":/@ \t") {
+			return nil, nil, fmt.Errorf("invalid image tag %q", req.Tag)
+		}
+		image = withTag(image, req.Tag)
+	}
+
+	instance := &domain.TemplateInstance{
+		Template: t.Name,
+		Ports:    make(map[string]string, len(t.Ports)),
+		Env:      make(map[string]string, len(t.Env)),
+		Volumes:  make([]string, 0, len(t.Volumes)),
+	}
+	spec := &domain.ContainerSpec{
+		Name:        name,
+		Image:       image,
+		Command:     t.Command,
+		Labels:      make(map[string]string),
+		Healthcheck: t.Healthcheck,
+	}
+
+	known := make(map[string]bool, len(t.Ports))
+	for _, port := range t.Ports {
+		containerPort, protocol, _ := splitPort(port.ContainerPort)
+		hostPort := port.HostPort
+		for _, key := range []string{port.Name, containerPort, containerPort + "/" + protocol} {
+			known[key] = true
+			if override, ok := req.HostPorts[key]; ok {
+				hostPort = override
+			}
+		}
+		if hostPort != "" {
+			if n, err := strconv.Atoi(hostPort); err != nil || n < 0 || n > 65535 {
+				return nil, nil, fmt.Errorf("invalid host port %q for %s", hostPort, port.Name)
+			}
+		}
+		spec.Ports = append(spec.Ports, domain.PortSpec{HostPort: hostPort, ContainerPort: containerPort, Protocol: protocol})
+		instance.Ports[port.Name] = hostPort
+	}
+	for key := range req.HostPorts {
+		if !known[key] {
+			return nil, nil, fmt.Errorf("template %s has no port %s", t.Name, key)
+		}
+	}
+
+	declared := make(map[string]bool, len(t.Env))
+	for _, env := range t.Env {
+		declared[env.Name] = true
+		value, ok := req.Env[env.Name]
+		if !ok || value == "" {
+			value = env.Default
+		}
+		if value == "" && env.Generate > 0 {
+			secret, err := GenerateSecret(env.Generate)
+			if err != nil {
+				return nil, nil, err
+			}
+			value = secret
+		}
+		if value == "" && env.Required {
+			return nil, nil, fmt.Errorf("env %s is required by template %s", env.Name, t.Name)
+		}
+		instance.Env[env.Name] = value
+	}
+	for key, value := range req.Env {
+		if !declared[key] {
+			if !envRegexp.MatchString(key) {
+				return nil, nil, fmt.Errorf("invalid env name %q", key)
+			}
+			instance.Env[key] = value
+		}
+	}
+	keys := make([]string, 0, len(instance.Env))
+	for key := range instance.Env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		spec.Env = append(spec.Env, key+"="+instance.Env[key])
+	}
+
+	for _, volume := range t.Volumes {
+		volumeName := name + "-" + volume.Name
+		spec.Mounts = append(spec.Mounts, domain.MountSpec{Type: "volume", Source: volumeName, Target: volume.Target})
+		instance.Volumes = append(instance.Volumes, volumeName)
+	}
+	return spec, instance, nil
+}
+
+func GenerateSecret(length int) (string, error) {
+	b := make([]byte, length)
+	max := big.NewInt(int64(len(secretAlphabet)))
+	for i := range b {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		b[i] = secretAlphabet[n.Int64()]
+	}
+	return string(b), nil
+}
+
+func splitPort(spec string) (string, string, error) {
+	port, protocol, hasProtocol := strings.Cut(strings.TrimSpace(spec), "/")
+	if !hasProtocol {
+		protocol = "tcp"
+	}
+	if protocol != "tcp" && protocol != "udp" {
+		return "", "", fmt.Errorf("unsupported protocol %q", protocol)
+	}
+	n, err := strconv.Atoi(port)
+	if err != nil || n < 1 || n > 65535 {
+		return "", "", fmt.Errorf("invalid container port %q", spec)
+	}
+	return port, protocol, nil
+}
+
+// withTag swaps the tag of an image reference, leaving registry ports alone.
+func withTag(image, tag string) string {
+	if i := strings.Index(image, "@"); i >= 0 {
+		image = image[:i]
+	}
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		image = image[:i]
+	}
+	return image + ":" + tag
+}
@@ -0,0 +1,171 @@
+package template
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+)
+
+var testTemplate = domain.Template{
+	Name:  "db",
+	Image: "registry.local:5000/acme/db:1",
+	Ports: []domain.TemplatePort{
+		{Name: "sql", ContainerPort: "5432", HostPort: "5432"},
+		{Name: "metrics", ContainerPort: "9187/udp"},
+	},
+	Env: []domain.TemplateEnv{
+		{Name: "DB_USER", Default: "devcon"},
+		{Name: "DB_PASSWORD", Generate: 16, Required: true},
+		{Name: "DB_LICENSE", Required: true},
+	},
+	Volumes: []domain.TemplateVolume{{Name: "data", Target: "/var/lib/db"}},
+}
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name      string
+		req       domain.TemplateRequest
+		wantImage string
+		wantPorts []domain.PortSpec
+		wantEnv   map[string]string
+		wantErr   string
+	}{
+		{
+			name:      "defaults",
+			req:       domain.TemplateRequest{Name: "orders-db", Env: map[string]string{"DB_LICENSE": "abc"}},
+			wantImage: "registry.local:5000/acme/db:1",
+			wantPorts: []domain.PortSpec{{HostPort: "5432", ContainerPort: "5432", Protocol: "tcp"}, {ContainerPort: "9187", Protocol: "udp"}},
+			wantEnv:   map[string]string{"DB_USER": "devcon", "DB_LICENSE": "abc"},
+		},
+		{
+			name: "overrides",
+			req: domain.TemplateRequest{
+				Name:      "orders-db",
+				Tag:       "2",
+				HostPorts: map[string]string{"sql": "15432", "9187/udp": "19187"},
+				Env:       map[string]string{"DB_USER": "app", "DB_PASSWORD": "secret", "DB_LICENSE": "abc", "EXTRA": "1"},
+			},
+			wantImage: "registry.local:5000/acme/db:2",
+			wantPorts: []domain.PortSpec{{HostPort: "15432", ContainerPort: "5432", Protocol: "tcp"}, {HostPort: "19187", ContainerPort: "9187", Protocol: "udp"}},
+			wantEnv:   map[string]string{"DB_USER": "app", "DB_PASSWORD": "secret", "DB_LICENSE": "abc", "EXTRA": "1"},
+		},
+		{
+			name:      "host port by container port",
+			req:       domain.TemplateRequest{Name: "db", HostPorts: map[string]string{"5432": "0"}, Env: map[string]string{"DB_LICENSE": "abc"}},
+			wantImage: "registry.local:5000/acme/db:1",
+			wantPorts: []domain.PortSpec{{HostPort: "0", ContainerPort: "5432", Protocol: "tcp"}, {ContainerPort: "9187", Protocol: "udp"}},
+			wantEnv:   map[string]string{"DB_USER": "devcon", "DB_LICENSE": "abc"},
+		},
+		{name: "invalid name", req: domain.TemplateRequest{Name: "-db"}, wantErr: "invalid resource name"},
+		{name: "invalid tag", req: domain.TemplateRequest{Name: "db", Tag: "1:2"}, wantErr: "invalid image tag"},
+		{name: "invalid host port", req: domain.TemplateRequest{Name: "db", HostPorts: map[string]string{"sql": "70000"}}, wantErr: `invalid host port "70000" for sql`},
+		{name: "unknown port", req: domain.TemplateRequest{Name: "db", HostPorts: map[string]string{"http": "80"}}, wantErr: "template db has no port http"},
+		{name: "missing required env", req: domain.TemplateRequest{Name: "db"}, wantErr: "env DB_LICENSE is required by template db"},
+		{name: "invalid extra env", req: domain.TemplateRequest{Name: "db", Env: map[string]string{"DB_LICENSE": "abc", "1BAD": "x"}}, wantErr: `invalid env name "1BAD"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, instance, err := Render(testTemplate, tt.req)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Render() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if spec.Image != tt.wantImage {
+				t.Errorf("Image = %q, want %q", spec.Image, tt.wantImage)
+			}
+			if !reflect.DeepEqual(spec.Ports, tt.wantPorts) {
+				t.Errorf("Ports = %+v, want %+v", spec.Ports, tt.wantPorts)
+			}
+
+			password := instance.Env["DB_PASSWORD"]
+			if _, ok := tt.wantEnv["DB_PASSWORD"]; !ok {
+				if len(password) != 16 {
+					t.Errorf("generated DB_PASSWORD has length %d, want 16", len(password))
+				}
+				delete(instance.Env, "DB_PASSWORD")
+			}
+			if !reflect.DeepEqual(instance.Env, tt.wantEnv) {
+				t.Errorf("Env = %v, want %v", instance.Env, tt.wantEnv)
+			}
+			for i := 1; i < len(spec.Env); i++ {
+				if spec.Env[i-1] > spec.Env[i] {
+					t.Errorf("spec env is not sorted: %v", spec.Env)
+				}
+			}
+
+			wantMounts := []domain.MountSpec{{Type: "volume", Source: spec.Name + "-data", Target: "/var/lib/db"}}
+			if !reflect.DeepEqual(spec.Mounts, wantMounts) {
+				t.Errorf("Mounts = %+v, want %+v", spec.Mounts, wantMounts)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		edit    func(*domain.Template)
+		wantErr string
+	}{
+		{"valid", func(*domain.Template) {}, ""},
+		{"uppercase name", func(t *domain.Template) { t.Name = "DB" }, "must be lowercase"},
+		{"no image", func(t *domain.Template) { t.Image = " " }, "image is required"},
+		{"unnamed port", func(t *domain.Template) { t.Ports = []domain.TemplatePort{{ContainerPort: "80"}} }, "every port needs a name"},
+		{"duplicate port", func(t *domain.Template) {
+			t.Ports = []domain.TemplatePort{{Name: "a", ContainerPort: "80"}, {Name: "a", ContainerPort: "81"}}
+		}, "duplicate port name a"},
+		{"bad protocol", func(t *domain.Template) { t.Ports = []domain.TemplatePort{{Name: "a", ContainerPort: "80/sctp"}} }, "unsupported protocol"},
+		{"bad container port", func(t *domain.Template) { t.Ports = []domain.TemplatePort{{Name: "a", ContainerPort: "0"}} }, "invalid container port"},
+		{"bad env name", func(t *domain.Template) { t.Env = []domain.TemplateEnv{{Name: "A-B"}} }, "invalid env name"},
+		{"secret too long", func(t *domain.Template) { t.Env = []domain.TemplateEnv{{Name: "A", Generate: 257}} }, "generate must be between 0 and 256"},
+		{"bad volume name", func(t *domain.Template) { t.Volumes = []domain.TemplateVolume{{Name: "Data", Target: "/data"}} }, "invalid volume name"},
+		{"relative volume target", func(t *domain.Template) { t.Volumes = []domain.TemplateVolume{{Name: "data", Target: "data"}} }, "must be an absolute path"},
+		{"bad readiness", func(t *domain.Template) { t.Readiness = &domain.ReadinessProbe{Type: "exec"} }, "exec probe needs a command"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl := testTemplate
+			tt.edit(&tmpl)
+			err := Validate(tmpl)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Validate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBuiltinsAreValid(t *testing.T) {
+	for _, tmpl := range Builtins() {
+		if err := Validate(tmpl); err != nil {
+			t.Errorf("builtin %s: %v", tmpl.Name, err)
+		}
+	}
+}
+
+func TestWithTag(t *testing.T) {
+	tests := []struct{ image, tag, want string }{
+		{"postgres", "16", "postgres:16"},
+		{"postgres:15-alpine", "16", "postgres:16"},
+		{"localhost:5000/db", "2", "localhost:5000/db:2"},
+		{"localhost:5000/db:1", "2", "localhost:5000/db:2"},
+		{"redis@sha256:abcd", "7", "redis:7"},
+	}
+	for _, tt := range tests {
+		if got := withTag(tt.image, tt.tag); got != tt.want {
+			t.Errorf("withTag(%q, %q) = %q, want %q", tt.image, tt.tag, got, tt.want)
+		}
+	}
+}
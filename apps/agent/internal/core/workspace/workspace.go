@@ -0,0 +1,201 @@
+package workspace
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/compose"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/deps"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+	"github.com/goccy/go-yaml"
+)
+
+const DefaultFile = "devcon.yaml"
+
+var namePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+// Parse reads a workspace spec and resolves relative stack paths and bind
+// mount sources against dir.
+func Parse(content []byte, dir string) (*domain.WorkspaceSpec, error) {
+	var spec domain.WorkspaceSpec
+	if err := yaml.Unmarshal(content, &spec); err != nil {
+		return nil, fmt.Errorf("workspace spec: %w", err)
+	}
+	spec.Name = strings.TrimSpace(spec.Name)
+	if !namePattern.MatchString(spec.Name) {
+		return nil, fmt.Errorf("workspace spec: invalid workspace name %q", spec.Name)
+	}
+
+	seen := make(map[string]bool)
+	unique := func(kind, name string) error {
+		if !namePattern.MatchString(name) {
+			return fmt.Errorf("workspace spec: invalid %s name %q", kind, name)
+		}
+		if seen[kind+"/"+name] {
+			return fmt.Errorf("workspace spec: duplicate %s %q", kind, name)
+		}
+		seen[kind+"/"+name] = true
+		return nil
+	}
+
+	for _, network := range spec.Networks {
+		if err := unique("network", network.Name); err != nil {
+			return nil, err
+		}
+	}
+	for _, volume := range spec.Volumes {
+		if err := unique("volume", volume.Name); err != nil {
+			return nil, err
+		}
+	}
+	for i := range spec.Stacks {
+		stack := &spec.Stacks[i]
+		if err := unique("stack", stack.Name); err != nil {
+			return nil, err
+		}
+		switch {
+		case stack.Compose != "" && stack.Path != "":
+			return nil, fmt.Errorf("workspace spec: stack %s: compose and path are mutually exclusive", stack.Name)
+		case stack.Compose == "" && stack.Path == "":
+			return nil, fmt.Errorf("workspace spec: stack %s: compose or path is required", stack.Name)
+		case stack.Path == "" && (stack.EnvFile != "" || len(stack.Files) > 0):
+			return nil, fmt.Errorf("workspace spec: stack %s: files and env_file require path", stack.Name)
+		case stack.Path != "" && len(stack.Overrides) > 0:
+			return nil, fmt.Errorf("workspace spec: stack %s: overrides require inline compose", stack.Name)
+		case stack.Path != "":
+			resolved, err := resolve(dir, stack.Path)
+			if err != nil {
+				return nil, fmt.Errorf("workspace spec: stack %s: %w", stack.Name, err)
+			}
+			stack.Path = resolved
+		}
+	}
+	for i := range spec.Resources {
+		resource := &spec.Resources[i]
+		if err := unique("resource", resource.Name); err != nil {
+			return nil, err
+		}
+		if err := validateResource(resource, dir); err != nil {
+			return nil, fmt.Errorf("workspace spec: resource %s: %w", resource.Name, err)
+		}
+	}
+	for _, stack := range spec.Stacks {
+		if seen["resource/"+stack.Name] {
+			return nil, fmt.Errorf("workspace spec: %q is both a stack and a resource", stack.Name)
+		}
+	}
+	if err := orderResources(&spec); err != nil {
+		return nil, fmt.Errorf("workspace spec: %w", err)
+	}
+	return &spec, nil
+}
+
+// orderResources sorts resources so each comes after the resources of the
+// spec it depends on. Dependencies outside the spec are checked at apply.
+func orderResources(spec *domain.WorkspaceSpec) error {
+	graph := make(map[string][]string, len(spec.Resources))
+	byName := make(map[string]domain.WorkspaceResource, len(spec.Resources))
+	for _, resource := range spec.Resources {
+		graph[resource.Name] = nil
+		byName[resource.Name] = resource
+		for _, dep := range resource.DependsOn {
+			graph[resource.Name] = append(graph[resource.Name], dep.Resource)
+		}
+	}
+	order, err := deps.Order(graph)
+	if err != nil {
+		return err
+	}
+	spec.Resources = spec.Resources[:0]
+	for _, name := range order {
+		spec.Resources = append(spec.Resources, byName[name])
+	}
+	return nil
+}
+
+func validateResource(resource *domain.WorkspaceResource, dir string) error {
+	resource.Image = strings.TrimSpace(resource.Image)
+	if resource.Image == "" {
+		return fmt.Errorf("image is required")
+	}
+	seen := make(map[string]bool, len(resource.DependsOn))
+	for i, dep := range resource.DependsOn {
+		dep, err := deps.Normalize(dep)
+		if err != nil {
+			return err
+		}
+		if dep.Resource == resource.Name {
+			return fmt.Errorf("cannot depend on itself")
+		}
+		if seen[dep.Resource] {
+			return fmt.Errorf("dependency %s is listed twice", dep.Resource)
+		}
+		seen[dep.Resource] = true
+		resource.DependsOn[i] = dep
+	}
+	for _, port := range resource.Ports {
+		if _, err := compose.ParsePort(port); err != nil {
+			return err
+		}
+	}
+	for i, volume := range resource.Volumes {
+		mount := compose.ParseMount(volume)
+		if !strings.HasPrefix(mount.Target, "/") {
+			return fmt.Errorf("mount target in %q must be an absolute container path", volume)
+		}
+		if mount.Type == "bind" && !filepath.IsAbs(mount.Source) {
+			source, err := resolve(dir, mount.Source)
+			if err != nil {
+				return err
+			}
+			resource.Volumes[i] = source + strings.TrimPrefix(volume, mount.Source)
+		}
+	}
+	return nil
+}
+
+func resolve(dir, path string) (string, error) {
+	if filepath.IsAbs(path) {
+		return filepath.Clean(path), nil
+	}
+	if strings.HasPrefix(path, "~") {
+		return "", fmt.Errorf("path %q cannot start with ~", path)
+	}
+	if dir == "" {
+		return "", fmt.Errorf("relative path %q requires the spec directory", path)
+	}
+	return filepath.Join(dir, path), nil
+}
+
+// Mounts converts the resource's volume strings to mount specs.
+func Mounts(resource domain.WorkspaceResource) []domain.MountSpec {
+	mounts := make([]domain.MountSpec, 0, len(resource.Volumes))
+	for _, volume := range resource.Volumes {
+		mounts = append(mounts, compose.ParseMount(volume))
+	}
+	return mounts
+}
+
+// Ports converts the resource's port strings; Parse has already validated them.
+func Ports(resource domain.WorkspaceResource) []domain.PortSpec {
+	ports := make([]domain.PortSpec, 0, len(resource.Ports))
+	for _, port := range resource.Ports {
+		if spec, err := compose.ParsePort(port); err == nil {
+			ports = append(ports, spec)
+		}
+	}
+	return ports
+}
+
+// Hash identifies a resource definition so apply can tell whether the
+// running container still matches it.
+func Hash(resource domain.WorkspaceResource) string {
+	data, _ := json.Marshal(resource)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -6,7 +6,9 @@ import (
 	"encoding/binary"
 	"io"
 	"net/netip"
+	"path"
 	"strconv"
+	"strings"
 
 	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
 	containertypes "github.com/moby/moby/api/types/container"
@@ -14,10 +16,22 @@ import (
 	dockerclient "github.com/moby/moby/client"
 )
 
+// EnsureImage pulls image so mutable tags such as postgres:latest stay
+// current. Images devcon built or committed exist only locally and are used
+// as they are: those under devcon/ and those labelled by devcon or compose.
 func (d *Daemon) EnsureImage(ctx context.Context, image string) error {
 	if image == "" {
 		return nil
 	}
+	if inspect, err := d.client.ImageInspect(ctx, image); err == nil {
+		var labels map[string]string
+		if inspect.Config != nil {
+			labels = inspect.Config.Labels
+		}
+		if localImage(image, labels) {
+			return nil
+		}
+	}
 
 	response, err := d.client.ImagePull(ctx, image, dockerclient.ImagePullOptions{})
 	if err != nil {
@@ -29,6 +43,18 @@ func (d *Daemon) EnsureImage(ctx context.Context, image string) error {
 	return err
 }
 
+func localImage(image string, labels map[string]string) bool {
+	if strings.HasPrefix(image, "devcon/") {
+		return true
+	}
+	for key := range labels {
+		if key == "com.docker.compose.project" || strings.HasPrefix(key, "devcon.") {
+			return true
+		}
+	}
+	return false
+}
+
 func (d *Daemon) Ping(ctx context.Context) error {
 
 	_, err := d.client.Ping(ctx, dockerclient.PingOptions{})
@@ -81,6 +107,14 @@ func (d *Daemon) DeleteContainer(ctx context.Context, id string) error {
 	return err
 }
 
+func (d *Daemon) DeleteContainerWithVolumes(ctx context.Context, id string) error {
+	_, err := d.client.ContainerRemove(ctx, id, dockerclient.ContainerRemoveOptions{
+		Force:         true,
+		RemoveVolumes: true,
+	})
+	return err
+}
+
 func (d *Daemon) CreateContainer(ctx context.Context, cfg *domain.ContainerCfg) (*dockerclient.ContainerCreateResult, error) {
 
 	port, err := network.ParsePort(cfg.ContainerPort + "/tcp")
@@ -101,6 +135,9 @@ func (d *Daemon) CreateContainer(ctx context.Context, cfg *domain.ContainerCfg)
 	if cfg.Type != "" {
 		labels["devcon.resource_type"] = cfg.Type
 	}
+	for k, v := range cfg.Labels {
+		labels[k] = v
+	}
 
 	res, err := d.client.ContainerCreate(ctx, dockerclient.ContainerCreateOptions{
 		Name: cfg.Name,
@@ -184,3 +221,43 @@ func copyDockerLogStream(dst io.Writer, src io.Reader) error {
 		}
 	}
 }
+
+func (d *Daemon) CommitContainer(ctx context.Context, id string, reference string) (string, error) {
+	res, err := d.client.ContainerCommit(ctx, id, dockerclient.ContainerCommitOptions{Reference: reference})
+	if err != nil {
+		return "", err
+	}
+	return res.ID, nil
+}
+
+func (d *Daemon) RenameContainer(ctx context.Context, id string, name string) error {
+	_, err := d.client.ContainerRename(ctx, id, dockerclient.ContainerRenameOptions{NewName: name})
+	return err
+}
+
+func (d *Daemon) PauseContainer(ctx context.Context, id string) error {
+	_, err := d.client.ContainerPause(ctx, id, dockerclient.ContainerPauseOptions{})
+	return err
+}
+
+func (d *Daemon) UnpauseContainer(ctx context.Context, id string) error {
+	_, err := d.client.ContainerUnpause(ctx, id, dockerclient.ContainerUnpauseOptions{})
+	return err
+}
+
+// CopyContainerPath copies a directory from one container into the same path
+// of another, keeping ownership so data directories stay usable.
+func (d *Daemon) CopyContainerPath(ctx context.Context, sourceID, targetID, containerPath string) error {
+	res, err := d.client.CopyFromContainer(ctx, sourceID, dockerclient.CopyFromContainerOptions{SourcePath: containerPath})
+	if err != nil {
+		return err
+	}
+	defer res.Content.Close()
+
+	_, err = d.client.CopyToContainer(ctx, targetID, dockerclient.CopyToContainerOptions{
+		DestinationPath: path.Dir(path.Clean(containerPath)),
+		Content:         res.Content,
+		CopyUIDGID:      true,
+	})
+	return err
+}
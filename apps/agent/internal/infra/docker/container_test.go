@@ -0,0 +1,23 @@
+package docker
+
+import "testing"
+
+func TestLocalImage(t *testing.T) {
+	tests := []struct {
+		image  string
+		labels map[string]string
+		want   bool
+	}{
+		{"devcon/clone-pg:1700000000", nil, true},
+		{"shop-web", map[string]string{"com.docker.compose.project": "shop"}, true},
+		{"pg-snapshot", map[string]string{"devcon.type": "postgres"}, true},
+		{"postgres:latest", map[string]string{"org.opencontainers.image.title": "postgres"}, false},
+		{"redis:7", nil, false},
+		{"registry.local/devcon/app:1", nil, false},
+	}
+	for _, tt := range tests {
+		if got := localImage(tt.image, tt.labels); got != tt.want {
+			t.Errorf("localImage(%q, %v) = %v, want %v", tt.image, tt.labels, got, tt.want)
+		}
+	}
+}
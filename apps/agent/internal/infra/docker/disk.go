@@ -0,0 +1,17 @@
+package docker
+
+import (
+	"context"
+
+	dockerclient "github.com/moby/moby/client"
+)
+
+// DiskUsage reports the size of every container, image and volume.
+func (d *Daemon) DiskUsage(ctx context.Context) (dockerclient.DiskUsageResult, error) {
+	return d.client.DiskUsage(ctx, dockerclient.DiskUsageOptions{
+		Containers: true,
+		Images:     true,
+		Volumes:    true,
+		Verbose:    true,
+	})
+}
@@ -0,0 +1,44 @@
+package docker
+
+import (
+	"context"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+	dockerclient "github.com/moby/moby/client"
+)
+
+// ContainerEvents streams container lifecycle events. The event channel is
+// closed when ctx is cancelled or the daemon connection fails; the error, if
+// any, is then available on the error channel.
+func (d *Daemon) ContainerEvents(ctx context.Context) (<-chan domain.ContainerEvent, <-chan error) {
+	res := d.client.Events(ctx, dockerclient.EventsListOptions{
+		Filters: make(dockerclient.Filters).Add("type", "container"),
+	})
+	events := make(chan domain.ContainerEvent)
+	errs := make(chan error, 1)
+	go func() {
+		defer close(errs)
+		defer close(events)
+		for {
+			select {
+			case err := <-res.Err:
+				errs <- err
+				return
+			case msg := <-res.Messages:
+				event := domain.ContainerEvent{
+					ID:     msg.Actor.ID,
+					Name:   msg.Actor.Attributes["name"],
+					Action: string(msg.Action),
+					Time:   msg.Time,
+				}
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					errs <- ctx.Err()
+					return
+				}
+			}
+		}
+	}()
+	return events, errs
+}
@@ -0,0 +1,79 @@
+package docker
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+	dockerclient "github.com/moby/moby/client"
+)
+
+// ExecContainer runs cmd inside a running container and waits for it,
+// returning its exit code and separated stdout and stderr.
+func (d *Daemon) ExecContainer(ctx context.Context, id string, spec domain.ExecSpec) (*domain.ExecResult, error) {
+	created, err := d.client.ExecCreate(ctx, id, dockerclient.ExecCreateOptions{
+		User:         spec.User,
+		Env:          spec.Env,
+		Cmd:          spec.Cmd,
+		AttachStdin:  spec.Stdin != nil,
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	attached, err := d.client.ExecAttach(ctx, created.ID, dockerclient.ExecAttachOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer attached.Close()
+
+	if spec.Stdin != nil {
+		go func() {
+			_, _ = io.Copy(attached.Conn, spec.Stdin)
+			_ = attached.CloseWrite()
+		}()
+	}
+
+	var stdout, stderr bytes.Buffer
+	var out io.Writer = &stdout
+	if spec.Stdout != nil {
+		out = spec.Stdout
+	}
+	if err := demuxDockerStream(out, &stderr, attached.Reader); err != nil {
+		return nil, err
+	}
+
+	inspect, err := d.client.ExecInspect(ctx, created.ID, dockerclient.ExecInspectOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return &domain.ExecResult{ExitCode: inspect.ExitCode, Stdout: stdout.Bytes(), Stderr: stderr.Bytes()}, nil
+}
+
+// demuxDockerStream splits a multiplexed attach stream by its stream header.
+func demuxDockerStream(stdout, stderr io.Writer, src io.Reader) error {
+	header := make([]byte, 8)
+	for {
+		if _, err := io.ReadFull(src, header); err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				return nil
+			}
+			return err
+		}
+		dst := stdout
+		if header[0] == 2 {
+			dst = stderr
+		}
+		frameSize := binary.BigEndian.Uint32(header[4:])
+		if _, err := io.CopyN(dst, src, int64(frameSize)); err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				return nil
+			}
+			return err
+		}
+	}
+}
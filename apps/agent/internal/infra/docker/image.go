@@ -0,0 +1,171 @@
+package docker
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+	dockerclient "github.com/moby/moby/client"
+)
+
+func (d *Daemon) ListImages(ctx context.Context) (dockerclient.ImageListResult, error) {
+	return d.client.ImageList(ctx, dockerclient.ImageListOptions{})
+}
+
+func (d *Daemon) RemoveImage(ctx context.Context, id string) error {
+	_, err := d.client.ImageRemove(ctx, id, dockerclient.ImageRemoveOptions{})
+	return err
+}
+
+func (d *Daemon) BuildImage(ctx context.Context, spec domain.BuildSpec, tag string, labels map[string]string) (string, error) {
+	buildContext, err := tarContext(spec.Context)
+	if err != nil {
+		return "", err
+	}
+	defer buildContext.Close()
+
+	args := make(map[string]*string, len(spec.Args))
+	for key, value := range spec.Args {
+		args[key] = &value
+	}
+	res, err := d.client.ImageBuild(ctx, buildContext, dockerclient.ImageBuildOptions{
+		Tags:       []string{tag},
+		Dockerfile: spec.Dockerfile,
+		Target:     spec.Target,
+		BuildArgs:  args,
+		Labels:     labels,
+		Remove:     true,
+	})
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	var id string
+	decoder := json.NewDecoder(res.Body)
+	for {
+		var msg struct {
+			Error string `json:"error"`
+			Aux   struct {
+				ID string `json:"ID"`
+			} `json:"aux"`
+		}
+		if err := decoder.Decode(&msg); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return "", err
+		}
+		if msg.Error != "" {
+			return "", fmt.Errorf("build failed: %s", msg.Error)
+		}
+		if msg.Aux.ID != "" {
+			id = msg.Aux.ID
+		}
+	}
+	return id, nil
+}
+
+// tarContext streams dir as a build context, skipping paths matched by a
+// top-level .dockerignore.
+func tarContext(dir string) (io.ReadCloser, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("build context %s is not a directory", dir)
+	}
+	ignore := readDockerignore(dir)
+
+	reader, writer := io.Pipe()
+	go func() {
+		tw := tar.NewWriter(writer)
+		err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			rel, err := filepath.Rel(dir, path)
+			if err != nil || rel == "." {
+				return err
+			}
+			rel = filepath.ToSlash(rel)
+			if ignored(ignore, rel) {
+				if entry.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			info, err := entry.Info()
+			if err != nil {
+				return err
+			}
+			link := ""
+			if info.Mode()&os.ModeSymlink != 0 {
+				if link, err = os.Readlink(path); err != nil {
+					return err
+				}
+			}
+			header, err := tar.FileInfoHeader(info, link)
+			if err != nil {
+				return err
+			}
+			header.Name = rel
+			if err := tw.WriteHeader(header); err != nil {
+				return err
+			}
+			if !info.Mode().IsRegular() {
+				return nil
+			}
+			file, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			_, err = io.Copy(tw, file)
+			return err
+		})
+		if err == nil {
+			err = tw.Close()
+		}
+		writer.CloseWithError(err)
+	}()
+	return reader, nil
+}
+
+func readDockerignore(dir string) []string {
+	content, err := os.ReadFile(filepath.Join(dir, ".dockerignore"))
+	if err != nil {
+		return nil
+	}
+	var patterns []string
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "!") {
+			continue
+		}
+		patterns = append(patterns, strings.TrimPrefix(path.Clean(line), "/"))
+	}
+	return patterns
+}
+
+func ignored(patterns []string, rel string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, rel); ok {
+			return true
+		}
+		if strings.HasPrefix(rel, pattern+"/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,29 @@
+package docker
+
+import (
+	"context"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+	dockerclient "github.com/moby/moby/client"
+)
+
+func (d *Daemon) ListNetworks(ctx context.Context) (dockerclient.NetworkListResult, error) {
+	return d.client.NetworkList(ctx, dockerclient.NetworkListOptions{})
+}
+
+func (d *Daemon) CreateNetwork(ctx context.Context, spec *domain.NetworkSpec) (string, error) {
+	res, err := d.client.NetworkCreate(ctx, spec.Name, dockerclient.NetworkCreateOptions{
+		Driver:   spec.Driver,
+		Internal: spec.Internal,
+		Labels:   spec.Labels,
+	})
+	if err != nil {
+		return "", err
+	}
+	return res.ID, nil
+}
+
+func (d *Daemon) RemoveNetwork(ctx context.Context, id string) error {
+	_, err := d.client.NetworkRemove(ctx, id, dockerclient.NetworkRemoveOptions{})
+	return err
+}
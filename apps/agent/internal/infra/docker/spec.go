@@ -0,0 +1,257 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+	containertypes "github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/api/types/mount"
+	"github.com/moby/moby/api/types/network"
+	dockerclient "github.com/moby/moby/client"
+)
+
+func (d *Daemon) CreateContainerFromSpec(ctx context.Context, spec *domain.ContainerSpec) (*dockerclient.ContainerCreateResult, error) {
+	config := &containertypes.Config{
+		Image:        spec.Image,
+		Cmd:          spec.Command,
+		Entrypoint:   spec.Entrypoint,
+		Env:          spec.Env,
+		Labels:       spec.Labels,
+		WorkingDir:   spec.WorkingDir,
+		User:         spec.User,
+		Hostname:     spec.Hostname,
+		StopSignal:   spec.StopSignal,
+		Tty:          spec.Tty,
+		OpenStdin:    spec.OpenStdin,
+		ExposedPorts: network.PortSet{},
+	}
+	hostConfig := &containertypes.HostConfig{
+		PortBindings: network.PortMap{},
+		Privileged:   spec.Privileged,
+		CapAdd:       spec.CapAdd,
+		ExtraHosts:   spec.ExtraHosts,
+	}
+
+	for _, exposed := range spec.Expose {
+		port, err := network.ParsePort(exposedPort(exposed))
+		if err != nil {
+			return nil, err
+		}
+		config.ExposedPorts[port] = struct{}{}
+	}
+
+	for _, p := range spec.Ports {
+		protocol := p.Protocol
+		if protocol == "" {
+			protocol = "tcp"
+		}
+		port, err := network.ParsePort(p.ContainerPort + "/" + protocol)
+		if err != nil {
+			return nil, err
+		}
+		config.ExposedPorts[port] = struct{}{}
+		binding := network.PortBinding{HostPort: p.HostPort}
+		if p.HostIP != "" {
+			ip, err := netip.ParseAddr(p.HostIP)
+			if err != nil {
+				return nil, fmt.Errorf("invalid host ip %q: %w", p.HostIP, err)
+			}
+			binding.HostIP = ip
+		}
+		hostConfig.PortBindings[port] = append(hostConfig.PortBindings[port], binding)
+	}
+
+	for _, m := range spec.Mounts {
+		hostConfig.Mounts = append(hostConfig.Mounts, mount.Mount{
+			Type:     mount.Type(m.Type),
+			Source:   m.Source,
+			Target:   m.Target,
+			ReadOnly: m.ReadOnly,
+		})
+	}
+
+	if len(spec.Tmpfs) > 0 {
+		hostConfig.Tmpfs = make(map[string]string, len(spec.Tmpfs))
+		for _, entry := range spec.Tmpfs {
+			target, options, _ := strings.Cut(entry, ":")
+			hostConfig.Tmpfs[target] = options
+		}
+	}
+
+	if spec.RestartPolicy != "" {
+		name, count, _ := strings.Cut(spec.RestartPolicy, ":")
+		hostConfig.RestartPolicy = containertypes.RestartPolicy{Name: containertypes.RestartPolicyMode(name)}
+		if count != "" {
+			retries, err := strconv.Atoi(count)
+			if err != nil {
+				return nil, fmt.Errorf("invalid restart policy %q", spec.RestartPolicy)
+			}
+			hostConfig.RestartPolicy.MaximumRetryCount = retries
+		}
+	}
+
+	if spec.Healthcheck != nil {
+		health, err := healthConfig(spec.Healthcheck)
+		if err != nil {
+			return nil, err
+		}
+		config.Healthcheck = health
+	}
+
+	var networking *network.NetworkingConfig
+	if len(spec.Networks) > 0 {
+		hostConfig.NetworkMode = containertypes.NetworkMode(spec.Networks[0].Name)
+		networking = &network.NetworkingConfig{EndpointsConfig: make(map[string]*network.EndpointSettings, len(spec.Networks))}
+		for _, attachment := range spec.Networks {
+			networking.EndpointsConfig[attachment.Name] = &network.EndpointSettings{Aliases: attachment.Aliases}
+		}
+	}
+
+	res, err := d.client.ContainerCreate(ctx, dockerclient.ContainerCreateOptions{
+		Name:             spec.Name,
+		Config:           config,
+		HostConfig:       hostConfig,
+		NetworkingConfig: networking,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func exposedPort(port string) string {
+	if strings.Contains(port, "/") {
+		return port
+	}
+	return port + "/tcp"
+}
+
+func healthConfig(spec *domain.HealthcheckSpec) (*containertypes.HealthConfig, error) {
+	if spec.Disable {
+		return &containertypes.HealthConfig{Test: []string{"NONE"}}, nil
+	}
+	health := &containertypes.HealthConfig{Test: spec.Test, Retries: spec.Retries}
+	durations := []struct {
+		value  string
+		target *time.Duration
+	}{
+		{spec.Interval, &health.Interval},
+		{spec.Timeout, &health.Timeout},
+		{spec.StartPeriod, &health.StartPeriod},
+	}
+	for _, d := range durations {
+		if d.value == "" {
+			continue
+		}
+		parsed, err := time.ParseDuration(d.value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid healthcheck duration %q: %w", d.value, err)
+		}
+		*d.target = parsed
+	}
+	return health, nil
+}
+
+func (d *Daemon) CreateContainerFrom(ctx context.Context, source dockerclient.ContainerInspectResult, overrides *domain.ContainerOverrides) (*dockerclient.ContainerCreateResult, error) {
+	src := source.Container
+	if src.Config == nil || src.HostConfig == nil {
+		return nil, fmt.Errorf("container %s has no configuration to copy", src.ID)
+	}
+
+	config := *src.Config
+	config.Labels = make(map[string]string, len(src.Config.Labels)+len(overrides.Labels))
+	for k, v := range src.Config.Labels {
+		config.Labels[k] = v
+	}
+	for k, v := range overrides.Labels {
+		config.Labels[k] = v
+	}
+	for _, k := range overrides.RemoveLabels {
+		delete(config.Labels, k)
+	}
+	if overrides.Image != "" {
+		config.Image = overrides.Image
+	}
+	if len(src.ID) >= 12 && config.Hostname == src.ID[:12] {
+		config.Hostname = ""
+	}
+
+	hostConfig := *src.HostConfig
+	hostConfig.PortBindings = make(network.PortMap, len(src.HostConfig.PortBindings))
+	for port, bindings := range src.HostConfig.PortBindings {
+		copied := make([]network.PortBinding, len(bindings))
+		copy(copied, bindings)
+		if hostPort, ok := overrides.HostPorts[port.String()]; ok {
+			for i := range copied {
+				copied[i].HostPort = hostPort
+			}
+		}
+		hostConfig.PortBindings[port] = copied
+	}
+
+	if len(overrides.Volumes) > 0 {
+		hostConfig.Binds = make([]string, len(src.HostConfig.Binds))
+		for i, bind := range src.HostConfig.Binds {
+			source, rest, _ := strings.Cut(bind, ":")
+			if renamed, ok := overrides.Volumes[source]; ok {
+				bind = renamed + ":" + rest
+			}
+			hostConfig.Binds[i] = bind
+		}
+		hostConfig.Mounts = make([]mount.Mount, len(src.HostConfig.Mounts))
+		for i, m := range src.HostConfig.Mounts {
+			if renamed, ok := overrides.Volumes[m.Source]; ok && m.Type == mount.TypeVolume {
+				m.Source = renamed
+			}
+			hostConfig.Mounts[i] = m
+		}
+	}
+
+	if overrides.KeepAnonymousVolumes {
+		mounted := make(map[string]bool)
+		for _, bind := range hostConfig.Binds {
+			parts := strings.Split(bind, ":")
+			if len(parts) > 1 {
+				mounted[parts[1]] = true
+			}
+		}
+		for _, m := range hostConfig.Mounts {
+			mounted[m.Target] = true
+		}
+		mounts := append([]mount.Mount(nil), hostConfig.Mounts...)
+		for _, m := range src.Mounts {
+			if m.Type == mount.TypeVolume && m.Name != "" && !mounted[m.Destination] {
+				mounts = append(mounts, mount.Mount{Type: mount.TypeVolume, Source: m.Name, Target: m.Destination})
+			}
+		}
+		hostConfig.Mounts = mounts
+	}
+
+	var networking *network.NetworkingConfig
+	if src.NetworkSettings != nil && len(src.NetworkSettings.Networks) > 0 {
+		networking = &network.NetworkingConfig{EndpointsConfig: make(map[string]*network.EndpointSettings, len(src.NetworkSettings.Networks))}
+		for name, endpoint := range src.NetworkSettings.Networks {
+			settings := &network.EndpointSettings{}
+			if endpoint != nil && !overrides.ResetAliases {
+				settings.Aliases = endpoint.Aliases
+			}
+			networking.EndpointsConfig[name] = settings
+		}
+	}
+
+	res, err := d.client.ContainerCreate(ctx, dockerclient.ContainerCreateOptions{
+		Name:             overrides.Name,
+		Config:           &config,
+		HostConfig:       &hostConfig,
+		NetworkingConfig: networking,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
@@ -0,0 +1,33 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+	containertypes "github.com/moby/moby/api/types/container"
+	dockerclient "github.com/moby/moby/client"
+)
+
+// ContainerStats takes a single usage sample of a running container.
+func (d *Daemon) ContainerStats(ctx context.Context, id string) (*domain.ContainerStats, error) {
+	res, err := d.client.ContainerStats(ctx, id, dockerclient.ContainerStatsOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	var raw containertypes.StatsResponse
+	if err := json.NewDecoder(res.Body).Decode(&raw); err != nil {
+		return nil, err
+	}
+	stats := &domain.ContainerStats{
+		CPUTotal:   raw.CPUStats.CPUUsage.TotalUsage,
+		SystemCPU:  raw.CPUStats.SystemUsage,
+		OnlineCPUs: raw.CPUStats.OnlineCPUs,
+	}
+	for _, network := range raw.Networks {
+		stats.NetworkBytes += network.RxBytes + network.TxBytes
+	}
+	return stats, nil
+}
@@ -0,0 +1,26 @@
+package docker
+
+import (
+	"context"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+	dockerclient "github.com/moby/moby/client"
+)
+
+func (d *Daemon) ListVolumes(ctx context.Context) (dockerclient.VolumeListResult, error) {
+	return d.client.VolumeList(ctx, dockerclient.VolumeListOptions{})
+}
+
+func (d *Daemon) CreateVolume(ctx context.Context, spec *domain.VolumeSpec) error {
+	_, err := d.client.VolumeCreate(ctx, dockerclient.VolumeCreateOptions{
+		Name:   spec.Name,
+		Driver: spec.Driver,
+		Labels: spec.Labels,
+	})
+	return err
+}
+
+func (d *Daemon) RemoveVolume(ctx context.Context, name string) error {
+	_, err := d.client.VolumeRemove(ctx, name, dockerclient.VolumeRemoveOptions{})
+	return err
+}
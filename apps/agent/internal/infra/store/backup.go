@@ -0,0 +1,167 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+)
+
+// BackupStore keeps dump files and their metadata side by side in the backup
+// directory, and backup policies under the data directory.
+type BackupStore struct {
+	backups  *fileStore
+	policies *fileStore
+}
+
+func NewBackupStore(backupsDir, dataDir string) (*BackupStore, error) {
+	backups, err := newFileStore(backupsDir)
+	if err != nil {
+		return nil, err
+	}
+	policies, err := newFileStore(filepath.Join(dataDir, "backup-policies"))
+	if err != nil {
+		return nil, err
+	}
+	return &BackupStore{backups: backups, policies: policies}, nil
+}
+
+// CreateBackup streams content into backup.File, a name relative to the
+// backup directory, and records the metadata once the dump is complete.
+func (s *BackupStore) CreateBackup(ctx context.Context, backup *domain.Backup, content io.Reader) error {
+	target := filepath.Join(s.backups.dir, filepath.Base(backup.File))
+	tmp, err := os.CreateTemp(s.backups.dir, backup.ID+"-*.partial")
+	if err != nil {
+		return err
+	}
+	size, err := io.Copy(tmp, content)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), target); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	backup.File = target
+	backup.SizeBytes = size
+
+	s.backups.mu.Lock()
+	defer s.backups.mu.Unlock()
+	if err := s.backups.write(backup.ID, backup); err != nil {
+		os.Remove(target)
+		return err
+	}
+	return nil
+}
+
+func (s *BackupStore) ListBackups(ctx context.Context) ([]domain.Backup, error) {
+	s.backups.mu.Lock()
+	defer s.backups.mu.Unlock()
+
+	keys, err := s.backups.keys()
+	if err != nil {
+		return nil, err
+	}
+	backups := make([]domain.Backup, 0, len(keys))
+	for _, key := range keys {
+		var backup domain.Backup
+		if _, err := s.backups.read(key, &backup); err != nil {
+			return nil, err
+		}
+		backups = append(backups, backup)
+	}
+	sort.Slice(backups, func(i, j int) bool { return backups[i].CreatedAt > backups[j].CreatedAt })
+	return backups, nil
+}
+
+func (s *BackupStore) GetBackup(ctx context.Context, id string) (*domain.Backup, error) {
+	s.backups.mu.Lock()
+	defer s.backups.mu.Unlock()
+
+	var backup domain.Backup
+	found, err := s.backups.read(filepath.Base(id), &backup)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &backup, nil
+}
+
+func (s *BackupStore) OpenBackup(ctx context.Context, id string) (io.ReadCloser, error) {
+	backup, err := s.GetBackup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if backup == nil {
+		return nil, fmt.Errorf("backup %s not found", id)
+	}
+	return os.Open(backup.File)
+}
+
+func (s *BackupStore) DeleteBackup(ctx context.Context, id string) error {
+	backup, err := s.GetBackup(ctx, id)
+	if err != nil || backup == nil {
+		return err
+	}
+
+	s.backups.mu.Lock()
+	defer s.backups.mu.Unlock()
+	if err := os.Remove(backup.File); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return s.backups.remove(backup.ID)
+}
+
+func (s *BackupStore) ListBackupPolicies(ctx context.Context) ([]domain.BackupPolicy, error) {
+	s.policies.mu.Lock()
+	defer s.policies.mu.Unlock()
+
+	keys, err := s.policies.keys()
+	if err != nil {
+		return nil, err
+	}
+	policies := make([]domain.BackupPolicy, 0, len(keys))
+	for _, key := range keys {
+		var policy domain.BackupPolicy
+		if _, err := s.policies.read(key, &policy); err != nil {
+			return nil, err
+		}
+		policies = append(policies, policy)
+	}
+	sort.Slice(policies, func(i, j int) bool { return policies[i].Resource < policies[j].Resource })
+	return policies, nil
+}
+
+func (s *BackupStore) GetBackupPolicy(ctx context.Context, resource string) (*domain.BackupPolicy, error) {
+	s.policies.mu.Lock()
+	defer s.policies.mu.Unlock()
+
+	var policy domain.BackupPolicy
+	found, err := s.policies.read(filepath.Base(resource), &policy)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+func (s *BackupStore) SaveBackupPolicy(ctx context.Context, policy *domain.BackupPolicy) error {
+	s.policies.mu.Lock()
+	defer s.policies.mu.Unlock()
+
+	return s.policies.write(filepath.Base(policy.Resource), policy)
+}
+
+func (s *BackupStore) DeleteBackupPolicy(ctx context.Context, resource string) error {
+	s.policies.mu.Lock()
+	defer s.policies.mu.Unlock()
+
+	return s.policies.remove(filepath.Base(resource))
+}
@@ -0,0 +1,58 @@
+package store
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+)
+
+type GCStore struct {
+	gc *fileStore
+}
+
+func NewGCStore(dataDir string) (*GCStore, error) {
+	gc, err := newFileStore(filepath.Join(dataDir, "gc"))
+	if err != nil {
+		return nil, err
+	}
+	return &GCStore{gc: gc}, nil
+}
+
+func (s *GCStore) GetGCConfig(ctx context.Context) (*domain.GCConfig, error) {
+	s.gc.mu.Lock()
+	defer s.gc.mu.Unlock()
+
+	config := &domain.GCConfig{}
+	if _, err := s.gc.read("config", config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+func (s *GCStore) SaveGCConfig(ctx context.Context, config *domain.GCConfig) error {
+	s.gc.mu.Lock()
+	defer s.gc.mu.Unlock()
+
+	return s.gc.write("config", config)
+}
+
+// GetArtifactUsage returns when each artifact was last seen in use, keyed by
+// kind and ID.
+func (s *GCStore) GetArtifactUsage(ctx context.Context) (map[string]int64, error) {
+	s.gc.mu.Lock()
+	defer s.gc.mu.Unlock()
+
+	usage := make(map[string]int64)
+	if _, err := s.gc.read("usage", &usage); err != nil {
+		return nil, err
+	}
+	return usage, nil
+}
+
+func (s *GCStore) SaveArtifactUsage(ctx context.Context, usage map[string]int64) error {
+	s.gc.mu.Lock()
+	defer s.gc.mu.Unlock()
+
+	return s.gc.write("usage", usage)
+}
@@ -0,0 +1,67 @@
+package store
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+)
+
+type LifecycleStore struct {
+	lifecycles *fileStore
+}
+
+func NewLifecycleStore(dataDir string) (*LifecycleStore, error) {
+	lifecycles, err := newFileStore(filepath.Join(dataDir, "lifecycle"))
+	if err != nil {
+		return nil, err
+	}
+	return &LifecycleStore{lifecycles: lifecycles}, nil
+}
+
+func (s *LifecycleStore) ListLifecycles(ctx context.Context) ([]domain.Lifecycle, error) {
+	s.lifecycles.mu.Lock()
+	defer s.lifecycles.mu.Unlock()
+
+	keys, err := s.lifecycles.keys()
+	if err != nil {
+		return nil, err
+	}
+	lifecycles := make([]domain.Lifecycle, 0, len(keys))
+	for _, key := range keys {
+		var lifecycle domain.Lifecycle
+		if _, err := s.lifecycles.read(key, &lifecycle); err != nil {
+			return nil, err
+		}
+		lifecycles = append(lifecycles, lifecycle)
+	}
+	sort.Slice(lifecycles, func(i, j int) bool { return lifecycles[i].Resource < lifecycles[j].Resource })
+	return lifecycles, nil
+}
+
+func (s *LifecycleStore) GetLifecycle(ctx context.Context, resource string) (*domain.Lifecycle, error) {
+	s.lifecycles.mu.Lock()
+	defer s.lifecycles.mu.Unlock()
+
+	var lifecycle domain.Lifecycle
+	found, err := s.lifecycles.read(filepath.Base(resource), &lifecycle)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &lifecycle, nil
+}
+
+func (s *LifecycleStore) SaveLifecycle(ctx context.Context, lifecycle *domain.Lifecycle) error {
+	s.lifecycles.mu.Lock()
+	defer s.lifecycles.mu.Unlock()
+
+	return s.lifecycles.write(filepath.Base(lifecycle.Resource), lifecycle)
+}
+
+func (s *LifecycleStore) DeleteLifecycle(ctx context.Context, resource string) error {
+	s.lifecycles.mu.Lock()
+	defer s.lifecycles.mu.Unlock()
+
+	return s.lifecycles.remove(filepath.Base(resource))
+}
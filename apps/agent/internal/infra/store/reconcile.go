@@ -0,0 +1,67 @@
+package store
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+)
+
+type DesiredStateStore struct {
+	states *fileStore
+}
+
+func NewDesiredStateStore(dataDir string) (*DesiredStateStore, error) {
+	states, err := newFileStore(filepath.Join(dataDir, "desired-state"))
+	if err != nil {
+		return nil, err
+	}
+	return &DesiredStateStore{states: states}, nil
+}
+
+func (s *DesiredStateStore) ListDesiredStates(ctx context.Context) ([]domain.DesiredState, error) {
+	s.states.mu.Lock()
+	defer s.states.mu.Unlock()
+
+	keys, err := s.states.keys()
+	if err != nil {
+		return nil, err
+	}
+	states := make([]domain.DesiredState, 0, len(keys))
+	for _, key := range keys {
+		var state domain.DesiredState
+		if _, err := s.states.read(key, &state); err != nil {
+			return nil, err
+		}
+		states = append(states, state)
+	}
+	sort.Slice(states, func(i, j int) bool { return states[i].Resource < states[j].Resource })
+	return states, nil
+}
+
+func (s *DesiredStateStore) GetDesiredState(ctx context.Context, resource string) (*domain.DesiredState, error) {
+	s.states.mu.Lock()
+	defer s.states.mu.Unlock()
+
+	var state domain.DesiredState
+	found, err := s.states.read(filepath.Base(resource), &state)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &state, nil
+}
+
+func (s *DesiredStateStore) SaveDesiredState(ctx context.Context, state *domain.DesiredState) error {
+	s.states.mu.Lock()
+	defer s.states.mu.Unlock()
+
+	return s.states.write(filepath.Base(state.Resource), state)
+}
+
+func (s *DesiredStateStore) DeleteDesiredState(ctx context.Context, resource string) error {
+	s.states.mu.Lock()
+	defer s.states.mu.Unlock()
+
+	return s.states.remove(filepath.Base(resource))
+}
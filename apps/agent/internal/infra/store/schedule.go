@@ -0,0 +1,108 @@
+package store
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+)
+
+// ScheduleStore keeps schedules and, separately, the run history of each
+// one, newest run first.
+type ScheduleStore struct {
+	schedules *fileStore
+	runs      *fileStore
+}
+
+func NewScheduleStore(dataDir string) (*ScheduleStore, error) {
+	schedules, err := newFileStore(filepath.Join(dataDir, "schedules"))
+	if err != nil {
+		return nil, err
+	}
+	runs, err := newFileStore(filepath.Join(dataDir, "schedule-runs"))
+	if err != nil {
+		return nil, err
+	}
+	return &ScheduleStore{schedules: schedules, runs: runs}, nil
+}
+
+func (s *ScheduleStore) ListSchedules(ctx context.Context) ([]domain.Schedule, error) {
+	s.schedules.mu.Lock()
+	defer s.schedules.mu.Unlock()
+
+	keys, err := s.schedules.keys()
+	if err != nil {
+		return nil, err
+	}
+	schedules := make([]domain.Schedule, 0, len(keys))
+	for _, key := range keys {
+		var schedule domain.Schedule
+		if _, err := s.schedules.read(key, &schedule); err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, schedule)
+	}
+	sort.Slice(schedules, func(i, j int) bool { return schedules[i].Name < schedules[j].Name })
+	return schedules, nil
+}
+
+func (s *ScheduleStore) GetSchedule(ctx context.Context, name string) (*domain.Schedule, error) {
+	s.schedules.mu.Lock()
+	defer s.schedules.mu.Unlock()
+
+	var schedule domain.Schedule
+	found, err := s.schedules.read(filepath.Base(name), &schedule)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+func (s *ScheduleStore) SaveSchedule(ctx context.Context, schedule *domain.Schedule) error {
+	s.schedules.mu.Lock()
+	defer s.schedules.mu.Unlock()
+
+	return s.schedules.write(filepath.Base(schedule.Name), schedule)
+}
+
+func (s *ScheduleStore) DeleteSchedule(ctx context.Context, name string) error {
+	s.schedules.mu.Lock()
+	err := s.schedules.remove(filepath.Base(name))
+	s.schedules.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	s.runs.mu.Lock()
+	defer s.runs.mu.Unlock()
+	return s.runs.remove(filepath.Base(name))
+}
+
+// AddScheduleRun records a run and drops the oldest runs beyond keep.
+func (s *ScheduleStore) AddScheduleRun(ctx context.Context, run domain.ScheduleRun, keep int) error {
+	s.runs.mu.Lock()
+	defer s.runs.mu.Unlock()
+
+	key := filepath.Base(run.Schedule)
+	var runs []domain.ScheduleRun
+	if _, err := s.runs.read(key, &runs); err != nil {
+		return err
+	}
+	runs = append([]domain.ScheduleRun{run}, runs...)
+	if keep > 0 && len(runs) > keep {
+		runs = runs[:keep]
+	}
+	return s.runs.write(key, runs)
+}
+
+func (s *ScheduleStore) ListScheduleRuns(ctx context.Context, name string) ([]domain.ScheduleRun, error) {
+	s.runs.mu.Lock()
+	defer s.runs.mu.Unlock()
+
+	runs := make([]domain.ScheduleRun, 0)
+	if _, err := s.runs.read(filepath.Base(name), &runs); err != nil {
+		return nil, err
+	}
+	return runs, nil
+}
@@ -0,0 +1,58 @@
+package store
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+)
+
+type StackStore struct {
+	files *fileStore
+}
+
+func NewStackStore(dataDir string) (*StackStore, error) {
+	files, err := newFileStore(filepath.Join(dataDir, "stacks"))
+	if err != nil {
+		return nil, err
+	}
+	return &StackStore{files: files}, nil
+}
+
+func (s *StackStore) GetStack(ctx context.Context, project string) (*domain.StackDefinition, error) {
+	s.files.mu.Lock()
+	defer s.files.mu.Unlock()
+
+	var stack domain.StackDefinition
+	found, err := s.files.read(project, &stack)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &stack, nil
+}
+
+func (s *StackStore) ListStacks(ctx context.Context) ([]domain.StackDefinition, error) {
+	s.files.mu.Lock()
+	defer s.files.mu.Unlock()
+
+	keys, err := s.files.keys()
+	if err != nil {
+		return nil, err
+	}
+	stacks := make([]domain.StackDefinition, 0, len(keys))
+	for _, key := range keys {
+		var stack domain.StackDefinition
+		if _, err := s.files.read(key, &stack); err != nil {
+			return nil, err
+		}
+		stacks = append(stacks, stack)
+	}
+	return stacks, nil
+}
+
+func (s *StackStore) SaveStack(ctx context.Context, stack *domain.StackDefinition) error {
+	s.files.mu.Lock()
+	defer s.files.mu.Unlock()
+
+	return s.files.write(stack.Project, stack)
+}
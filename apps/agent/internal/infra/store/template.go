@@ -0,0 +1,58 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+	"github.com/goccy/go-yaml"
+)
+
+// TemplateStore reads custom templates from YAML or JSON files in a
+// directory, one template per file. Files are read on every call so edits
+// show up without restarting the agent.
+type TemplateStore struct {
+	dir string
+}
+
+func NewTemplateStore(dir string) *TemplateStore {
+	return &TemplateStore{dir: dir}
+}
+
+func (s *TemplateStore) ListTemplates(ctx context.Context) ([]domain.Template, error) {
+	entries, err := os.ReadDir(s.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	templates := make([]domain.Template, 0, len(entries))
+	for _, entry := range entries {
+		switch filepath.Ext(entry.Name()) {
+		case ".yaml", ".yml", ".json":
+		default:
+			continue
+		}
+		if entry.IsDir() {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(s.dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		var t domain.Template
+		if err := yaml.Unmarshal(content, &t); err != nil {
+			return nil, fmt.Errorf("template %s: %w", entry.Name(), err)
+		}
+		t.Builtin = false
+		templates = append(templates, t)
+	}
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+	return templates, nil
+}
@@ -0,0 +1,68 @@
+package store
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+)
+
+type TrashStore struct {
+	entries *fileStore
+}
+
+func NewTrashStore(dataDir string) (*TrashStore, error) {
+	entries, err := newFileStore(filepath.Join(dataDir, "trash"))
+	if err != nil {
+		return nil, err
+	}
+	return &TrashStore{entries: entries}, nil
+}
+
+// ListTrash returns the most recently deleted resources first.
+func (s *TrashStore) ListTrash(ctx context.Context) ([]domain.TrashEntry, error) {
+	s.entries.mu.Lock()
+	defer s.entries.mu.Unlock()
+
+	keys, err := s.entries.keys()
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]domain.TrashEntry, 0, len(keys))
+	for _, key := range keys {
+		var entry domain.TrashEntry
+		if _, err := s.entries.read(key, &entry); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].DeletedAt > entries[j].DeletedAt })
+	return entries, nil
+}
+
+func (s *TrashStore) GetTrash(ctx context.Context, resource string) (*domain.TrashEntry, error) {
+	s.entries.mu.Lock()
+	defer s.entries.mu.Unlock()
+
+	var entry domain.TrashEntry
+	found, err := s.entries.read(filepath.Base(resource), &entry)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (s *TrashStore) SaveTrash(ctx context.Context, entry *domain.TrashEntry) error {
+	s.entries.mu.Lock()
+	defer s.entries.mu.Unlock()
+
+	return s.entries.write(filepath.Base(entry.Resource), entry)
+}
+
+func (s *TrashStore) DeleteTrash(ctx context.Context, resource string) error {
+	s.entries.mu.Lock()
+	defer s.entries.mu.Unlock()
+
+	return s.entries.remove(filepath.Base(resource))
+}
@@ -0,0 +1,168 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/app"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+	"github.com/spf13/cobra"
+)
+
+func NewBackupCmd(containerApp *app.ContainerApp) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backup",
+		Short: "Back up and restore database resources",
+	}
+
+	var req domain.BackupRequest
+	create := &cobra.Command{
+		Use:   "create <resource>",
+		Short: "Take a backup now",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			b, err := containerApp.CreateBackup(ctx, args[0], req)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Backup %s written to %s (%s)\n", b.ID, b.File, formatBytes(b.SizeBytes))
+			return nil
+		},
+	}
+	create.Flags().StringVarP(&req.Database, "database", "d", "", "Database to dump instead of the configured one")
+	cmd.AddCommand(create)
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "list [resource]",
+		Short: "List backups",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resource := ""
+			if len(args) == 1 {
+				resource = args[0]
+			}
+			ctx := context.Background()
+			backups, err := containerApp.ListBackups(ctx, resource)
+			if err != nil {
+				return err
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "ID\tRESOURCE\tFORMAT\tDATABASE\tSIZE\tTRIGGER\tCREATED")
+			for _, b := range backups {
+				created := time.Unix(b.CreatedAt, 0).Format(time.DateTime)
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", b.ID, b.Resource, b.Format, b.Database, formatBytes(b.SizeBytes), b.Trigger, created)
+			}
+			return w.Flush()
+		},
+	})
+
+	var restore domain.RestoreRequest
+	restoreCmd := &cobra.Command{
+		Use:   "restore <backup-id>",
+		Short: "Restore a backup into its resource or another one",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			result, err := containerApp.RestoreBackup(ctx, args[0], restore)
+			if err != nil {
+				return err
+			}
+			if result.Database != "" {
+				fmt.Printf("Backup %s restored into %s (database %s)\n", result.Backup, result.Target, result.Database)
+			} else {
+				fmt.Printf("Backup %s restored into %s\n", result.Backup, result.Target)
+			}
+			return nil
+		},
+	}
+	restoreCmd.Flags().StringVar(&restore.Target, "to", "", "Resource to restore into")
+	restoreCmd.Flags().StringVarP(&restore.Database, "database", "d", "", "Database to restore into")
+	cmd.AddCommand(restoreCmd)
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "delete <backup-id>",
+		Short: "Delete a backup",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			if err := containerApp.DeleteBackup(ctx, args[0]); err != nil {
+				return err
+			}
+			fmt.Printf("Backup %s deleted\n", args[0])
+			return nil
+		},
+	})
+
+	var policy domain.BackupPolicy
+	schedule := &cobra.Command{
+		Use:   "schedule <resource>",
+		Short: "Back up a resource on a cron schedule",
+		Long:  "Back up a resource on a cron schedule. Schedules run while the devcon server is running.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			saved, err := containerApp.SetBackupPolicy(ctx, args[0], policy)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Backups of %s scheduled at %q\n", saved.Resource, saved.Schedule)
+			return nil
+		},
+	}
+	schedule.Flags().StringVar(&policy.Schedule, "cron", "", "Cron expression, e.g. \"0 3 * * *\" or \"@daily\"")
+	schedule.Flags().StringVarP(&policy.Database, "database", "d", "", "Database to dump")
+	schedule.Flags().IntVar(&policy.Retention.KeepLast, "keep-last", 0, "Keep only the newest N backups")
+	schedule.Flags().IntVar(&policy.Retention.MaxAgeDays, "max-age-days", 0, "Delete backups older than N days")
+	schedule.Flags().BoolVar(&policy.Disabled, "disabled", false, "Save the policy without running it")
+	schedule.MarkFlagRequired("cron")
+	cmd.AddCommand(schedule)
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "unschedule <resource>",
+		Short: "Remove a resource's backup schedule",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			if err := containerApp.DeleteBackupPolicy(ctx, args[0]); err != nil {
+				return err
+			}
+			fmt.Printf("Backup schedule of %s removed\n", args[0])
+			return nil
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "policies",
+		Short: "List backup schedules",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			policies, err := containerApp.ListBackupPolicies(ctx)
+			if err != nil {
+				return err
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "RESOURCE\tSCHEDULE\tKEEP LAST\tMAX AGE\tLAST RUN\tSTATUS")
+			for _, p := range policies {
+				lastRun, status := "-", "ok"
+				if p.LastRunAt > 0 {
+					lastRun = time.Unix(p.LastRunAt, 0).Format(time.DateTime)
+				}
+				if p.LastError != "" {
+					status = p.LastError
+				}
+				if p.Disabled {
+					status = "disabled"
+				}
+				fmt.Fprintf(w, "%s\t%s\t%d\t%dd\t%s\t%s\n", p.Resource, p.Schedule, p.Retention.KeepLast, p.Retention.MaxAgeDays, lastRun, status)
+			}
+			return w.Flush()
+		},
+	})
+
+	return cmd
+}
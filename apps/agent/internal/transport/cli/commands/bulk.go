@@ -0,0 +1,66 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/app"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/bulk"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+	"github.com/spf13/cobra"
+)
+
+func NewBulkCmd(containerApp *app.ContainerApp) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bulk",
+		Short: "Start, stop, restart or delete many resources at once",
+	}
+	for _, action := range []string{domain.BulkStart, domain.BulkStop, domain.BulkRestart, domain.BulkDelete} {
+		cmd.AddCommand(newBulkActionCmd(containerApp, action))
+	}
+	return cmd
+}
+
+func newBulkActionCmd(containerApp *app.ContainerApp, action string) *cobra.Command {
+	var req domain.BulkRequest
+
+	cmd := &cobra.Command{
+		Use:   action + " [id or name...]",
+		Short: fmt.Sprintf("Bulk %s the given resources, or those matching --type and --selector", action),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req.IDs = args
+			ctx := context.Background()
+			report, err := containerApp.Bulk(ctx, action, req)
+			if err != nil {
+				return err
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "NAME\tRESULT\tDURATION")
+			for _, r := range report.Results {
+				result := "ok"
+				switch {
+				case r.Error != "":
+					result = "failed: " + r.Error
+				case report.DryRun:
+					result = "would " + action
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\n", r.Name, result, time.Duration(r.DurationMs)*time.Millisecond)
+			}
+			if err := w.Flush(); err != nil {
+				return err
+			}
+			if report.Failed > 0 {
+				return fmt.Errorf("%d of %d resources failed", report.Failed, len(report.Results))
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&req.Type, "type", "", "Only resources of this type, e.g. postgres")
+	cmd.Flags().StringVarP(&req.Selector, "selector", "l", "", "Label selector, e.g. env=dev,!devcon.protected")
+	cmd.Flags().IntVar(&req.Concurrency, "concurrency", bulk.DefaultConcurrency, fmt.Sprintf("Resources acted on at once (max %d)", bulk.MaxConcurrency))
+	cmd.Flags().BoolVar(&req.DryRun, "dry-run", false, "Only list the selected resources")
+	return cmd
+}
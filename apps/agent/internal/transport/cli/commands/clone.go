@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/app"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+	"github.com/spf13/cobra"
+)
+
+func NewCloneCmd(containerApp *app.ContainerApp) *cobra.Command {
+	var copyData bool
+	var noStart bool
+
+	cmd := &cobra.Command{
+		Use:   "clone <source> <name>",
+		Short: "Copy a resource under a new name",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			result, err := containerApp.CloneResource(ctx, args[0], domain.CloneRequest{
+				Name:     args[1],
+				CopyData: copyData,
+				NoStart:  noStart,
+			})
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Cloned %s to %s (%s)\n", result.Source, result.Resource.Name, result.Resource.State)
+			switch result.Data {
+			case "volumes":
+				fmt.Println("Data: copied volumes")
+			case "commit":
+				fmt.Printf("Data: committed filesystem as %s\n", result.Image)
+			}
+			for _, port := range sortedKeys(result.Ports) {
+				fmt.Printf("Port: %s -> %s\n", port, result.Ports[port])
+			}
+			for _, target := range sortedKeys(result.Volumes) {
+				fmt.Printf("Volume: %s -> %s\n", result.Volumes[target], target)
+			}
+			for _, warning := range result.Warnings {
+				fmt.Printf("Warning: %s\n", warning)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&copyData, "copy-data", false, "Copy volume contents, or commit the filesystem when there are no volumes")
+	cmd.Flags().BoolVar(&noStart, "no-start", false, "Create the clone without starting it")
+	return cmd
+}
@@ -0,0 +1,94 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/app"
+	"github.com/spf13/cobra"
+)
+
+func NewDriftCmd(containerApp *app.ContainerApp) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "drift",
+		Short: "Inspect and correct drift from the desired resource state",
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "list",
+		Short: "List tracked resources and their last reconcile",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			states, err := containerApp.ListDesiredStates(ctx)
+			if err != nil {
+				return err
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "RESOURCE\tDESIRED\tPOLICY\tCHECKED\tACTION\tDRIFT")
+			for _, s := range states {
+				checked, action, drift := "-", "-", ""
+				if r := s.LastReconcile; r != nil {
+					checked = time.Unix(r.CheckedAt, 0).Format(time.DateTime)
+					action = r.Action
+					drift = strings.Join(r.Drift, "; ")
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", s.Resource, s.State, s.Policy, checked, action, drift)
+			}
+			return w.Flush()
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "check [resource]",
+		Short: "Reconcile now, one resource or all of them",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resource := ""
+			if len(args) == 1 {
+				resource = args[0]
+			}
+			ctx := context.Background()
+			results, err := containerApp.Reconcile(ctx, resource)
+			if err != nil {
+				return err
+			}
+			for _, r := range results {
+				switch {
+				case r.Action == "ignored":
+					fmt.Printf("%s: ignored\n", r.Resource)
+					continue
+				case len(r.Drift) == 0:
+					fmt.Printf("%s: in sync\n", r.Resource)
+					continue
+				}
+				line := fmt.Sprintf("%s: %s (%s)", r.Resource, strings.Join(r.Drift, "; "), r.Action)
+				if r.Error != "" {
+					line += ": " + r.Error
+				}
+				fmt.Println(line)
+			}
+			return nil
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "policy <resource> <auto_fix|alert|ignore>",
+		Short: "Set how drift of a resource is handled",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			state, err := containerApp.SetDriftPolicy(ctx, args[0], args[1])
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Drift policy for %s set to %s\n", state.Resource, state.Policy)
+			return nil
+		},
+	})
+	return cmd
+}
@@ -0,0 +1,136 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/app"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+	"github.com/spf13/cobra"
+)
+
+func NewGCCmd(containerApp *app.ContainerApp) *cobra.Command {
+	var req domain.GCRequest
+	var volumes bool
+
+	cmd := &cobra.Command{
+		Use:   "gc",
+		Short: "Remove devcon containers, images, networks and volumes unused for too long",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cmd.Flags().Changed("volumes") {
+				req.Volumes = &volumes
+			}
+			ctx := context.Background()
+			report, err := containerApp.RunGC(ctx, req)
+			if err != nil {
+				return err
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "KIND\tNAME\tSIZE\tUNUSED SINCE\tRESULT")
+			for _, candidate := range report.Candidates {
+				result := "would remove"
+				if candidate.Error != "" {
+					result = "failed: " + candidate.Error
+				} else if candidate.Removed {
+					result = "removed"
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", candidate.Kind, candidate.Name, formatBytes(candidate.SizeBytes), time.Unix(candidate.UnusedSince, 0).Format(time.DateTime), result)
+			}
+			if err := w.Flush(); err != nil {
+				return err
+			}
+			if report.DryRun {
+				fmt.Printf("%d artifacts unused for %s, %s reclaimable\n", len(report.Candidates), report.MaxAge, formatBytes(report.ReclaimableBytes))
+				return nil
+			}
+			fmt.Printf("Reclaimed %s\n", formatBytes(report.ReclaimedBytes))
+			if report.Errors > 0 {
+				return fmt.Errorf("%d artifacts could not be removed", report.Errors)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&req.DryRun, "dry-run", false, "Only report what would be removed")
+	cmd.Flags().StringVar(&req.MaxAge, "max-age", "", "How long an artifact must be unused, e.g. 72h (defaults to the configured age)")
+	cmd.Flags().BoolVar(&volumes, "volumes", false, "Also remove unused named volumes")
+
+	cmd.AddCommand(newGCConfigCmd(containerApp))
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "protect <name>",
+		Short: "Never collect a resource, stack, workspace, volume, network or image",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			if _, err := containerApp.ProtectArtifact(ctx, args[0]); err != nil {
+				return err
+			}
+			fmt.Printf("Protected %s\n", args[0])
+			return nil
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "unprotect <name>",
+		Short: "Let garbage collection consider a name again",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			if _, err := containerApp.UnprotectArtifact(ctx, args[0]); err != nil {
+				return err
+			}
+			fmt.Printf("Unprotected %s\n", args[0])
+			return nil
+		},
+	})
+	return cmd
+}
+
+func newGCConfigCmd(containerApp *app.ContainerApp) *cobra.Command {
+	var req domain.GCConfigRequest
+	var volumes bool
+
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Show or change the max age, schedule and protected names",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cmd.Flags().Changed("volumes") {
+				req.Volumes = &volumes
+			}
+			ctx := context.Background()
+			cfg, err := containerApp.GetGCConfig(ctx)
+			if req.MaxAge != "" || req.Schedule != "" || req.Volumes != nil {
+				cfg, err = containerApp.UpdateGCConfig(ctx, req)
+			}
+			if err != nil {
+				return err
+			}
+			schedule, protected, lastRun := "off", "-", "never"
+			if cfg.Schedule != "" {
+				schedule = cfg.Schedule
+			}
+			if len(cfg.Protected) > 0 {
+				protected = strings.Join(cfg.Protected, ", ")
+			}
+			if cfg.LastRun != nil {
+				lastRun = fmt.Sprintf("%s, reclaimed %s", time.Unix(cfg.LastRun.StartedAt, 0).Format(time.DateTime), formatBytes(cfg.LastRun.ReclaimedBytes))
+			}
+			fmt.Printf("Max age:   %s\n", cfg.MaxAge)
+			fmt.Printf("Schedule:  %s\n", schedule)
+			fmt.Printf("Volumes:   %t\n", cfg.Volumes)
+			fmt.Printf("Protected: %s\n", protected)
+			fmt.Printf("Last run:  %s\n", lastRun)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&req.MaxAge, "max-age", "", "Default max age, e.g. 168h")
+	cmd.Flags().StringVar(&req.Schedule, "schedule", "", `Cron expression to collect on, or "off"`)
+	cmd.Flags().BoolVar(&volumes, "volumes", false, "Collect unused named volumes by default")
+	return cmd
+}
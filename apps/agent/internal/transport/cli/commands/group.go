@@ -0,0 +1,163 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/app"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+	"github.com/spf13/cobra"
+)
+
+func NewGroupCmd(containerApp *app.ContainerApp) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "group",
+		Short: "Manage groups of resources and run them together",
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "list",
+		Short: "List groups and their status",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			groups, err := containerApp.ListGroups(ctx)
+			if err != nil {
+				return err
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "GROUP\tSTATUS\tRUNNING\tMEMBERS")
+			for _, g := range groups {
+				names := make([]string, 0, len(g.Members))
+				for _, m := range g.Members {
+					names = append(names, m.Name)
+				}
+				fmt.Fprintf(w, "%s\t%s\t%d/%d\t%s\n", g.Name, g.Status, g.Running, g.Total, strings.Join(names, ","))
+			}
+			return w.Flush()
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "show <group>",
+		Short: "Show the members of a group",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			group, err := containerApp.GetGroup(ctx, args[0])
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Group %s: %s (%d/%d running)\n", group.Name, group.Status, group.Running, group.Total)
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "NAME\tTYPE\tIMAGE\tSTATUS")
+			for _, m := range group.Members {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", m.Name, m.Type, m.Image, m.Status)
+			}
+			return w.Flush()
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "add <group> <resource...>",
+		Short: "Add resources to a group, recreating their containers",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			result, err := containerApp.AddGroupMembers(ctx, args[0], args[1:])
+			if result != nil && (err == nil || len(result.Changed) > 0) {
+				printGroupMembers(result, "Added", "to")
+			}
+			return err
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "remove <group> <resource...>",
+		Short: "Remove resources from a group, recreating their containers",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			result, err := containerApp.RemoveGroupMembers(ctx, args[0], args[1:])
+			if result != nil && (err == nil || len(result.Changed) > 0) {
+				printGroupMembers(result, "Removed", "from")
+			}
+			return err
+		},
+	})
+
+	for _, action := range []string{domain.BulkStart, domain.BulkStop, domain.BulkRestart, domain.BulkDelete} {
+		cmd.AddCommand(newGroupActionCmd(containerApp, action))
+	}
+	cmd.AddCommand(newGroupLogsCmd(containerApp))
+	return cmd
+}
+
+func printGroupMembers(result *domain.GroupMembersResult, verb, preposition string) {
+	if len(result.Changed) == 0 {
+		fmt.Println("Nothing to change")
+		return
+	}
+	fmt.Printf("%s %s %s group %s\n", verb, strings.Join(result.Changed, ", "), preposition, result.Group)
+}
+
+func newGroupActionCmd(containerApp *app.ContainerApp, action string) *cobra.Command {
+	short := fmt.Sprintf("%s every member of a group", strings.ToUpper(action[:1])+action[1:])
+	if action == domain.BulkDelete {
+		short = "Move every member of a group to the trash"
+	}
+	return &cobra.Command{
+		Use:   action + " <group>",
+		Short: short,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			report, err := containerApp.GroupAction(ctx, args[0], action)
+			if err != nil {
+				return err
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "NAME\tRESULT\tDURATION")
+			for _, r := range report.Results {
+				result := "ok"
+				if r.Error != "" {
+					result = "failed: " + r.Error
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\n", r.Name, result, time.Duration(r.DurationMs)*time.Millisecond)
+			}
+			if err := w.Flush(); err != nil {
+				return err
+			}
+			if report.Failed > 0 {
+				return fmt.Errorf("%d of %d resources failed", report.Failed, len(report.Results))
+			}
+			return nil
+		},
+	}
+}
+
+func newGroupLogsCmd(containerApp *app.ContainerApp) *cobra.Command {
+	var tail int
+
+	cmd := &cobra.Command{
+		Use:   "logs <group>",
+		Short: "Show the logs of every member of a group, merged by time",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			logs, err := containerApp.GetGroupLogs(ctx, args[0], tail)
+			if err != nil {
+				return err
+			}
+			fmt.Print(logs)
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&tail, "tail", 200, "Number of lines to show")
+	return cmd
+}
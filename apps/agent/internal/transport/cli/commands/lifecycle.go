@@ -0,0 +1,97 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/app"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+	"github.com/spf13/cobra"
+)
+
+func NewLifecycleCmd(containerApp *app.ContainerApp) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "lifecycle",
+		Short: "Manage resource TTLs and idle timeouts",
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "list",
+		Short: "List resources with a TTL or idle timeout",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			lifecycles, err := containerApp.ListLifecycles(ctx)
+			if err != nil {
+				return err
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "RESOURCE\tEXPIRES\tACTION\tIDLE TIMEOUT\tIDLE STOP")
+			for _, l := range lifecycles {
+				expires, action, timeout, idleStop := "-", "-", "-", "-"
+				if l.ExpiresAt != 0 {
+					expires = time.Unix(l.ExpiresAt, 0).Format(time.DateTime)
+					action = l.ExpiryAction
+				}
+				if l.IdleTimeout != 0 {
+					timeout = (time.Duration(l.IdleTimeout) * time.Second).String()
+					idleStop = time.Unix(l.LastActiveAt+l.IdleTimeout, 0).Format(time.DateTime)
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", l.Resource, expires, action, timeout, idleStop)
+			}
+			return w.Flush()
+		},
+	})
+
+	var req domain.LifecycleRequest
+	set := &cobra.Command{
+		Use:   "set <resource>",
+		Short: "Set or clear (with 0) the TTL and idle timeout of a resource",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			lifecycle, err := containerApp.SetLifecycle(ctx, args[0], req)
+			if err != nil {
+				return err
+			}
+			printLifecycle(lifecycle)
+			return nil
+		},
+	}
+	set.Flags().StringVar(&req.TTL, "ttl", "", "Time until the resource expires, e.g. 2h")
+	set.Flags().StringVar(&req.TTLAction, "ttl-action", "", "What happens on expiry: delete or stop")
+	set.Flags().StringVar(&req.IdleTimeout, "idle-timeout", "", "Stop the resource after this long without activity, e.g. 30m")
+	cmd.AddCommand(set)
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "extend <resource> <duration>",
+		Short: "Push back the expiry and idle stop of a resource",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			lifecycle, err := containerApp.ExtendLifecycle(ctx, args[0], args[1])
+			if err != nil {
+				return err
+			}
+			printLifecycle(lifecycle)
+			return nil
+		},
+	})
+	return cmd
+}
+
+func printLifecycle(l *domain.Lifecycle) {
+	if l.ExpiresAt == 0 && l.IdleTimeout == 0 {
+		fmt.Printf("%s has no TTL or idle timeout\n", l.Resource)
+		return
+	}
+	if l.ExpiresAt != 0 {
+		fmt.Printf("%s: %s at %s\n", l.Resource, l.ExpiryAction, time.Unix(l.ExpiresAt, 0).Format(time.DateTime))
+	}
+	if l.IdleTimeout != 0 {
+		fmt.Printf("%s: stops after %s idle, next at %s\n", l.Resource, time.Duration(l.IdleTimeout)*time.Second, time.Unix(l.LastActiveAt+l.IdleTimeout, 0).Format(time.DateTime))
+	}
+}
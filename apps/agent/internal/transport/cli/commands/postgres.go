@@ -0,0 +1,240 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/app"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+	"github.com/spf13/cobra"
+)
+
+func NewPostgresCmd(containerApp *app.ContainerApp) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "postgres",
+		Aliases: []string{"pg"},
+		Short:   "Administer postgres resources",
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "databases <resource>",
+		Short: "List databases",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			databases, err := containerApp.ListPostgresDatabases(ctx, args[0])
+			if err != nil {
+				return err
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "NAME\tOWNER\tENCODING\tSIZE")
+			for _, db := range databases {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", db.Name, db.Owner, db.Encoding, formatBytes(db.SizeBytes))
+			}
+			return w.Flush()
+		},
+	})
+
+	var owner string
+	createDB := &cobra.Command{
+		Use:   "createdb <resource> <database>",
+		Short: "Create a database",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			if err := containerApp.CreatePostgresDatabase(ctx, args[0], domain.PostgresDatabaseRequest{Name: args[1], Owner: owner}); err != nil {
+				return err
+			}
+			fmt.Printf("Database %s created\n", args[1])
+			return nil
+		},
+	}
+	createDB.Flags().StringVar(&owner, "owner", "", "Role that owns the database")
+	cmd.AddCommand(createDB)
+
+	var force bool
+	dropDB := &cobra.Command{
+		Use:   "dropdb <resource> <database>",
+		Short: "Drop a database",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			if err := containerApp.DropPostgresDatabase(ctx, args[0], args[1], force); err != nil {
+				return err
+			}
+			fmt.Printf("Database %s dropped\n", args[1])
+			return nil
+		},
+	}
+	dropDB.Flags().BoolVar(&force, "force", false, "Terminate open connections first")
+	cmd.AddCommand(dropDB)
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "roles <resource>",
+		Short: "List roles",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			roles, err := containerApp.ListPostgresRoles(ctx, args[0])
+			if err != nil {
+				return err
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "NAME\tLOGIN\tSUPERUSER\tCREATEDB\tCREATEROLE")
+			for _, role := range roles {
+				fmt.Fprintf(w, "%s\t%t\t%t\t%t\t%t\n", role.Name, role.Login, role.Superuser, role.CreateDB, role.CreateRole)
+			}
+			return w.Flush()
+		},
+	})
+
+	var role domain.PostgresRoleRequest
+	createRole := &cobra.Command{
+		Use:   "createrole <resource> <role>",
+		Short: "Create a role",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			role.Name = args[1]
+			ctx := context.Background()
+			if err := containerApp.CreatePostgresRole(ctx, args[0], role); err != nil {
+				return err
+			}
+			fmt.Printf("Role %s created\n", args[1])
+			return nil
+		},
+	}
+	createRole.Flags().StringVar(&role.Password, "password", "", "Role password")
+	createRole.Flags().BoolVar(&role.Login, "login", true, "Allow the role to log in")
+	createRole.Flags().BoolVar(&role.Superuser, "superuser", false, "Make the role a superuser")
+	createRole.Flags().BoolVar(&role.CreateDB, "createdb", false, "Allow the role to create databases")
+	createRole.Flags().BoolVar(&role.CreateRole, "createrole", false, "Allow the role to create roles")
+	cmd.AddCommand(createRole)
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "droprole <resource> <role>",
+		Short: "Drop a role",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			if err := containerApp.DropPostgresRole(ctx, args[0], args[1]); err != nil {
+				return err
+			}
+			fmt.Printf("Role %s dropped\n", args[1])
+			return nil
+		},
+	})
+
+	var database string
+	query := &cobra.Command{
+		Use:   "query <resource> <sql>",
+		Short: "Run a SQL query and print the result",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			result, err := containerApp.PostgresQuery(ctx, args[0], domain.PostgresQueryRequest{Database: database, SQL: args[1]})
+			if err != nil {
+				return err
+			}
+			printQueryResult(result)
+			return nil
+		},
+	}
+	query.Flags().StringVarP(&database, "database", "d", "", "Database to connect to")
+	cmd.AddCommand(query)
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "connections <resource>",
+		Short: "Show active client connections",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			connections, err := containerApp.PostgresConnections(ctx, args[0])
+			if err != nil {
+				return err
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "PID\tDATABASE\tUSER\tCLIENT\tSTATE\tQUERY")
+			for _, conn := range connections {
+				fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\n", conn.PID, deref(conn.Database), deref(conn.User), deref(conn.ClientAddr), deref(conn.State), oneLine(conn.Query, 60))
+			}
+			return w.Flush()
+		},
+	})
+
+	var tablesDB string
+	tables := &cobra.Command{
+		Use:   "tables <resource>",
+		Short: "Show table sizes",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			sizes, err := containerApp.PostgresTableSizes(ctx, args[0], tablesDB)
+			if err != nil {
+				return err
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "TABLE\tROWS\tTABLE SIZE\tINDEX SIZE\tTOTAL")
+			for _, t := range sizes {
+				fmt.Fprintf(w, "%s.%s\t%d\t%s\t%s\t%s\n", t.Schema, t.Table, t.Rows, formatBytes(t.TableBytes), formatBytes(t.IndexBytes), formatBytes(t.TotalBytes))
+			}
+			return w.Flush()
+		},
+	}
+	tables.Flags().StringVarP(&tablesDB, "database", "d", "", "Database to inspect")
+	cmd.AddCommand(tables)
+
+	return cmd
+}
+
+func printQueryResult(result *domain.QueryResult) {
+	if len(result.Columns) == 0 {
+		fmt.Println("OK")
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(result.Columns, "\t"))
+	for _, row := range result.Rows {
+		fields := make([]string, len(row))
+		for i, value := range row {
+			if value == nil {
+				fields[i] = "NULL"
+			} else {
+				fields[i] = oneLine(*value, 80)
+			}
+		}
+		fmt.Fprintln(w, strings.Join(fields, "\t"))
+	}
+	w.Flush()
+	fmt.Printf("(%d rows)\n", len(result.Rows))
+}
+
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return "-"
+	}
+	return *s
+}
+
+func oneLine(s string, max int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if len(s) > max {
+		return s[:max-3] + "..."
+	}
+	return s
+}
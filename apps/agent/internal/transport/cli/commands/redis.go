@@ -0,0 +1,172 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/app"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+	"github.com/spf13/cobra"
+)
+
+func NewRedisCmd(containerApp *app.ContainerApp) *cobra.Command {
+	var db int
+
+	cmd := &cobra.Command{
+		Use:   "redis",
+		Short: "Inspect redis resources",
+	}
+	cmd.PersistentFlags().IntVarP(&db, "db", "n", 0, "Logical database number")
+
+	var section string
+	info := &cobra.Command{
+		Use:   "info <resource>",
+		Short: "Show INFO grouped by section",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			sections, err := containerApp.RedisInfo(ctx, args[0], section)
+			if err != nil {
+				return err
+			}
+			for _, s := range sections {
+				fmt.Printf("# %s\n", s.Name)
+				for _, key := range sortedKeys(s.Fields) {
+					fmt.Printf("  %s: %s\n", key, s.Fields[key])
+				}
+			}
+			return nil
+		},
+	}
+	info.Flags().StringVar(&section, "section", "", "Only show this section")
+	cmd.AddCommand(info)
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "keyspace <resource>",
+		Short: "Show key counts per database and hit rates",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			keyspace, err := containerApp.RedisKeyspace(ctx, args[0])
+			if err != nil {
+				return err
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "DB\tKEYS\tEXPIRES\tAVG TTL (ms)")
+			for _, d := range keyspace.Databases {
+				fmt.Fprintf(w, "%d\t%d\t%d\t%d\n", d.DB, d.Keys, d.Expires, d.AvgTTL)
+			}
+			w.Flush()
+			fmt.Printf("Hits: %d  Misses: %d  Expired: %d  Evicted: %d\n", keyspace.Hits, keyspace.Misses, keyspace.ExpiredKeys, keyspace.EvictedKeys)
+			return nil
+		},
+	})
+
+	var scan domain.RedisScanRequest
+	keys := &cobra.Command{
+		Use:   "keys <resource> [pattern]",
+		Short: "Scan keys with their type, TTL and size",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			scan.DB = db
+			if len(args) == 2 {
+				scan.Pattern = args[1]
+			}
+			ctx := context.Background()
+			result, err := containerApp.RedisScanKeys(ctx, args[0], scan)
+			if err != nil {
+				return err
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "KEY\tTYPE\tTTL\tSIZE")
+			for _, key := range result.Keys {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", key.Key, key.Type, formatTTL(key.TTLMillis), formatBytes(key.SizeBytes))
+			}
+			w.Flush()
+			if result.Cursor != "0" {
+				fmt.Printf("More keys available: --cursor %s\n", result.Cursor)
+			}
+			return nil
+		},
+	}
+	keys.Flags().StringVar(&scan.Cursor, "cursor", "0", "Continue a previous scan")
+	keys.Flags().IntVar(&scan.Count, "count", 100, "Maximum keys to return")
+	cmd.AddCommand(keys)
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "get <resource> <key>",
+		Short: "Show a key's value",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			value, err := containerApp.RedisGetValue(ctx, args[0], db, args[1])
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Type:   %s\nTTL:    %s\nLength: %d\n", value.Type, formatTTL(value.TTLMillis), value.Length)
+			if s, ok := value.Value.(string); ok {
+				fmt.Println(s)
+			} else {
+				b, err := json.MarshalIndent(value.Value, "", "  ")
+				if err != nil {
+					return err
+				}
+				fmt.Println(string(b))
+			}
+			if value.Truncated {
+				fmt.Println("(truncated)")
+			}
+			return nil
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "del <resource> <key>...",
+		Short: "Delete keys",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			deleted, err := containerApp.RedisDeleteKeys(ctx, args[0], domain.RedisDeleteRequest{DB: db, Keys: args[1:]})
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Deleted %d keys\n", deleted)
+			return nil
+		},
+	})
+
+	var confirm bool
+	flush := &cobra.Command{
+		Use:   "flushdb <resource>",
+		Short: "Delete every key in the database",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			if err := containerApp.RedisFlushDB(ctx, args[0], db, confirm); err != nil {
+				return err
+			}
+			fmt.Printf("Database %d flushed\n", db)
+			return nil
+		},
+	}
+	flush.Flags().BoolVar(&confirm, "yes", false, "Confirm flushing the database")
+	cmd.AddCommand(flush)
+
+	return cmd
+}
+
+func formatTTL(ms int64) string {
+	switch {
+	case ms == -1:
+		return "none"
+	case ms < 0:
+		return "-"
+	case ms < 1000:
+		return fmt.Sprintf("%dms", ms)
+	default:
+		return fmt.Sprintf("%ds", ms/1000)
+	}
+}
@@ -0,0 +1,147 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/app"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+	"github.com/spf13/cobra"
+)
+
+func NewScheduleCmd(containerApp *app.ContainerApp) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schedule",
+		Short: "Start, stop or restart resources and stacks on cron schedules",
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "list",
+		Short: "List schedules with their last and next run",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			schedules, err := containerApp.ListSchedules(ctx)
+			if err != nil {
+				return err
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "NAME\tACTION\tTARGET\tCRON\tTIME ZONE\tNEXT RUN\tLAST RUN")
+			for _, s := range schedules {
+				zone, next, last := "local", "disabled", "-"
+				if s.TimeZone != "" {
+					zone = s.TimeZone
+				}
+				if s.NextRunAt != 0 {
+					next = time.Unix(s.NextRunAt, 0).Format(time.DateTime)
+				}
+				if s.LastRun != nil {
+					last = time.Unix(s.LastRun.StartedAt, 0).Format(time.DateTime) + " " + s.LastRun.Outcome
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s %s\t%s\t%s\t%s\t%s\n", s.Name, s.Action, s.TargetKind, s.Target, s.Cron, zone, next, last)
+			}
+			return w.Flush()
+		},
+	})
+
+	cmd.AddCommand(newScheduleSaveCmd(containerApp, "add", "Add a schedule"))
+	cmd.AddCommand(newScheduleSaveCmd(containerApp, "update", "Replace the definition of a schedule"))
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "delete <name>",
+		Short: "Delete a schedule and its run history",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			if err := containerApp.DeleteSchedule(ctx, args[0]); err != nil {
+				return err
+			}
+			fmt.Printf("Deleted schedule %s\n", args[0])
+			return nil
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "run <name>",
+		Short: "Run a schedule's action now",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			run, err := containerApp.RunSchedule(ctx, args[0])
+			if err != nil {
+				return err
+			}
+			if run.Error != "" {
+				return fmt.Errorf("%s %s failed: %s", run.Action, run.Target, run.Error)
+			}
+			fmt.Printf("Ran %s %s\n", run.Action, run.Target)
+			return nil
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "runs <name>",
+		Short: "Show the run history of a schedule",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			runs, err := containerApp.ListScheduleRuns(ctx, args[0])
+			if err != nil {
+				return err
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "STARTED\tTRIGGER\tACTION\tTARGET\tDURATION\tOUTCOME\tERROR")
+			for _, r := range runs {
+				duration := time.Duration(r.FinishedAt-r.StartedAt) * time.Second
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", time.Unix(r.StartedAt, 0).Format(time.DateTime), r.Trigger, r.Action, r.Target, duration, r.Outcome, r.Error)
+			}
+			return w.Flush()
+		},
+	})
+	return cmd
+}
+
+func newScheduleSaveCmd(containerApp *app.ContainerApp, use, short string) *cobra.Command {
+	var req domain.ScheduleRequest
+	var stack bool
+
+	cmd := &cobra.Command{
+		Use:   use + " <name> <start|stop|restart> <target>",
+		Short: short,
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req.Name, req.Action, req.Target = args[0], args[1], args[2]
+			req.TargetKind = domain.ScheduleTargetResource
+			if stack {
+				req.TargetKind = domain.ScheduleTargetStack
+			}
+
+			ctx := context.Background()
+			var saved *domain.Schedule
+			var err error
+			if use == "add" {
+				saved, err = containerApp.CreateSchedule(ctx, req)
+			} else {
+				saved, err = containerApp.UpdateSchedule(ctx, req.Name, req)
+			}
+			if err != nil {
+				return err
+			}
+			if saved.NextRunAt == 0 {
+				fmt.Printf("Saved schedule %s (disabled)\n", saved.Name)
+				return nil
+			}
+			fmt.Printf("Saved schedule %s, next run %s\n", saved.Name, time.Unix(saved.NextRunAt, 0).Format(time.DateTime))
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&req.Cron, "cron", "", "Cron expression, e.g. \"0 9 * * 1-5\" or \"@daily\"")
+	cmd.Flags().StringVar(&req.TimeZone, "tz", "", "IANA time zone the expression is evaluated in, e.g. Europe/Berlin")
+	cmd.Flags().BoolVar(&stack, "stack", false, "Target is a stack rather than a resource")
+	cmd.Flags().BoolVar(&req.Disabled, "disabled", false, "Save the schedule without running it")
+	cmd.MarkFlagRequired("cron")
+	return cmd
+}
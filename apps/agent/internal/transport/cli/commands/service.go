@@ -0,0 +1,116 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/app"
+	"github.com/spf13/cobra"
+)
+
+func NewServiceCmd(containerApp *app.ContainerApp) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "service",
+		Short: "Manage a single service of a compose stack",
+	}
+
+	actions := []struct {
+		use   string
+		short string
+		done  string
+		run   func(ctx context.Context, project, service string) error
+	}{
+		{"start", "Start a stack service", "started", containerApp.StartService},
+		{"stop", "Stop a stack service", "stopped", containerApp.StopService},
+		{"restart", "Restart a stack service", "restarted", containerApp.RestartService},
+		{"recreate", "Recreate the containers of a stack service", "recreated", containerApp.RecreateService},
+	}
+
+	for _, action := range actions {
+		cmd.AddCommand(&cobra.Command{
+			Use:   action.use + " <project/service>",
+			Short: action.short,
+			Args:  cobra.ExactArgs(1),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				project, service, err := app.ParseServiceRef(args[0])
+				if err != nil {
+					return err
+				}
+				ctx := context.Background()
+				if err := action.run(ctx, project, service); err != nil {
+					return err
+				}
+				fmt.Printf("Service %s/%s %s\n", project, service, action.done)
+				return nil
+			},
+		})
+	}
+
+	cmd.AddCommand(newServiceLogsCmd(containerApp))
+	cmd.AddCommand(newServiceScaleCmd(containerApp))
+	return cmd
+}
+
+func newServiceLogsCmd(containerApp *app.ContainerApp) *cobra.Command {
+	var tail int
+
+	cmd := &cobra.Command{
+		Use:   "logs <project/service>",
+		Short: "Show logs of a stack service",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			project, service, err := app.ParseServiceRef(args[0])
+			if err != nil {
+				return err
+			}
+			ctx := context.Background()
+			logs, err := containerApp.GetServiceLogs(ctx, project, service, tail)
+			if err != nil {
+				return err
+			}
+			fmt.Print(logs)
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&tail, "tail", 200, "Number of lines to show per container")
+	return cmd
+}
+
+func newServiceScaleCmd(containerApp *app.ContainerApp) *cobra.Command {
+	return &cobra.Command{
+		Use:   "scale <project/service> <replicas>",
+		Short: "Scale a stack service to the given number of containers",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			project, service, err := app.ParseServiceRef(args[0])
+			if err != nil {
+				return err
+			}
+			replicas, err := strconv.Atoi(args[1])
+			if err != nil {
+				return fmt.Errorf("replicas must be a number")
+			}
+			ctx := context.Background()
+			result, err := containerApp.ScaleService(ctx, project, service, replicas)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Service %s/%s scaled to %d\n", project, service, result.Replicas)
+			for _, name := range result.Created {
+				fmt.Printf("  created  %s\n", name)
+			}
+			for _, name := range result.Started {
+				fmt.Printf("  started  %s\n", name)
+			}
+			for _, name := range result.Stopped {
+				fmt.Printf("  stopped  %s\n", name)
+			}
+			for _, name := range result.Removed {
+				fmt.Printf("  removed  %s\n", name)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,244 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/app"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+	"github.com/spf13/cobra"
+)
+
+func NewStackCmd(containerApp *app.ContainerApp) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stack",
+		Short: "Manage whole compose stacks",
+	}
+
+	actions := []struct {
+		use   string
+		short string
+		done  string
+		run   func(ctx context.Context, project string) error
+	}{
+		{"start", "Start every service of a stack", "started", containerApp.StartStack},
+		{"stop", "Stop every service of a stack", "stopped", containerApp.StopStack},
+		{"restart", "Restart every service of a stack", "restarted", containerApp.RestartStack},
+	}
+
+	for _, action := range actions {
+		cmd.AddCommand(&cobra.Command{
+			Use:   action.use + " <project>",
+			Short: action.short,
+			Args:  cobra.ExactArgs(1),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				ctx := context.Background()
+				if err := action.run(ctx, args[0]); err != nil {
+					return err
+				}
+				fmt.Printf("Stack %s %s\n", args[0], action.done)
+				return nil
+			},
+		})
+	}
+
+	cmd.AddCommand(newStackDownCmd(containerApp))
+	cmd.AddCommand(newStackApplyCmd(containerApp))
+	cmd.AddCommand(newStackHistoryCmd(containerApp))
+	cmd.AddCommand(newStackRollbackCmd(containerApp))
+	cmd.AddCommand(&cobra.Command{
+		Use:   "reload <project>",
+		Short: "Re-apply an imported stack from its source directory",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			result, err := containerApp.ReloadStack(ctx, args[0])
+			if err != nil {
+				return err
+			}
+			printApplyResult(result)
+			return nil
+		},
+	})
+	return cmd
+}
+
+func newStackApplyCmd(containerApp *app.ContainerApp) *cobra.Command {
+	var files []string
+	var profiles []string
+	var envFile string
+
+	cmd := &cobra.Command{
+		Use:   "apply <name>",
+		Short: "Apply compose files, recreating only the services that changed",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			source, err := readStackSource(files, profiles, envFile)
+			if err != nil {
+				return err
+			}
+			ctx := context.Background()
+			result, err := containerApp.ApplyStack(ctx, args[0], *source)
+			if err != nil {
+				return err
+			}
+			printApplyResult(result)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&files, "file", "f", nil, "Compose files merged in order (default docker-compose.yml and docker-compose.override.yml)")
+	cmd.Flags().StringSliceVar(&profiles, "profile", nil, "Profiles to enable")
+	cmd.Flags().StringVar(&envFile, "env-file", ".env", "Env file used for variable substitution")
+	return cmd
+}
+
+// readStackSource follows docker compose defaults: without -f the override
+// file is picked up next to docker-compose.yml, and a missing default .env is
+// not an error.
+func readStackSource(files, profiles []string, envFile string) (*domain.StackSource, error) {
+	explicit := len(files) > 0
+	if !explicit {
+		files = []string{"docker-compose.yml", "docker-compose.override.yml"}
+	}
+
+	source := &domain.StackSource{Profiles: profiles}
+	for i, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			if !explicit && i > 0 && errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		if i == 0 {
+			source.Compose = string(content)
+		} else {
+			source.Overrides = append(source.Overrides, string(content))
+		}
+	}
+
+	if envFile != "" {
+		content, err := os.ReadFile(envFile)
+		if err != nil && !(errors.Is(err, os.ErrNotExist) && envFile == ".env") {
+			return nil, err
+		}
+		source.EnvFile = string(content)
+	}
+	return source, nil
+}
+
+func newStackHistoryCmd(containerApp *app.ContainerApp) *cobra.Command {
+	return &cobra.Command{
+		Use:   "history <project>",
+		Short: "List stored versions of a stack definition",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			stack, err := containerApp.GetStackDefinition(ctx, args[0])
+			if err != nil {
+				return err
+			}
+			current := stack.Current().Version
+			for i := len(stack.Versions) - 1; i >= 0; i-- {
+				v := stack.Versions[i]
+				marker := " "
+				if v.Version == current {
+					marker = "*"
+				}
+				fmt.Printf("%s v%-4d %s  %s\n", marker, v.Version, time.Unix(v.CreatedAt, 0).Format(time.RFC3339), v.Note)
+			}
+			return nil
+		},
+	}
+}
+
+func newStackRollbackCmd(containerApp *app.ContainerApp) *cobra.Command {
+	return &cobra.Command{
+		Use:   "rollback <project> [version]",
+		Short: "Re-apply a previous stack definition",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			version := 0
+			if len(args) == 2 {
+				v, err := strconv.Atoi(strings.TrimPrefix(args[1], "v"))
+				if err != nil {
+					return fmt.Errorf("invalid version %q", args[1])
+				}
+				version = v
+			}
+			ctx := context.Background()
+			result, err := containerApp.RollbackStack(ctx, args[0], version)
+			if err != nil {
+				return err
+			}
+			printApplyResult(result)
+			return nil
+		},
+	}
+}
+
+func printApplyResult(result *domain.StackApplyResult) {
+	fmt.Printf("Stack %s at version %d\n", result.Project, result.Version)
+	sections := []struct {
+		verb     string
+		services []string
+	}{
+		{"Created", result.Created},
+		{"Recreated", result.Recreated},
+		{"Removed", result.Removed},
+		{"Unchanged", result.Unchanged},
+	}
+	for _, section := range sections {
+		for _, service := range section.services {
+			fmt.Printf("%s service %s\n", section.verb, service)
+		}
+	}
+}
+
+func newStackDownCmd(containerApp *app.ContainerApp) *cobra.Command {
+	var opts domain.StackDownOptions
+
+	cmd := &cobra.Command{
+		Use:   "down <project>",
+		Short: "Remove a stack's containers and networks",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			report, err := containerApp.DownStack(ctx, args[0], opts)
+			if report == nil {
+				return err
+			}
+
+			verb := "Removed"
+			if report.DryRun {
+				verb = "Would remove"
+			}
+			sections := []struct {
+				kind  string
+				items []string
+			}{
+				{"container", report.Containers},
+				{"network", report.Networks},
+				{"volume", report.Volumes},
+				{"image", report.Images},
+			}
+			for _, section := range sections {
+				for _, item := range section.items {
+					fmt.Printf("%s %s %s\n", verb, section.kind, item)
+				}
+			}
+			return err
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opts.Volumes, "volumes", "v", false, "Also remove named volumes declared by the stack")
+	cmd.Flags().BoolVar(&opts.Images, "images", false, "Also remove images built for the stack")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Only show what would be removed")
+	return cmd
+}
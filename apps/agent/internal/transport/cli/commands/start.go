@@ -21,6 +21,18 @@ func NewStartServer(containerApp *app.ContainerApp, systemApp *app.SystemApp) *c
 				port = "8080"
 			}
 
+			if err := containerApp.StartBackupScheduler(); err != nil {
+				return err
+			}
+			if err := containerApp.StartScheduler(); err != nil {
+				return err
+			}
+			if err := containerApp.StartGCScheduler(); err != nil {
+				return err
+			}
+			containerApp.StartReconciler()
+			containerApp.StartLifecycleMonitor()
+			containerApp.StartTrashPurger()
 			router := http.SetupRouter(systemApp, containerApp)
 
 			if daemon {
@@ -0,0 +1,155 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/app"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+	"github.com/spf13/cobra"
+)
+
+func NewTemplateCmd(containerApp *app.ContainerApp) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "template",
+		Short: "Browse the resource template catalog and create resources from it",
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "list",
+		Short: "List available templates",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			templates, err := containerApp.ListTemplates(ctx)
+			if err != nil {
+				return err
+			}
+			for _, t := range templates {
+				source := "custom"
+				if t.Builtin {
+					source = "builtin"
+				}
+				fmt.Printf("%-12s %-8s %-32s %s\n", t.Name, source, t.Image, t.Description)
+			}
+			return nil
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "show <template>",
+		Short: "Show a template's ports, env and volumes",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			t, err := containerApp.GetTemplate(ctx, args[0])
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Name:   %s\nImage:  %s\nType:   %s\n", t.Name, t.Image, t.Type)
+			for _, port := range t.Ports {
+				fmt.Printf("Port:   %s %s -> %s\n", port.Name, port.HostPort, port.ContainerPort)
+			}
+			for _, env := range t.Env {
+				value := env.Default
+				if value == "" && env.Generate > 0 {
+					value = fmt.Sprintf("<generated %d chars>", env.Generate)
+				}
+				fmt.Printf("Env:    %s=%s\n", env.Name, value)
+			}
+			for _, volume := range t.Volumes {
+				fmt.Printf("Volume: %s -> %s\n", volume.Name, volume.Target)
+			}
+			return nil
+		},
+	})
+
+	cmd.AddCommand(newTemplateCreateCmd(containerApp))
+	return cmd
+}
+
+func newTemplateCreateCmd(containerApp *app.ContainerApp) *cobra.Command {
+	var req domain.TemplateRequest
+	var ports []string
+	var env []string
+	var wait bool
+
+	cmd := &cobra.Command{
+		Use:   "create <template> <name>",
+		Short: "Create a resource from a template",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req.Name = args[1]
+			var err error
+			if req.HostPorts, err = parseAssignments(ports); err != nil {
+				return err
+			}
+			if req.Env, err = parseAssignments(env); err != nil {
+				return err
+			}
+
+			ctx := context.Background()
+			begin := time.Now()
+			instance, err := containerApp.CreateFromTemplate(ctx, args[0], req)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Created %s from template %s (%s)\n", instance.Resource.Name, instance.Template, instance.Resource.ID[:12])
+			for _, name := range sortedKeys(instance.Ports) {
+				fmt.Printf("Port   %s: %s\n", name, instance.Ports[name])
+			}
+			for _, name := range sortedKeys(instance.Env) {
+				fmt.Printf("Env    %s=%s\n", name, instance.Env[name])
+			}
+			for _, volume := range instance.Volumes {
+				fmt.Printf("Volume %s\n", volume)
+			}
+			if !wait {
+				return nil
+			}
+			readiness, err := containerApp.WaitReady(ctx, instance.Resource.ID, begin)
+			if err != nil {
+				return err
+			}
+			if !readiness.Ready {
+				return fmt.Errorf("%s is not ready (%s): %s", instance.Resource.Name, readiness.Probe, readiness.Error)
+			}
+			fmt.Printf("Ready  %s in %dms (start %dms, probe %dms)\n", readiness.Probe, readiness.TotalMs, readiness.StartMs, readiness.ReadyMs)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&req.Tag, "tag", "", "Image tag to use instead of the template default")
+	cmd.Flags().StringArrayVarP(&ports, "port", "p", nil, "Host port override as <port name or container port>=<host port>")
+	cmd.Flags().StringArrayVarP(&env, "env", "e", nil, "Env override as KEY=value")
+	cmd.Flags().BoolVar(&wait, "wait", false, "Wait until the resource passes its readiness probe")
+	cmd.Flags().StringVar(&req.TTL, "ttl", "", "Time until the resource expires, e.g. 2h")
+	cmd.Flags().StringVar(&req.TTLAction, "ttl-action", "", "What happens on expiry: delete or stop")
+	cmd.Flags().StringVar(&req.IdleTimeout, "idle-timeout", "", "Stop the resource after this long without activity")
+	return cmd
+}
+
+func parseAssignments(items []string) (map[string]string, error) {
+	values := make(map[string]string, len(items))
+	for _, item := range items {
+		key, value, ok := strings.Cut(item, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("expected key=value, got %q", item)
+		}
+		values[key] = value
+	}
+	return values, nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,73 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/app"
+	"github.com/spf13/cobra"
+)
+
+func NewTrashCmd(containerApp *app.ContainerApp) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "trash",
+		Short: "List, restore or purge deleted resources",
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "list",
+		Short: "List deleted resources that can still be restored",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			entries, err := containerApp.ListTrash(ctx)
+			if err != nil {
+				return err
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "RESOURCE\tTYPE\tIMAGE\tVOLUMES\tDELETED\tPURGED AT")
+			for _, e := range entries {
+				volumes := "-"
+				if len(e.Volumes) > 0 {
+					volumes = strings.Join(e.Volumes, ",")
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", e.Resource, e.Type, e.Image, volumes, time.Unix(e.DeletedAt, 0).Format(time.DateTime), time.Unix(e.PurgeAt, 0).Format(time.DateTime))
+			}
+			return w.Flush()
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "restore <resource>",
+		Short: "Restore a deleted resource, starting it if it was running",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			entry, err := containerApp.RestoreResource(ctx, args[0])
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Restored %s\n", entry.Resource)
+			return nil
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "purge <resource>",
+		Short: "Remove a deleted resource for good",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			if err := containerApp.PurgeResource(ctx, args[0]); err != nil {
+				return err
+			}
+			fmt.Printf("Purged %s\n", args[0])
+			return nil
+		},
+	})
+	return cmd
+}
@@ -0,0 +1,41 @@
+package commands
+
+import (
+	"context"
+	"os"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/app"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+	"github.com/spf13/cobra"
+)
+
+func NewUpCmd(containerApp *app.ContainerApp) *cobra.Command {
+	var req domain.StackImport
+
+	cmd := &cobra.Command{
+		Use:   "up",
+		Short: "Import and apply the compose stack in the current directory",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir, err := os.Getwd()
+			if err != nil {
+				return err
+			}
+			req.Path = dir
+
+			ctx := context.Background()
+			result, err := containerApp.ImportStack(ctx, req)
+			if err != nil {
+				return err
+			}
+			printApplyResult(result)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&req.Name, "name", "n", "", "Stack name (default the directory name)")
+	cmd.Flags().StringSliceVarP(&req.Files, "file", "f", nil, "Compose files merged in order, relative to the directory")
+	cmd.Flags().StringSliceVar(&req.Profiles, "profile", nil, "Profiles to enable")
+	cmd.Flags().StringVar(&req.EnvFile, "env-file", "", "Env file used for variable substitution (default .env)")
+	return cmd
+}
@@ -0,0 +1,99 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/app"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/connection"
+	"github.com/spf13/cobra"
+)
+
+func NewURLCmd(containerApp *app.ContainerApp) *cobra.Command {
+	var reveal bool
+	var copyURL bool
+	var internal bool
+
+	cmd := &cobra.Command{
+		Use:   "url <name>",
+		Short: "Print connection URLs for a resource",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			info, err := containerApp.GetConnectionInfo(ctx, args[0], reveal)
+			if err != nil {
+				return err
+			}
+
+			scope := connection.ScopeHost
+			if internal {
+				scope = connection.ScopeNetwork
+			}
+			found := false
+			for _, endpoint := range info.Endpoints {
+				if endpoint.Scope != scope {
+					continue
+				}
+				found = true
+				label := endpoint.Name
+				if endpoint.Network != "" {
+					label += " (" + endpoint.Network + ")"
+				}
+				fmt.Printf("%-24s %s\n", label, endpoint.URL)
+			}
+			if !found {
+				return fmt.Errorf("resource %s has no %s endpoints", args[0], scope)
+			}
+
+			if copyURL {
+				// The clipboard gets the real secret even when the output is masked.
+				revealed := info
+				if !reveal {
+					revealed, err = containerApp.GetConnectionInfo(ctx, args[0], true)
+					if err != nil {
+						return err
+					}
+				}
+				for _, endpoint := range revealed.Endpoints {
+					if endpoint.Scope == scope {
+						if err := copyToClipboard(endpoint.URL); err != nil {
+							return err
+						}
+						fmt.Printf("Copied %s URL to clipboard\n", endpoint.Name)
+						break
+					}
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&reveal, "reveal", false, "Show passwords instead of masking them")
+	cmd.Flags().BoolVarP(&copyURL, "copy", "c", false, "Copy the primary URL to the clipboard")
+	cmd.Flags().BoolVar(&internal, "internal", false, "Show URLs for containers on the same network")
+	return cmd
+}
+
+func copyToClipboard(text string) error {
+	var candidates [][]string
+	switch runtime.GOOS {
+	case "darwin":
+		candidates = [][]string{{"pbcopy"}}
+	case "windows":
+		candidates = [][]string{{"clip"}}
+	default:
+		candidates = [][]string{{"wl-copy"}, {"xclip", "-selection", "clipboard"}, {"xsel", "--clipboard", "--input"}, {"clip.exe"}}
+	}
+	for _, candidate := range candidates {
+		if _, err := exec.LookPath(candidate[0]); err != nil {
+			continue
+		}
+		copyCmd := exec.Command(candidate[0], candidate[1:]...)
+		copyCmd.Stdin = strings.NewReader(text)
+		return copyCmd.Run()
+	}
+	return fmt.Errorf("no clipboard tool found")
+}
@@ -0,0 +1,105 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/app"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/workspace"
+	"github.com/spf13/cobra"
+)
+
+func NewPlanCmd(containerApp *app.ContainerApp) *cobra.Command {
+	var file string
+	var prune, adopt bool
+
+	cmd := &cobra.Command{
+		Use:   "plan",
+		Short: "Show what apply would change to match the workspace spec",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req, err := readWorkspaceRequest(file, prune, adopt)
+			if err != nil {
+				return err
+			}
+			ctx := context.Background()
+			plan, err := containerApp.PlanWorkspace(ctx, *req)
+			if err != nil {
+				return err
+			}
+			printWorkspacePlan(plan)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", workspace.DefaultFile, "Workspace spec file")
+	cmd.Flags().BoolVar(&prune, "prune", false, "Plan deletion of workspace resources missing from the spec")
+	cmd.Flags().BoolVar(&adopt, "adopt", false, "Plan replacing same-named resources created outside the workspace")
+	return cmd
+}
+
+func NewApplyCmd(containerApp *app.ContainerApp) *cobra.Command {
+	var file string
+	var prune, adopt bool
+
+	cmd := &cobra.Command{
+		Use:   "apply",
+		Short: "Create or update resources to match the workspace spec",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req, err := readWorkspaceRequest(file, prune, adopt)
+			if err != nil {
+				return err
+			}
+			ctx := context.Background()
+			plan, err := containerApp.ApplyWorkspace(ctx, *req)
+			if err != nil {
+				return err
+			}
+			printWorkspacePlan(plan)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", workspace.DefaultFile, "Workspace spec file")
+	cmd.Flags().BoolVar(&prune, "prune", false, "Delete workspace resources missing from the spec")
+	cmd.Flags().BoolVar(&adopt, "adopt", false, "Replace same-named resources created outside the workspace")
+	return cmd
+}
+
+func readWorkspaceRequest(file string, prune, adopt bool) (*domain.WorkspaceRequest, error) {
+	path, err := filepath.Abs(file)
+	if err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.WorkspaceRequest{Spec: string(content), Dir: filepath.Dir(path), Prune: prune, Adopt: adopt}, nil
+}
+
+func printWorkspacePlan(plan *domain.WorkspacePlan) {
+	symbols := map[string]string{"create": "+", "update": "~", "delete": "-", "unchanged": "=", "orphaned": "?"}
+	counts := make(map[string]int)
+	for _, change := range plan.Changes {
+		counts[change.Action]++
+		line := fmt.Sprintf("%s %-8s %s", symbols[change.Action], change.Kind, change.Name)
+		if change.Reason != "" {
+			line += " (" + change.Reason + ")"
+		}
+		fmt.Println(line)
+	}
+	format := "Plan for %s: %d to create, %d to update, %d to delete, %d unchanged"
+	if plan.Applied {
+		format = "Applied %s: %d created, %d updated, %d deleted, %d unchanged"
+	}
+	fmt.Printf(format, plan.Workspace, counts["create"], counts["update"], counts["delete"], counts["unchanged"])
+	if counts["orphaned"] > 0 {
+		fmt.Printf(", %d not in spec (use --prune)", counts["orphaned"])
+	}
+	fmt.Println()
+}
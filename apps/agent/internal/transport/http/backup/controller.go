@@ -0,0 +1,134 @@
+package backup
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strconv"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/app"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+	"github.com/gin-gonic/gin"
+)
+
+type BackupHandler struct {
+	app *app.ContainerApp
+}
+
+func NewBackupHandler(app *app.ContainerApp) *BackupHandler {
+	return &BackupHandler{app: app}
+}
+
+func (h *BackupHandler) CreateHandler(c *gin.Context) {
+	var req domain.BackupRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	ctx := context.Background()
+	created, err := h.app.CreateBackup(ctx, c.Param("id"), req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"backup": created})
+}
+
+func (h *BackupHandler) ListHandler(c *gin.Context) {
+	ctx := context.Background()
+	backups, err := h.app.ListBackups(ctx, c.Query("resource"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"backups": backups})
+}
+
+func (h *BackupHandler) DetailsHandler(c *gin.Context) {
+	ctx := context.Background()
+	b, err := h.app.GetBackup(ctx, c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"backup": b})
+}
+
+func (h *BackupHandler) DownloadHandler(c *gin.Context) {
+	ctx := context.Background()
+	b, content, err := h.app.OpenBackup(ctx, c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	defer content.Close()
+
+	c.Header("Content-Disposition", "attachment; filename=\""+filepath.Base(b.File)+"\"")
+	c.Header("Content-Length", strconv.FormatInt(b.SizeBytes, 10))
+	c.Header("Content-Type", "application/octet-stream")
+	c.Status(http.StatusOK)
+	io.Copy(c.Writer, content)
+}
+
+func (h *BackupHandler) RestoreHandler(c *gin.Context) {
+	var req domain.RestoreRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	ctx := context.Background()
+	result, err := h.app.RestoreBackup(ctx, c.Param("id"), req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"restored": result})
+}
+
+func (h *BackupHandler) DeleteHandler(c *gin.Context) {
+	ctx := context.Background()
+	if err := h.app.DeleteBackup(ctx, c.Param("id")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Backup deleted"})
+}
+
+func (h *BackupHandler) ListPoliciesHandler(c *gin.Context) {
+	ctx := context.Background()
+	policies, err := h.app.ListBackupPolicies(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"policies": policies})
+}
+
+func (h *BackupHandler) SetPolicyHandler(c *gin.Context) {
+	var policy domain.BackupPolicy
+	if err := c.ShouldBindJSON(&policy); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx := context.Background()
+	saved, err := h.app.SetBackupPolicy(ctx, c.Param("resource"), policy)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"policy": saved})
+}
+
+func (h *BackupHandler) DeletePolicyHandler(c *gin.Context) {
+	ctx := context.Background()
+	if err := h.app.DeleteBackupPolicy(ctx, c.Param("resource")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Backup policy deleted"})
+}
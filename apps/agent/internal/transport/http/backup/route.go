@@ -0,0 +1,29 @@
+package backup
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+type BackupRouter struct {
+	handler *BackupHandler
+}
+
+func NewBackupRouter(handler *BackupHandler) *BackupRouter {
+	return &BackupRouter{handler: handler}
+}
+
+func (r *BackupRouter) SetupBackupRouter(router *gin.RouterGroup) {
+	router.POST("/containers/:id/backups", r.handler.CreateHandler)
+
+	api := router.Group("/backups")
+	{
+		api.GET("", r.handler.ListHandler)
+		api.GET("/policies", r.handler.ListPoliciesHandler)
+		api.PUT("/policies/:resource", r.handler.SetPolicyHandler)
+		api.DELETE("/policies/:resource", r.handler.DeletePolicyHandler)
+		api.GET("/:id", r.handler.DetailsHandler)
+		api.GET("/:id/download", r.handler.DownloadHandler)
+		api.POST("/:id/restore", r.handler.RestoreHandler)
+		api.DELETE("/:id", r.handler.DeleteHandler)
+	}
+}
@@ -4,9 +4,12 @@ import (
 	"context"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/abhishekkkk-15/devcon/agent/internal/app"
 	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+	"github.com/abhishekkkk-15/devcon/agent/internal/transport/http/response"
 	"github.com/gin-gonic/gin"
 )
 
@@ -30,7 +33,7 @@ func (h *ContainerHandler) ListHandler(c *gin.Context) {
 
 func (h *ContainerHandler) ResourceListHandler(c *gin.Context) {
 	ctx := context.Background()
-	resources, err := h.app.ListResources(ctx)
+	resources, err := h.app.ListResources(ctx, c.Query("group") == "stack", c.Query("trashed") == "true")
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -39,98 +42,259 @@ func (h *ContainerHandler) ResourceListHandler(c *gin.Context) {
 }
 
 func (h *ContainerHandler) StartHandler(c *gin.Context) {
+	h.runStart(c, "start", h.app.Start)
+}
+
+func (h *ContainerHandler) RestartHandler(c *gin.Context) {
+	h.runStart(c, "restart", h.app.Restart)
+}
+
+func (h *ContainerHandler) StopHandler(c *gin.Context) {
+	id := c.Param("id")
+	op := h.app.RunOperation("stop", id, func(ctx context.Context) (any, error) {
+		return nil, h.app.Stop(ctx, id)
+	})
+	c.JSON(http.StatusAccepted, gin.H{"operation": op})
+}
+
+func (h *ContainerHandler) DeleteHandler(c *gin.Context) {
+	var req domain.DeleteRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	id := c.Param("id")
+	op := h.app.RunOperation("delete", id, func(ctx context.Context) (any, error) {
+		return h.app.DeleteResource(ctx, id, req)
+	})
+	c.JSON(http.StatusAccepted, gin.H{"operation": op})
+}
+
+// BulkHandler runs start, stop, restart or delete on many resources. The
+// operation succeeds even when some of them failed; see the per-resource
+// results in its report.
+func (h *ContainerHandler) BulkHandler(c *gin.Context) {
+	var req domain.BulkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	action := c.Param("action")
+	op := h.app.RunOperation("bulk", action, func(ctx context.Context) (any, error) {
+		return h.app.Bulk(ctx, action, req)
+	})
+	c.JSON(http.StatusAccepted, gin.H{"operation": op})
+}
+
+func (h *ContainerHandler) DetailsHandler(c *gin.Context) {
 	id := c.Param("id")
 	ctx := context.Background()
-	if err := h.app.Start(ctx, id); err != nil {
+	details, err := h.app.GetResourceDetails(ctx, id)
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"message": "Container started"})
+	c.JSON(http.StatusOK, gin.H{"resource": details})
 }
 
-func (h *ContainerHandler) RestartHandler(c *gin.Context) {
+func (h *ContainerHandler) LogsHandler(c *gin.Context) {
 	id := c.Param("id")
+	tail, err := strconv.Atoi(c.DefaultQuery("tail", "200"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "tail must be a number"})
+		return
+	}
 	ctx := context.Background()
-	if err := h.app.Restart(ctx, id); err != nil {
+	logs, err := h.app.GetResourceLogs(ctx, id, tail)
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"message": "Container restarted"})
+	c.JSON(http.StatusOK, gin.H{"logs": logs})
 }
 
-func (h *ContainerHandler) StopHandler(c *gin.Context) {
+func (h *ContainerHandler) ConnectionHandler(c *gin.Context) {
 	id := c.Param("id")
+	reveal := c.Query("reveal") == "true"
 	ctx := context.Background()
-	if err := h.app.Stop(ctx, id); err != nil {
+	info, err := h.app.GetConnectionInfo(ctx, id, reveal)
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"message": "Container stopped"})
+	c.JSON(http.StatusOK, gin.H{"connection": info})
 }
 
-func (h *ContainerHandler) DeleteHandler(c *gin.Context) {
+func (h *ContainerHandler) CloneHandler(c *gin.Context) {
+	var req domain.CloneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 	id := c.Param("id")
+	op := h.app.RunOperation("clone", id, func(ctx context.Context) (any, error) {
+		return h.app.CloneResource(ctx, id, req)
+	})
+	c.JSON(http.StatusAccepted, gin.H{"operation": op})
+}
+
+func (h *ContainerHandler) LifecycleHandler(c *gin.Context) {
+	var req domain.LifecycleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 	ctx := context.Background()
-	if err := h.app.Delete(ctx, id); err != nil {
+	lifecycle, err := h.app.SetLifecycle(ctx, c.Param("id"), req)
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"message": "Container deleted"})
+	c.JSON(http.StatusOK, gin.H{"lifecycle": lifecycle})
 }
 
-func (h *ContainerHandler) DetailsHandler(c *gin.Context) {
-	id := c.Param("id")
+func (h *ContainerHandler) ExtendHandler(c *gin.Context) {
+	var req domain.ExtendRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 	ctx := context.Background()
-	details, err := h.app.GetResourceDetails(ctx, id)
+	lifecycle, err := h.app.ExtendLifecycle(ctx, c.Param("id"), req.By)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"resource": details})
+	c.JSON(http.StatusOK, gin.H{"lifecycle": lifecycle})
 }
 
-func (h *ContainerHandler) LogsHandler(c *gin.Context) {
-	id := c.Param("id")
-	tail, err := strconv.Atoi(c.DefaultQuery("tail", "200"))
+func (h *ContainerHandler) DriftListHandler(c *gin.Context) {
+	ctx := context.Background()
+	states, err := h.app.ListDesiredStates(ctx)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "tail must be a number"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"resources": states})
+}
+
+func (h *ContainerHandler) DriftPolicyHandler(c *gin.Context) {
+	var req domain.DriftPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 	ctx := context.Background()
-	logs, err := h.app.GetResourceLogs(ctx, id, tail)
+	state, err := h.app.SetDriftPolicy(ctx, c.Param("id"), req.Policy)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"logs": logs})
+	c.JSON(http.StatusOK, gin.H{"desired_state": state})
+}
+
+func (h *ContainerHandler) ReconcileHandler(c *gin.Context) {
+	ctx := context.Background()
+	results, err := h.app.Reconcile(ctx, c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"results": results})
 }
 
 func (h *ContainerHandler) StartDevconHandler(c *gin.Context) {
+	var cfg domain.ContainerCfg
+	if err := c.ShouldBindJSON(&cfg); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !h.checkCompose(c, &cfg) {
+		return
+	}
+	op := h.app.RunOperation("devcon", cfg.Name, func(ctx context.Context) (any, error) {
+		return h.app.StartDevconWeb(ctx, &cfg)
+	})
+	c.JSON(http.StatusAccepted, gin.H{"operation": op})
+}
+
+func (h *ContainerHandler) CreateHandler(c *gin.Context) {
+	var cfg domain.ContainerCfg
+	if err := c.ShouldBindJSON(&cfg); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !h.checkCompose(c, &cfg) {
+		return
+	}
+	wait := c.Query("wait") == "true"
+	op := h.app.RunOperation("create", cfg.Name, func(ctx context.Context) (any, error) {
+		begin := time.Now()
+		created, err := h.app.CreateResource(ctx, &cfg)
+		if err != nil || !wait {
+			return created, err
+		}
+		created.Readiness, err = h.app.WaitReady(ctx, created.ID, begin)
+		return created, err
+	})
+	c.JSON(http.StatusAccepted, gin.H{"operation": op})
+}
+
+func (h *ContainerHandler) ComposePreviewHandler(c *gin.Context) {
 	var cfg domain.ContainerCfg
 	if err := c.ShouldBindJSON(&cfg); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 	ctx := context.Background()
-	status, err := h.app.StartDevconWeb(ctx, &cfg)
+	preview, err := h.app.PreviewCompose(ctx, &cfg)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		response.WriteError(c, err)
 		return
 	}
-	c.JSON(http.StatusAccepted, status)
+	c.JSON(http.StatusOK, gin.H{"preview": preview})
 }
 
-func (h *ContainerHandler) CreateHandler(c *gin.Context) {
+func (h *ContainerHandler) ComposeConfigHandler(c *gin.Context) {
 	var cfg domain.ContainerCfg
 	if err := c.ShouldBindJSON(&cfg); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 	ctx := context.Background()
-	created, err := h.app.CreateResource(ctx, &cfg)
+	resolved, err := h.app.ResolveCompose(ctx, &cfg)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		response.WriteError(c, err)
 		return
 	}
-	c.JSON(http.StatusAccepted, created)
+	c.JSON(http.StatusOK, gin.H{"resolved": resolved})
+}
+
+// checkCompose answers a compose file that does not validate right away, with
+// its issues, rather than through a failed operation.
+// runStart queues start, which starts or restarts a resource. With
+// ?wait=true the operation also waits for it to be ready and returns the
+// readiness as its result.
+func (h *ContainerHandler) runStart(c *gin.Context, kind string, start func(ctx context.Context, id string) error) {
+	id := c.Param("id")
+	wait := c.Query("wait") == "true"
+	op := h.app.RunOperation(kind, id, func(ctx context.Context) (any, error) {
+		begin := time.Now()
+		if err := start(ctx, id); err != nil || !wait {
+			return nil, err
+		}
+		return h.app.WaitReady(ctx, id, begin)
+	})
+	c.JSON(http.StatusAccepted, gin.H{"operation": op})
+}
+
+func (h *ContainerHandler) checkCompose(c *gin.Context, cfg *domain.ContainerCfg) bool {
+	if strings.TrimSpace(cfg.Compose) == "" {
+		return true
+	}
+	if _, err := h.app.ResolveCompose(context.Background(), cfg); err != nil {
+		response.WriteError(c, err)
+		return false
+	}
+	return true
 }
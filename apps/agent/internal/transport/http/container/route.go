@@ -21,11 +21,21 @@ func (r *ContainerRouter) SetupContainerRouter(router *gin.RouterGroup) {
 		api.GET("/resources", r.handler.ResourceListHandler)
 		api.GET("/:id", r.handler.DetailsHandler)
 		api.GET("/:id/logs", r.handler.LogsHandler)
+		api.GET("/:id/connection", r.handler.ConnectionHandler)
+		api.GET("/drift", r.handler.DriftListHandler)
+		api.PUT("/:id/drift-policy", r.handler.DriftPolicyHandler)
+		api.POST("/:id/reconcile", r.handler.ReconcileHandler)
+		api.PUT("/:id/lifecycle", r.handler.LifecycleHandler)
+		api.POST("/:id/extend", r.handler.ExtendHandler)
 		api.POST("", r.handler.CreateHandler)
+		api.POST("/:id/clone", r.handler.CloneHandler)
 		api.POST("/start/:id", r.handler.StartHandler)
 		api.POST("/restart/:id", r.handler.RestartHandler)
 		api.POST("/stop/:id", r.handler.StopHandler)
+		api.POST("/bulk/:action", r.handler.BulkHandler)
 		api.DELETE("/:id", r.handler.DeleteHandler)
 		api.POST("/devcon", r.handler.StartDevconHandler)
+		api.POST("/compose/preview", r.handler.ComposePreviewHandler)
+		api.POST("/compose/config", r.handler.ComposeConfigHandler)
 	}
 }
@@ -0,0 +1,41 @@
+package events
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/app"
+	"github.com/gin-gonic/gin"
+)
+
+type EventsHandler struct {
+	app *app.ContainerApp
+}
+
+func NewEventsHandler(app *app.ContainerApp) *EventsHandler {
+	return &EventsHandler{app: app}
+}
+
+func (h *EventsHandler) ListHandler(c *gin.Context) {
+	since, err := strconv.ParseInt(c.DefaultQuery("since", "0"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid since"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"events": h.app.Events(since)})
+}
+
+// StreamHandler sends events as server-sent events until the client goes
+// away.
+func (h *EventsHandler) StreamHandler(c *gin.Context) {
+	events := h.app.SubscribeEvents(c.Request.Context())
+	c.Stream(func(w io.Writer) bool {
+		event, ok := <-events
+		if !ok {
+			return false
+		}
+		c.SSEvent(event.Kind, event)
+		return true
+	})
+}
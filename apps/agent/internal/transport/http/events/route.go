@@ -0,0 +1,21 @@
+package events
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+type EventsRouter struct {
+	handler *EventsHandler
+}
+
+func NewEventsRouter(handler *EventsHandler) *EventsRouter {
+	return &EventsRouter{handler: handler}
+}
+
+func (r *EventsRouter) SetupEventsRouter(router *gin.RouterGroup) {
+	api := router.Group("/events")
+	{
+		api.GET("", r.handler.ListHandler)
+		api.GET("/stream", r.handler.StreamHandler)
+	}
+}
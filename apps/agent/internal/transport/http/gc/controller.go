@@ -0,0 +1,101 @@
+package gc
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/app"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+	"github.com/gin-gonic/gin"
+)
+
+type GCHandler struct {
+	app *app.ContainerApp
+}
+
+func NewGCHandler(app *app.ContainerApp) *GCHandler {
+	return &GCHandler{app: app}
+}
+
+// PlanHandler reports what a collection would remove without removing it.
+func (h *GCHandler) PlanHandler(c *gin.Context) {
+	req := domain.GCRequest{MaxAge: c.Query("max_age"), DryRun: true}
+	if value := c.Query("volumes"); value != "" {
+		volumes, err := strconv.ParseBool(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		req.Volumes = &volumes
+	}
+	ctx := context.Background()
+	report, err := h.app.RunGC(ctx, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"report": report})
+}
+
+func (h *GCHandler) RunHandler(c *gin.Context) {
+	var req domain.GCRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	ctx := context.Background()
+	report, err := h.app.RunGC(ctx, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"report": report})
+}
+
+func (h *GCHandler) ConfigHandler(c *gin.Context) {
+	ctx := context.Background()
+	cfg, err := h.app.GetGCConfig(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"config": cfg})
+}
+
+func (h *GCHandler) UpdateConfigHandler(c *gin.Context) {
+	var req domain.GCConfigRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx := context.Background()
+	cfg, err := h.app.UpdateGCConfig(ctx, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"config": cfg})
+}
+
+func (h *GCHandler) ProtectHandler(c *gin.Context) {
+	ctx := context.Background()
+	cfg, err := h.app.ProtectArtifact(ctx, c.Param("name"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"config": cfg})
+}
+
+func (h *GCHandler) UnprotectHandler(c *gin.Context) {
+	ctx := context.Background()
+	cfg, err := h.app.UnprotectArtifact(ctx, c.Param("name"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"config": cfg})
+}
@@ -0,0 +1,25 @@
+package gc
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+type GCRouter struct {
+	handler *GCHandler
+}
+
+func NewGCRouter(handler *GCHandler) *GCRouter {
+	return &GCRouter{handler: handler}
+}
+
+func (r *GCRouter) SetupGCRouter(router *gin.RouterGroup) {
+	api := router.Group("/gc")
+	{
+		api.GET("", r.handler.PlanHandler)
+		api.POST("", r.handler.RunHandler)
+		api.GET("/config", r.handler.ConfigHandler)
+		api.PUT("/config", r.handler.UpdateConfigHandler)
+		api.POST("/protected/:name", r.handler.ProtectHandler)
+		api.DELETE("/protected/:name", r.handler.UnprotectHandler)
+	}
+}
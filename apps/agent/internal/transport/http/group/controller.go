@@ -0,0 +1,105 @@
+package group
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/app"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+	"github.com/gin-gonic/gin"
+)
+
+type GroupHandler struct {
+	app *app.ContainerApp
+}
+
+func NewGroupHandler(app *app.ContainerApp) *GroupHandler {
+	return &GroupHandler{app: app}
+}
+
+func (h *GroupHandler) ListHandler(c *gin.Context) {
+	ctx := context.Background()
+	groups, err := h.app.ListGroups(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"groups": groups})
+}
+
+func (h *GroupHandler) GetHandler(c *gin.Context) {
+	ctx := context.Background()
+	group, err := h.app.GetGroup(ctx, c.Param("name"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"group": group})
+}
+
+func (h *GroupHandler) AddMembersHandler(c *gin.Context) {
+	var req domain.GroupMembersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx := context.Background()
+	result, err := h.app.AddGroupMembers(ctx, c.Param("name"), req.Resources)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error(), "members": result})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"members": result})
+}
+
+func (h *GroupHandler) RemoveMemberHandler(c *gin.Context) {
+	ctx := context.Background()
+	result, err := h.app.RemoveGroupMembers(ctx, c.Param("name"), []string{c.Param("resource")})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error(), "members": result})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"members": result})
+}
+
+func (h *GroupHandler) StartHandler(c *gin.Context) {
+	h.action(c, domain.BulkStart)
+}
+
+func (h *GroupHandler) StopHandler(c *gin.Context) {
+	h.action(c, domain.BulkStop)
+}
+
+func (h *GroupHandler) RestartHandler(c *gin.Context) {
+	h.action(c, domain.BulkRestart)
+}
+
+func (h *GroupHandler) DeleteHandler(c *gin.Context) {
+	h.action(c, domain.BulkDelete)
+}
+
+func (h *GroupHandler) action(c *gin.Context, action string) {
+	ctx := context.Background()
+	report, err := h.app.GroupAction(ctx, c.Param("name"), action)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"report": report})
+}
+
+func (h *GroupHandler) LogsHandler(c *gin.Context) {
+	tail, err := strconv.Atoi(c.DefaultQuery("tail", "200"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "tail must be a number"})
+		return
+	}
+	ctx := context.Background()
+	logs, err := h.app.GetGroupLogs(ctx, c.Param("name"), tail)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"logs": logs})
+}
@@ -0,0 +1,28 @@
+package group
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+type GroupRouter struct {
+	handler *GroupHandler
+}
+
+func NewGroupRouter(handler *GroupHandler) *GroupRouter {
+	return &GroupRouter{handler: handler}
+}
+
+func (r *GroupRouter) SetupGroupRouter(router *gin.RouterGroup) {
+	api := router.Group("/groups")
+	{
+		api.GET("", r.handler.ListHandler)
+		api.GET("/:name", r.handler.GetHandler)
+		api.GET("/:name/logs", r.handler.LogsHandler)
+		api.POST("/:name/members", r.handler.AddMembersHandler)
+		api.DELETE("/:name/members/:resource", r.handler.RemoveMemberHandler)
+		api.POST("/:name/start", r.handler.StartHandler)
+		api.POST("/:name/stop", r.handler.StopHandler)
+		api.POST("/:name/restart", r.handler.RestartHandler)
+		api.DELETE("/:name", r.handler.DeleteHandler)
+	}
+}
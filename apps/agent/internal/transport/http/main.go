@@ -3,8 +3,20 @@ package http
 import (
 	"github.com/abhishekkkk-15/devcon/agent/internal/app"
 	"github.com/abhishekkkk-15/devcon/agent/internal/core/util"
+	backupRouter "github.com/abhishekkkk-15/devcon/agent/internal/transport/http/backup"
 	containerRouter "github.com/abhishekkkk-15/devcon/agent/internal/transport/http/container"
+	eventsRouter "github.com/abhishekkkk-15/devcon/agent/internal/transport/http/events"
+	gcRouter "github.com/abhishekkkk-15/devcon/agent/internal/transport/http/gc"
+	groupRouter "github.com/abhishekkkk-15/devcon/agent/internal/transport/http/group"
+	operationRouter "github.com/abhishekkkk-15/devcon/agent/internal/transport/http/operation"
+	postgresRouter "github.com/abhishekkkk-15/devcon/agent/internal/transport/http/postgres"
+	redisRouter "github.com/abhishekkkk-15/devcon/agent/internal/transport/http/redis"
+	scheduleRouter "github.com/abhishekkkk-15/devcon/agent/internal/transport/http/schedule"
+	stackRouter "github.com/abhishekkkk-15/devcon/agent/internal/transport/http/stack"
 	systemRouter "github.com/abhishekkkk-15/devcon/agent/internal/transport/http/system"
+	templateRouter "github.com/abhishekkkk-15/devcon/agent/internal/transport/http/template"
+	trashRouter "github.com/abhishekkkk-15/devcon/agent/internal/transport/http/trash"
+	workspaceRouter "github.com/abhishekkkk-15/devcon/agent/internal/transport/http/workspace"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 )
@@ -12,6 +24,18 @@ import (
 func SetupRouter(systemApp *app.SystemApp, containerApp *app.ContainerApp) *gin.Engine {
 	sysHandler := systemRouter.NewSystemHandler(systemApp)
 	conHandler := containerRouter.NewContainerHandler(containerApp)
+	stkHandler := stackRouter.NewStackHandler(containerApp)
+	tplHandler := templateRouter.NewTemplateHandler(containerApp)
+	pgHandler := postgresRouter.NewPostgresHandler(containerApp)
+	rdsHandler := redisRouter.NewRedisHandler(containerApp)
+	bkpHandler := backupRouter.NewBackupHandler(containerApp)
+	wsHandler := workspaceRouter.NewWorkspaceHandler(containerApp)
+	evtHandler := eventsRouter.NewEventsHandler(containerApp)
+	schHandler := scheduleRouter.NewScheduleHandler(containerApp)
+	gcHandler := gcRouter.NewGCHandler(containerApp)
+	opHandler := operationRouter.NewOperationHandler(containerApp)
+	trHandler := trashRouter.NewTrashHandler(containerApp)
+	grpHandler := groupRouter.NewGroupHandler(containerApp)
 
 	env := util.GodotEnv("ENV")
 
@@ -39,5 +63,41 @@ func SetupRouter(systemApp *app.SystemApp, containerApp *app.ContainerApp) *gin.
 	conRouter := containerRouter.NewContainerRouter(conHandler)
 	conRouter.SetupContainerRouter(api)
 
+	stkRouter := stackRouter.NewStackRouter(stkHandler)
+	stkRouter.SetupStackRouter(api)
+
+	tplRouter := templateRouter.NewTemplateRouter(tplHandler)
+	tplRouter.SetupTemplateRouter(api)
+
+	pgRouter := postgresRouter.NewPostgresRouter(pgHandler)
+	pgRouter.SetupPostgresRouter(api)
+
+	rdsRouter := redisRouter.NewRedisRouter(rdsHandler)
+	rdsRouter.SetupRedisRouter(api)
+
+	bkpRouter := backupRouter.NewBackupRouter(bkpHandler)
+	bkpRouter.SetupBackupRouter(api)
+
+	wsRouter := workspaceRouter.NewWorkspaceRouter(wsHandler)
+	wsRouter.SetupWorkspaceRouter(api)
+
+	evtRouter := eventsRouter.NewEventsRouter(evtHandler)
+	evtRouter.SetupEventsRouter(api)
+
+	schRouter := scheduleRouter.NewScheduleRouter(schHandler)
+	schRouter.SetupScheduleRouter(api)
+
+	gcRtr := gcRouter.NewGCRouter(gcHandler)
+	gcRtr.SetupGCRouter(api)
+
+	opRouter := operationRouter.NewOperationRouter(opHandler)
+	opRouter.SetupOperationRouter(api)
+
+	trRouter := trashRouter.NewTrashRouter(trHandler)
+	trRouter.SetupTrashRouter(api)
+
+	grpRouter := groupRouter.NewGroupRouter(grpHandler)
+	grpRouter.SetupGroupRouter(api)
+
 	return router
 }
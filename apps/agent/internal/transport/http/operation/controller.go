@@ -0,0 +1,57 @@
+package operation
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/app"
+	"github.com/gin-gonic/gin"
+)
+
+type OperationHandler struct {
+	app *app.ContainerApp
+}
+
+func NewOperationHandler(app *app.ContainerApp) *OperationHandler {
+	return &OperationHandler{app: app}
+}
+
+func (h *OperationHandler) ListHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"operations": h.app.ListOperations()})
+}
+
+func (h *OperationHandler) DetailsHandler(c *gin.Context) {
+	op, err := h.app.GetOperation(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"operation": op})
+}
+
+// StreamHandler sends the operation as server-sent events whenever it
+// changes, ending once it has finished.
+func (h *OperationHandler) StreamHandler(c *gin.Context) {
+	updates, err := h.app.WatchOperation(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	c.Stream(func(w io.Writer) bool {
+		op, ok := <-updates
+		if !ok {
+			return false
+		}
+		c.SSEvent(op.Status, op)
+		return true
+	})
+}
+
+func (h *OperationHandler) CancelHandler(c *gin.Context) {
+	op, err := h.app.CancelOperation(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusAccepted, gin.H{"operation": op})
+}
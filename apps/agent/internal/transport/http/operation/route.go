@@ -0,0 +1,23 @@
+package operation
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+type OperationRouter struct {
+	handler *OperationHandler
+}
+
+func NewOperationRouter(handler *OperationHandler) *OperationRouter {
+	return &OperationRouter{handler: handler}
+}
+
+func (r *OperationRouter) SetupOperationRouter(router *gin.RouterGroup) {
+	api := router.Group("/operations")
+	{
+		api.GET("", r.handler.ListHandler)
+		api.GET("/:id", r.handler.DetailsHandler)
+		api.GET("/:id/stream", r.handler.StreamHandler)
+		api.POST("/:id/cancel", r.handler.CancelHandler)
+	}
+}
@@ -0,0 +1,119 @@
+package postgres
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/app"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+	"github.com/gin-gonic/gin"
+)
+
+type PostgresHandler struct {
+	app *app.ContainerApp
+}
+
+func NewPostgresHandler(app *app.ContainerApp) *PostgresHandler {
+	return &PostgresHandler{app: app}
+}
+
+func (h *PostgresHandler) ListDatabasesHandler(c *gin.Context) {
+	ctx := context.Background()
+	databases, err := h.app.ListPostgresDatabases(ctx, c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"databases": databases})
+}
+
+func (h *PostgresHandler) CreateDatabaseHandler(c *gin.Context) {
+	var req domain.PostgresDatabaseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx := context.Background()
+	if err := h.app.CreatePostgresDatabase(ctx, c.Param("id"), req); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"message": "Database created"})
+}
+
+func (h *PostgresHandler) DropDatabaseHandler(c *gin.Context) {
+	ctx := context.Background()
+	if err := h.app.DropPostgresDatabase(ctx, c.Param("id"), c.Param("name"), c.Query("force") == "true"); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Database dropped"})
+}
+
+func (h *PostgresHandler) ListRolesHandler(c *gin.Context) {
+	ctx := context.Background()
+	roles, err := h.app.ListPostgresRoles(ctx, c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"roles": roles})
+}
+
+func (h *PostgresHandler) CreateRoleHandler(c *gin.Context) {
+	var req domain.PostgresRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx := context.Background()
+	if err := h.app.CreatePostgresRole(ctx, c.Param("id"), req); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"message": "Role created"})
+}
+
+func (h *PostgresHandler) DropRoleHandler(c *gin.Context) {
+	ctx := context.Background()
+	if err := h.app.DropPostgresRole(ctx, c.Param("id"), c.Param("name")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Role dropped"})
+}
+
+func (h *PostgresHandler) QueryHandler(c *gin.Context) {
+	var req domain.PostgresQueryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx := context.Background()
+	result, err := h.app.PostgresQuery(ctx, c.Param("id"), req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"result": result})
+}
+
+func (h *PostgresHandler) ConnectionsHandler(c *gin.Context) {
+	ctx := context.Background()
+	connections, err := h.app.PostgresConnections(ctx, c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"connections": connections})
+}
+
+func (h *PostgresHandler) TableSizesHandler(c *gin.Context) {
+	ctx := context.Background()
+	tables, err := h.app.PostgresTableSizes(ctx, c.Param("id"), c.Query("database"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"tables": tables})
+}
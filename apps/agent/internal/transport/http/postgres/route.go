@@ -0,0 +1,28 @@
+package postgres
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+type PostgresRouter struct {
+	handler *PostgresHandler
+}
+
+func NewPostgresRouter(handler *PostgresHandler) *PostgresRouter {
+	return &PostgresRouter{handler: handler}
+}
+
+func (r *PostgresRouter) SetupPostgresRouter(router *gin.RouterGroup) {
+	api := router.Group("/containers/:id/postgres")
+	{
+		api.GET("/databases", r.handler.ListDatabasesHandler)
+		api.POST("/databases", r.handler.CreateDatabaseHandler)
+		api.DELETE("/databases/:name", r.handler.DropDatabaseHandler)
+		api.GET("/roles", r.handler.ListRolesHandler)
+		api.POST("/roles", r.handler.CreateRoleHandler)
+		api.DELETE("/roles/:name", r.handler.DropRoleHandler)
+		api.POST("/query", r.handler.QueryHandler)
+		api.GET("/connections", r.handler.ConnectionsHandler)
+		api.GET("/tables", r.handler.TableSizesHandler)
+	}
+}
@@ -0,0 +1,108 @@
+package redis
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/app"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+	"github.com/gin-gonic/gin"
+)
+
+type RedisHandler struct {
+	app *app.ContainerApp
+}
+
+func NewRedisHandler(app *app.ContainerApp) *RedisHandler {
+	return &RedisHandler{app: app}
+}
+
+func (h *RedisHandler) InfoHandler(c *gin.Context) {
+	ctx := context.Background()
+	sections, err := h.app.RedisInfo(ctx, c.Param("id"), c.Query("section"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"sections": sections})
+}
+
+func (h *RedisHandler) KeyspaceHandler(c *gin.Context) {
+	ctx := context.Background()
+	keyspace, err := h.app.RedisKeyspace(ctx, c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"keyspace": keyspace})
+}
+
+func (h *RedisHandler) ScanHandler(c *gin.Context) {
+	db, err := strconv.Atoi(c.DefaultQuery("db", "0"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "db must be a number"})
+		return
+	}
+	count, err := strconv.Atoi(c.DefaultQuery("count", "0"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "count must be a number"})
+		return
+	}
+	req := domain.RedisScanRequest{DB: db, Pattern: c.Query("pattern"), Cursor: c.Query("cursor"), Count: count}
+	ctx := context.Background()
+	result, err := h.app.RedisScanKeys(ctx, c.Param("id"), req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"scan": result})
+}
+
+func (h *RedisHandler) ValueHandler(c *gin.Context) {
+	db, err := strconv.Atoi(c.DefaultQuery("db", "0"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "db must be a number"})
+		return
+	}
+	ctx := context.Background()
+	value, err := h.app.RedisGetValue(ctx, c.Param("id"), db, c.Query("key"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"value": value})
+}
+
+func (h *RedisHandler) DeleteHandler(c *gin.Context) {
+	var req domain.RedisDeleteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx := context.Background()
+	deleted, err := h.app.RedisDeleteKeys(ctx, c.Param("id"), req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"deleted": deleted})
+}
+
+func (h *RedisHandler) FlushDBHandler(c *gin.Context) {
+	db, err := strconv.Atoi(c.DefaultQuery("db", "0"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "db must be a number"})
+		return
+	}
+	if c.Query("confirm") != "true" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "flushdb requires confirm=true"})
+		return
+	}
+	ctx := context.Background()
+	if err := h.app.RedisFlushDB(ctx, c.Param("id"), db, true); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Database flushed"})
+}
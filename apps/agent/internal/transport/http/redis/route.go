@@ -0,0 +1,25 @@
+package redis
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+type RedisRouter struct {
+	handler *RedisHandler
+}
+
+func NewRedisRouter(handler *RedisHandler) *RedisRouter {
+	return &RedisRouter{handler: handler}
+}
+
+func (r *RedisRouter) SetupRedisRouter(router *gin.RouterGroup) {
+	api := router.Group("/containers/:id/redis")
+	{
+		api.GET("/info", r.handler.InfoHandler)
+		api.GET("/keyspace", r.handler.KeyspaceHandler)
+		api.GET("/keys", r.handler.ScanHandler)
+		api.GET("/value", r.handler.ValueHandler)
+		api.POST("/keys/delete", r.handler.DeleteHandler)
+		api.POST("/flushdb", r.handler.FlushDBHandler)
+	}
+}
@@ -0,0 +1,20 @@
+package response
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+	"github.com/gin-gonic/gin"
+)
+
+// WriteError reports compose validation errors as 422 with their issues and
+// anything else as 500.
+func WriteError(c *gin.Context, err error) {
+	var validationErr *domain.ComposeValidationError
+	if errors.As(err, &validationErr) {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error(), "issues": validationErr.Issues})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
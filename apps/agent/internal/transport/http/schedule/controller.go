@@ -0,0 +1,97 @@
+package schedule
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/app"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+	"github.com/gin-gonic/gin"
+)
+
+type ScheduleHandler struct {
+	app *app.ContainerApp
+}
+
+func NewScheduleHandler(app *app.ContainerApp) *ScheduleHandler {
+	return &ScheduleHandler{app: app}
+}
+
+func (h *ScheduleHandler) ListHandler(c *gin.Context) {
+	ctx := context.Background()
+	schedules, err := h.app.ListSchedules(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"schedules": schedules})
+}
+
+func (h *ScheduleHandler) CreateHandler(c *gin.Context) {
+	var req domain.ScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx := context.Background()
+	created, err := h.app.CreateSchedule(ctx, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"schedule": created})
+}
+
+func (h *ScheduleHandler) DetailsHandler(c *gin.Context) {
+	ctx := context.Background()
+	s, err := h.app.GetSchedule(ctx, c.Param("name"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"schedule": s})
+}
+
+func (h *ScheduleHandler) UpdateHandler(c *gin.Context) {
+	var req domain.ScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx := context.Background()
+	updated, err := h.app.UpdateSchedule(ctx, c.Param("name"), req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"schedule": updated})
+}
+
+func (h *ScheduleHandler) DeleteHandler(c *gin.Context) {
+	ctx := context.Background()
+	if err := h.app.DeleteSchedule(ctx, c.Param("name")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Schedule deleted"})
+}
+
+func (h *ScheduleHandler) RunsHandler(c *gin.Context) {
+	ctx := context.Background()
+	runs, err := h.app.ListScheduleRuns(ctx, c.Param("name"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"runs": runs})
+}
+
+func (h *ScheduleHandler) RunHandler(c *gin.Context) {
+	ctx := context.Background()
+	run, err := h.app.RunSchedule(ctx, c.Param("name"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"run": run})
+}
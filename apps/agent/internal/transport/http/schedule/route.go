@@ -0,0 +1,26 @@
+package schedule
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+type ScheduleRouter struct {
+	handler *ScheduleHandler
+}
+
+func NewScheduleRouter(handler *ScheduleHandler) *ScheduleRouter {
+	return &ScheduleRouter{handler: handler}
+}
+
+func (r *ScheduleRouter) SetupScheduleRouter(router *gin.RouterGroup) {
+	api := router.Group("/schedules")
+	{
+		api.GET("", r.handler.ListHandler)
+		api.POST("", r.handler.CreateHandler)
+		api.GET("/:name", r.handler.DetailsHandler)
+		api.PUT("/:name", r.handler.UpdateHandler)
+		api.DELETE("/:name", r.handler.DeleteHandler)
+		api.GET("/:name/runs", r.handler.RunsHandler)
+		api.POST("/:name/run", r.handler.RunHandler)
+	}
+}
@@ -0,0 +1,245 @@
+package stack
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/app"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+	"github.com/abhishekkkk-15/devcon/agent/internal/transport/http/response"
+	"github.com/gin-gonic/gin"
+)
+
+type StackHandler struct {
+	app *app.ContainerApp
+}
+
+func NewStackHandler(app *app.ContainerApp) *StackHandler {
+	return &StackHandler{app: app}
+}
+
+type scaleRequest struct {
+	Replicas *int `json:"replicas" binding:"required"`
+}
+
+type applyRequest struct {
+	Compose   string   `json:"compose" binding:"required"`
+	Overrides []string `json:"overrides"`
+	Profiles  []string `json:"profiles"`
+	EnvFile   string   `json:"env_file"`
+}
+
+type rollbackRequest struct {
+	Version int `json:"version"`
+}
+
+func (h *StackHandler) StartHandler(c *gin.Context) {
+	ctx := context.Background()
+	if err := h.app.StartStack(ctx, c.Param("project")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Stack started"})
+}
+
+func (h *StackHandler) StopHandler(c *gin.Context) {
+	ctx := context.Background()
+	if err := h.app.StopStack(ctx, c.Param("project")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Stack stopped"})
+}
+
+func (h *StackHandler) RestartHandler(c *gin.Context) {
+	ctx := context.Background()
+	if err := h.app.RestartStack(ctx, c.Param("project")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Stack restarted"})
+}
+
+func (h *StackHandler) DownHandler(c *gin.Context) {
+	opts := domain.StackDownOptions{
+		Volumes: c.Query("volumes") == "true",
+		Images:  c.Query("images") == "true",
+		DryRun:  c.Query("dry_run") == "true",
+	}
+	project := c.Param("project")
+	op := h.app.RunOperation("down", project, func(ctx context.Context) (any, error) {
+		return h.app.DownStack(ctx, project, opts)
+	})
+	c.JSON(http.StatusAccepted, gin.H{"operation": op})
+}
+
+func (h *StackHandler) StartServiceHandler(c *gin.Context) {
+	ctx := context.Background()
+	if err := h.app.StartService(ctx, c.Param("project"), c.Param("service")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Service started"})
+}
+
+func (h *StackHandler) StopServiceHandler(c *gin.Context) {
+	ctx := context.Background()
+	if err := h.app.StopService(ctx, c.Param("project"), c.Param("service")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Service stopped"})
+}
+
+func (h *StackHandler) RestartServiceHandler(c *gin.Context) {
+	ctx := context.Background()
+	if err := h.app.RestartService(ctx, c.Param("project"), c.Param("service")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Service restarted"})
+}
+
+func (h *StackHandler) RecreateServiceHandler(c *gin.Context) {
+	ctx := context.Background()
+	if err := h.app.RecreateService(ctx, c.Param("project"), c.Param("service")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Service recreated"})
+}
+
+func (h *StackHandler) ServiceLogsHandler(c *gin.Context) {
+	tail, err := strconv.Atoi(c.DefaultQuery("tail", "200"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "tail must be a number"})
+		return
+	}
+	ctx := context.Background()
+	logs, err := h.app.GetServiceLogs(ctx, c.Param("project"), c.Param("service"), tail)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"logs": logs})
+}
+
+func (h *StackHandler) ScaleServiceHandler(c *gin.Context) {
+	var req scaleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx := context.Background()
+	result, err := h.app.ScaleService(ctx, c.Param("project"), c.Param("service"), *req.Replicas)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"scale": result})
+}
+
+func (h *StackHandler) ApplyHandler(c *gin.Context) {
+	var req applyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	project := c.Param("project")
+	source := domain.StackSource{
+		Compose:   req.Compose,
+		Overrides: req.Overrides,
+		Profiles:  req.Profiles,
+		EnvFile:   req.EnvFile,
+	}
+	if err := h.app.CheckStack(project, source); err != nil {
+		response.WriteError(c, err)
+		return
+	}
+	op := h.app.RunOperation("apply", project, func(ctx context.Context) (any, error) {
+		return h.app.ApplyStack(ctx, project, source)
+	})
+	c.JSON(http.StatusAccepted, gin.H{"operation": op})
+}
+
+func (h *StackHandler) DefinitionHandler(c *gin.Context) {
+	ctx := context.Background()
+	stack, err := h.app.GetStackDefinition(ctx, c.Param("project"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"project":  stack.Project,
+		"current":  stack.Current(),
+		"previous": stack.Previous(),
+	})
+}
+
+func (h *StackHandler) DefinitionsHandler(c *gin.Context) {
+	ctx := context.Background()
+	stack, err := h.app.GetStackDefinition(ctx, c.Param("project"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"versions": stack.Versions})
+}
+
+func (h *StackHandler) DefinitionVersionHandler(c *gin.Context) {
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "version must be a number"})
+		return
+	}
+	ctx := context.Background()
+	v, err := h.app.GetStackVersion(ctx, c.Param("project"), version)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"definition": v})
+}
+
+func (h *StackHandler) RollbackHandler(c *gin.Context) {
+	var req rollbackRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	project := c.Param("project")
+	op := h.app.RunOperation("rollback", project, func(ctx context.Context) (any, error) {
+		return h.app.RollbackStack(ctx, project, req.Version)
+	})
+	c.JSON(http.StatusAccepted, gin.H{"operation": op})
+}
+
+func (h *StackHandler) ImportHandler(c *gin.Context) {
+	var req domain.StackImport
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	target := strings.TrimSpace(req.Name)
+	if target == "" {
+		target = req.Path
+	}
+	op := h.app.RunOperation("import", target, func(ctx context.Context) (any, error) {
+		return h.app.ImportStack(ctx, req)
+	})
+	c.JSON(http.StatusAccepted, gin.H{"operation": op})
+}
+
+func (h *StackHandler) ReloadHandler(c *gin.Context) {
+	ctx := context.Background()
+	result, err := h.app.ReloadStack(ctx, c.Param("project"))
+	if err != nil {
+		response.WriteError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"applied": result})
+}
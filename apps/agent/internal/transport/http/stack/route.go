@@ -0,0 +1,38 @@
+package stack
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+type StackRouter struct {
+	handler *StackHandler
+}
+
+func NewStackRouter(handler *StackHandler) *StackRouter {
+	return &StackRouter{handler: handler}
+}
+
+func (r *StackRouter) SetupStackRouter(router *gin.RouterGroup) {
+	router.POST("/stacks/import", r.handler.ImportHandler)
+
+	api := router.Group("/stacks/:project")
+	{
+		api.POST("/start", r.handler.StartHandler)
+		api.POST("/stop", r.handler.StopHandler)
+		api.POST("/restart", r.handler.RestartHandler)
+		api.DELETE("", r.handler.DownHandler)
+		api.PUT("", r.handler.ApplyHandler)
+		api.GET("/definition", r.handler.DefinitionHandler)
+		api.GET("/definitions", r.handler.DefinitionsHandler)
+		api.GET("/definitions/:version", r.handler.DefinitionVersionHandler)
+		api.POST("/rollback", r.handler.RollbackHandler)
+		api.POST("/reload", r.handler.ReloadHandler)
+
+		api.POST("/services/:service/start", r.handler.StartServiceHandler)
+		api.POST("/services/:service/stop", r.handler.StopServiceHandler)
+		api.POST("/services/:service/restart", r.handler.RestartServiceHandler)
+		api.POST("/services/:service/recreate", r.handler.RecreateServiceHandler)
+		api.POST("/services/:service/scale", r.handler.ScaleServiceHandler)
+		api.GET("/services/:service/logs", r.handler.ServiceLogsHandler)
+	}
+}
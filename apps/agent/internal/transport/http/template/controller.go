@@ -0,0 +1,58 @@
+package template
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/app"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+	"github.com/gin-gonic/gin"
+)
+
+type TemplateHandler struct {
+	app *app.ContainerApp
+}
+
+func NewTemplateHandler(app *app.ContainerApp) *TemplateHandler {
+	return &TemplateHandler{app: app}
+}
+
+func (h *TemplateHandler) ListHandler(c *gin.Context) {
+	ctx := context.Background()
+	templates, err := h.app.ListTemplates(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"templates": templates})
+}
+
+func (h *TemplateHandler) DetailsHandler(c *gin.Context) {
+	ctx := context.Background()
+	t, err := h.app.GetTemplate(ctx, c.Param("name"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"template": t})
+}
+
+func (h *TemplateHandler) CreateHandler(c *gin.Context) {
+	var req domain.TemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	name, wait := c.Param("name"), c.Query("wait") == "true"
+	op := h.app.RunOperation("template", name, func(ctx context.Context) (any, error) {
+		begin := time.Now()
+		instance, err := h.app.CreateFromTemplate(ctx, name, req)
+		if err != nil || !wait {
+			return instance, err
+		}
+		instance.Resource.Readiness, err = h.app.WaitReady(ctx, instance.Resource.ID, begin)
+		return instance, err
+	})
+	c.JSON(http.StatusAccepted, gin.H{"operation": op})
+}
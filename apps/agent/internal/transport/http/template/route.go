@@ -0,0 +1,22 @@
+package template
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+type TemplateRouter struct {
+	handler *TemplateHandler
+}
+
+func NewTemplateRouter(handler *TemplateHandler) *TemplateRouter {
+	return &TemplateRouter{handler: handler}
+}
+
+func (r *TemplateRouter) SetupTemplateRouter(router *gin.RouterGroup) {
+	api := router.Group("/templates")
+	{
+		api.GET("", r.handler.ListHandler)
+		api.GET("/:name", r.handler.DetailsHandler)
+		api.POST("/:name/resources", r.handler.CreateHandler)
+	}
+}
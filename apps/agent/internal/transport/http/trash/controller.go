@@ -0,0 +1,46 @@
+package trash
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/app"
+	"github.com/gin-gonic/gin"
+)
+
+type TrashHandler struct {
+	app *app.ContainerApp
+}
+
+func NewTrashHandler(app *app.ContainerApp) *TrashHandler {
+	return &TrashHandler{app: app}
+}
+
+func (h *TrashHandler) ListHandler(c *gin.Context) {
+	ctx := context.Background()
+	entries, err := h.app.ListTrash(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"trash": entries})
+}
+
+func (h *TrashHandler) RestoreHandler(c *gin.Context) {
+	ctx := context.Background()
+	entry, err := h.app.RestoreResource(ctx, c.Param("name"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Resource restored", "restored": entry})
+}
+
+func (h *TrashHandler) PurgeHandler(c *gin.Context) {
+	ctx := context.Background()
+	if err := h.app.PurgeResource(ctx, c.Param("name")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Resource purged"})
+}
@@ -0,0 +1,22 @@
+package trash
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+type TrashRouter struct {
+	handler *TrashHandler
+}
+
+func NewTrashRouter(handler *TrashHandler) *TrashRouter {
+	return &TrashRouter{handler: handler}
+}
+
+func (r *TrashRouter) SetupTrashRouter(router *gin.RouterGroup) {
+	api := router.Group("/trash")
+	{
+		api.GET("", r.handler.ListHandler)
+		api.POST("/:name/restore", r.handler.RestoreHandler)
+		api.DELETE("/:name", r.handler.PurgeHandler)
+	}
+}
@@ -0,0 +1,48 @@
+package workspace
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/abhishekkkk-15/devcon/agent/internal/app"
+	"github.com/abhishekkkk-15/devcon/agent/internal/core/domain"
+	"github.com/gin-gonic/gin"
+)
+
+type WorkspaceHandler struct {
+	app *app.ContainerApp
+}
+
+func NewWorkspaceHandler(app *app.ContainerApp) *WorkspaceHandler {
+	return &WorkspaceHandler{app: app}
+}
+
+func (h *WorkspaceHandler) PlanHandler(c *gin.Context) {
+	var req domain.WorkspaceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx := context.Background()
+	plan, err := h.app.PlanWorkspace(ctx, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"plan": plan})
+}
+
+func (h *WorkspaceHandler) ApplyHandler(c *gin.Context) {
+	var req domain.WorkspaceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx := context.Background()
+	plan, err := h.app.ApplyWorkspace(ctx, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"plan": plan})
+}
@@ -0,0 +1,21 @@
+package workspace
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+type WorkspaceRouter struct {
+	handler *WorkspaceHandler
+}
+
+func NewWorkspaceRouter(handler *WorkspaceHandler) *WorkspaceRouter {
+	return &WorkspaceRouter{handler: handler}
+}
+
+func (r *WorkspaceRouter) SetupWorkspaceRouter(router *gin.RouterGroup) {
+	api := router.Group("/workspace")
+	{
+		api.POST("/plan", r.handler.PlanHandler)
+		api.POST("/apply", r.handler.ApplyHandler)
+	}
+}